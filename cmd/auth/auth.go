@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ReaderRole is allowed to retrieve news and trends
+	ReaderRole = "reader"
+
+	// AdminRole is allowed everything the reader is, and to manage sources and see stats of the server
+	AdminRole = "admin"
+
+	// APIKeyHeader is the header with the API key of the client
+	APIKeyHeader = "X-API-Key"
+
+	// PrincipalKey is the key of the authenticated Principal in gin.Context
+	PrincipalKey = "principal"
+)
+
+var (
+	// ErrMissingCredentials is returned, when the request has neither API key nor bearer token
+	ErrMissingCredentials = errors.New("missing credentials: send X-API-Key header or Authorization: Bearer token")
+
+	// ErrInvalidAPIKey is returned, when the API key is not known
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrUnknownRole is returned, when the role is not one of the supported ones
+	ErrUnknownRole = fmt.Errorf("unknown role, supported roles are %s and %s", ReaderRole, AdminRole)
+
+	// ErrForbidden is returned, when the role of the client is not allowed to make the request
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNoCredentials is returned, when neither API keys nor token secret are configured,
+	// and authentication is not disabled explicitly
+	ErrNoCredentials = errors.New("API keys or token secret are required, unless authentication is disabled")
+
+	// ErrDisabledWithCredentials is returned, when authentication is disabled, but credentials are configured
+	ErrDisabledWithCredentials = errors.New("API keys or token secret are configured, but authentication is disabled")
+
+	// roleLevels orders roles: a role is allowed everything roles of lower levels are
+	roleLevels = map[string]int{
+		ReaderRole: 1,
+		AdminRole:  2,
+	}
+)
+
+// Principal is an authenticated client
+type Principal struct {
+	Name string
+	Role string
+}
+
+// Authenticator verifies credentials of requests
+type Authenticator struct {
+	// keys map SHA-256 hashes of API keys to their clients
+	keys map[[sha256.Size]byte]Principal
+
+	// secret signs bearer tokens
+	secret []byte
+
+	// now returns the current time, which expiration of tokens is checked against
+	now func() time.Time
+
+	// disabled allows every request
+	disabled bool
+}
+
+// New creates an Authenticator of the credentials. Roles of API keys are validated.
+// Credentials are required, unless authentication is disabled, and then they are not allowed.
+func New(config Config) (*Authenticator, error) {
+	hasCredentials := len(config.APIKeys) > 0 || config.TokenSecret != ""
+	switch {
+	case config.Disabled && hasCredentials:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, ErrDisabledWithCredentials)
+	case config.Disabled:
+		return &Authenticator{disabled: true}, nil
+	case !hasCredentials:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, ErrNoCredentials)
+	}
+
+	a := &Authenticator{
+		keys:   make(map[[sha256.Size]byte]Principal, len(config.APIKeys)),
+		secret: []byte(config.TokenSecret),
+		now:    time.Now,
+	}
+
+	for _, key := range config.APIKeys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("%w: API key %q is empty", ErrInvalidConfig, key.Name)
+		}
+		if !isRole(key.Role) {
+			return nil, fmt.Errorf("%w: API key %q: %w", ErrInvalidConfig, key.Name, ErrUnknownRole)
+		}
+
+		a.keys[sha256.Sum256([]byte(key.Key))] = Principal{Name: key.Name, Role: key.Role}
+	}
+
+	return a, nil
+}
+
+// Disabled reports whether authentication is disabled explicitly, so every request is allowed
+func (a *Authenticator) Disabled() bool {
+	return a != nil && a.disabled
+}
+
+// Authenticate returns the client, which sent the request, by its API key or bearer token.
+// Nil Authenticator has no credentials, so it rejects every request.
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	if a == nil {
+		return Principal{}, ErrNoCredentials
+	}
+
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return a.authenticateKey(key)
+	}
+
+	authorization := r.Header.Get("Authorization")
+	token, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || token == "" {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	if len(a.secret) == 0 {
+		return Principal{}, ErrInvalidToken
+	}
+
+	claims, err := ParseToken(a.secret, strings.TrimSpace(token), a.now())
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if !isRole(claims.Role) {
+		return Principal{}, ErrUnknownRole
+	}
+
+	return Principal{Name: claims.Subject, Role: claims.Role}, nil
+}
+
+// Require returns middleware, which allows only requests of clients with the role, or with a higher one.
+// Missing and invalid credentials are rejected with 401 Unauthorized, and insufficient role with 403 Forbidden.
+// Only if authentication is disabled explicitly, every request is allowed.
+func (a *Authenticator) Require(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a.Disabled() {
+			c.Next()
+			return
+		}
+
+		principal, err := a.Authenticate(c.Request)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="go-gator"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if roleLevels[principal.Role] < roleLevels[role] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": fmt.Sprintf("%s: %s role is required", ErrForbidden, role),
+			})
+			return
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Next()
+	}
+}
+
+// authenticateKey returns the client of the API key. Keys are compared in constant time.
+func (a *Authenticator) authenticateKey(key string) (Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	for known, principal := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], known[:]) == 1 {
+			return principal, nil
+		}
+	}
+
+	return Principal{}, ErrInvalidAPIKey
+}
+
+// isRole reports whether the role is supported
+func isRole(role string) bool {
+	_, exists := roleLevels[role]
+	return exists
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testConfig = Config{
+	APIKeys: []APIKey{
+		{Name: "operator", Role: AdminRole, Key: "admin-key"},
+		{Name: "dashboard", Role: ReaderRole, Key: "reader-key"},
+	},
+	TokenSecret: "secret",
+}
+
+func TestNew(t *testing.T) {
+	a, err := New(testConfig)
+	assert.Nil(t, err)
+	assert.False(t, a.Disabled())
+
+	_, err = New(Config{})
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	a, err = New(Config{Disabled: true})
+	assert.Nil(t, err)
+	assert.True(t, a.Disabled())
+
+	_, err = New(Config{TokenSecret: "secret", Disabled: true})
+	assert.ErrorIs(t, err, ErrDisabledWithCredentials)
+
+	_, err = New(Config{APIKeys: []APIKey{{Name: "cli", Role: "root", Key: "key"}}})
+	assert.ErrorIs(t, err, ErrUnknownRole)
+
+	_, err = New(Config{APIKeys: []APIKey{{Name: "cli", Role: ReaderRole}}})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+	a, err := New(testConfig)
+	assert.Nil(t, err)
+
+	now := time.Now()
+	readerToken, err := IssueToken([]byte(testConfig.TokenSecret), "cli", ReaderRole, time.Hour, now)
+	assert.Nil(t, err)
+	adminToken, err := IssueToken([]byte(testConfig.TokenSecret), "ci", AdminRole, time.Hour, now)
+	assert.Nil(t, err)
+	expiredToken, err := IssueToken([]byte(testConfig.TokenSecret), "cli", AdminRole, time.Hour, now.Add(-2*time.Hour))
+	assert.Nil(t, err)
+	foreignToken, err := IssueToken([]byte("another secret"), "cli", AdminRole, time.Hour, now)
+	assert.Nil(t, err)
+
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	server.GET("/news", a.Require(ReaderRole), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	server.DELETE("/admin/sources", a.Require(AdminRole), func(c *gin.Context) {
+		principal := c.MustGet(PrincipalKey).(Principal)
+		c.String(http.StatusOK, principal.Name)
+	})
+
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		headers    map[string]string
+		statusCode int
+	}{
+		{"Reader without credentials", http.MethodGet, "/news", nil, http.StatusUnauthorized},
+		{"Reader with reader key", http.MethodGet, "/news", map[string]string{APIKeyHeader: "reader-key"}, http.StatusOK},
+		{"Reader with admin key", http.MethodGet, "/news", map[string]string{APIKeyHeader: "admin-key"}, http.StatusOK},
+		{"Reader with unknown key", http.MethodGet, "/news", map[string]string{APIKeyHeader: "guess"}, http.StatusUnauthorized},
+		{"Reader with token", http.MethodGet, "/news", map[string]string{"Authorization": "Bearer " + readerToken}, http.StatusOK},
+		{"Admin with reader key", http.MethodDelete, "/admin/sources", map[string]string{APIKeyHeader: "reader-key"}, http.StatusForbidden},
+		{"Admin with reader token", http.MethodDelete, "/admin/sources", map[string]string{"Authorization": "Bearer " + readerToken}, http.StatusForbidden},
+		{"Admin with admin key", http.MethodDelete, "/admin/sources", map[string]string{APIKeyHeader: "admin-key"}, http.StatusOK},
+		{"Admin with admin token", http.MethodDelete, "/admin/sources", map[string]string{"Authorization": "Bearer " + adminToken}, http.StatusOK},
+		{"Admin with expired token", http.MethodDelete, "/admin/sources", map[string]string{"Authorization": "Bearer " + expiredToken}, http.StatusUnauthorized},
+		{"Admin with token of another secret", http.MethodDelete, "/admin/sources", map[string]string{"Authorization": "Bearer " + foreignToken}, http.StatusUnauthorized},
+		{"Admin with basic auth", http.MethodDelete, "/admin/sources", map[string]string{"Authorization": "Basic YWRtaW46YWRtaW4="}, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.url, nil)
+			for header, value := range tc.headers {
+				req.Header.Set(header, value)
+			}
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+			if tc.statusCode == http.StatusUnauthorized {
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthenticator_RequireDisabled(t *testing.T) {
+	disabled, err := New(Config{Disabled: true})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		authenticator *Authenticator
+		statusCode    int
+	}{
+		{"Disabled authentication allows every request", disabled, http.StatusOK},
+		{"Authenticator without credentials rejects every request", nil, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			server := gin.New()
+			server.DELETE("/admin/sources", tc.authenticator.Require(AdminRole), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodDelete, "/admin/sources", nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// EnvConfigFile is the environment variable with the path to the JSON file with credentials
+	EnvConfigFile = "GOGATOR_AUTH_FILE"
+
+	// EnvAPIKeys is the environment variable with API keys in the format "name:role:key,name:role:key".
+	// They are added to the keys of the file.
+	EnvAPIKeys = "GOGATOR_API_KEYS"
+
+	// EnvTokenSecret is the environment variable with the secret of bearer tokens. It overrides the file.
+	EnvTokenSecret = "GOGATOR_TOKEN_SECRET"
+)
+
+var (
+	// ErrInvalidConfig is returned, when credentials can not be loaded
+	ErrInvalidConfig = errors.New("invalid auth config")
+)
+
+// Config holds credentials, which clients are authenticated with.
+//
+// Example of the file:
+//
+//	{
+//	  "apiKeys": [
+//	    {"name": "operator", "role": "admin", "key": "5f2b..."},
+//	    {"name": "dashboard", "role": "reader", "key": "9c1e..."}
+//	  ],
+//	  "tokenSecret": "a long random string"
+//	}
+//
+// Without API keys and token secret every request is rejected, unless Disabled is set explicitly.
+type Config struct {
+	APIKeys     []APIKey `json:"apiKeys"`
+	TokenSecret string   `json:"tokenSecret"`
+
+	// Disabled allows every request without credentials. API keys and token secret can not be set with it.
+	Disabled bool `json:"-"`
+}
+
+// APIKey is a static key of the client with the role
+type APIKey struct {
+	Name string `json:"name" yaml:"name"`
+	Role string `json:"role" yaml:"role"`
+	Key  string `json:"key" yaml:"key"`
+}
+
+// LoadConfig reads credentials from the JSON file and environment variables.
+// If path is empty, the file of EnvConfigFile is read, and if it is not set either, only environment is used.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+
+		err = json.Unmarshal(data, &config)
+		if err != nil {
+			return Config{}, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+		}
+	}
+
+	if keys := os.Getenv(EnvAPIKeys); keys != "" {
+		for _, entry := range strings.Split(keys, ",") {
+			parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+			if len(parts) != 3 {
+				return Config{}, fmt.Errorf("%w: %s should contain name:role:key entries", ErrInvalidConfig, EnvAPIKeys)
+			}
+
+			config.APIKeys = append(config.APIKeys, APIKey{Name: parts[0], Role: parts[1], Key: parts[2]})
+		}
+	}
+
+	if secret := os.Getenv(EnvTokenSecret); secret != "" {
+		config.TokenSecret = secret
+	}
+
+	return config, nil
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	err := os.WriteFile(path, []byte(`{
+		"apiKeys": [{"name": "operator", "role": "admin", "key": "admin-key"}],
+		"tokenSecret": "file secret"
+	}`), 0600)
+	assert.Nil(t, err)
+
+	config, err := LoadConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, Config{
+		APIKeys:     []APIKey{{Name: "operator", Role: AdminRole, Key: "admin-key"}},
+		TokenSecret: "file secret",
+	}, config)
+
+	t.Setenv(EnvAPIKeys, "dashboard:reader:reader:key")
+	t.Setenv(EnvTokenSecret, "env secret")
+
+	config, err = LoadConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, Config{
+		APIKeys: []APIKey{
+			{Name: "operator", Role: AdminRole, Key: "admin-key"},
+			{Name: "dashboard", Role: ReaderRole, Key: "reader:key"},
+		},
+		TokenSecret: "env secret",
+	}, config)
+
+	t.Setenv(EnvConfigFile, path)
+	config, err = LoadConfig("")
+	assert.Nil(t, err)
+	assert.Len(t, config.APIKeys, 2)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	err = os.WriteFile(path, []byte(`{"apiKeys": `), 0600)
+	assert.Nil(t, err)
+	_, err = LoadConfig(path)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	t.Setenv(EnvAPIKeys, "operator-without-role")
+	_, err = LoadConfig("")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
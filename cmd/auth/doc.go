@@ -0,0 +1,10 @@
+// Package auth is used to authenticate clients of the server and authorize their requests by roles.
+//
+// Clients are authenticated by static API keys, sent in X-API-Key header, or by bearer tokens, signed
+// with HMAC-SHA256 by a secret shared with the server (JWT in HS256 format), sent in Authorization header.
+// Both are loaded from a JSON file and environment variables, so no external identity provider is needed.
+//
+// Every client has a role: reader is allowed to retrieve news, and admin is also allowed to manage sources.
+// If neither API keys nor the token secret are configured, every request is rejected, unless authentication
+// is disabled explicitly.
+package auth
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned, when the token is malformed or its signature is wrong
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrExpiredToken is returned, when the token is expired
+	ErrExpiredToken = errors.New("token is expired")
+
+	// tokenHeader is the encoded header of all tokens: they are signed with HMAC-SHA256
+	tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+)
+
+// Claims are the payload of the token
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// IssueToken creates a token of the subject with the role, signed by the secret.
+// Zero ttl means the token never expires.
+func IssueToken(secret []byte, subject, role string, ttl time.Duration, now time.Time) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("token secret is not set")
+	}
+
+	if !isRole(role) {
+		return "", ErrUnknownRole
+	}
+
+	claims := Claims{Subject: subject, Role: role, IssuedAt: now.Unix()}
+	if ttl > 0 {
+		claims.ExpiresAt = now.Add(ttl).Unix()
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + sign(secret, unsigned), nil
+}
+
+// ParseToken verifies the signature and expiration of the token, and returns its claims
+func ParseToken(secret []byte, token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Claims{}, ErrInvalidToken
+	}
+
+	expected := sign(secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+// sign returns encoded HMAC-SHA256 of the value
+func sign(secret []byte, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
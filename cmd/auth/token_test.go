@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssueToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+
+	token, err := IssueToken(secret, "operator", AdminRole, time.Hour, now)
+	assert.Nil(t, err)
+	assert.Len(t, strings.Split(token, "."), 3)
+
+	claims, err := ParseToken(secret, token, now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, Claims{Subject: "operator", Role: AdminRole, IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()}, claims)
+
+	_, err = ParseToken(secret, token, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrExpiredToken)
+
+	_, err = ParseToken([]byte("another secret"), token, now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	parts := strings.Split(token, ".")
+	forged, err := IssueToken(secret, "operator", ReaderRole, 0, now)
+	assert.Nil(t, err)
+	_, err = ParseToken(secret, parts[0]+"."+strings.Split(forged, ".")[1]+"."+parts[2], now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "payload of another token does not match the signature")
+
+	_, err = ParseToken(secret, "not a token", now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = IssueToken(secret, "operator", "root", 0, now)
+	assert.ErrorIs(t, err, ErrUnknownRole)
+
+	_, err = IssueToken(nil, "operator", AdminRole, 0, now)
+	assert.NotNil(t, err)
+}
+
+func TestIssueToken_WithoutExpiration(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+
+	token, err := IssueToken(secret, "dashboard", ReaderRole, 0, now)
+	assert.Nil(t, err)
+
+	claims, err := ParseToken(secret, token, now.AddDate(10, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, ReaderRole, claims.Role)
+}
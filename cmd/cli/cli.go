@@ -5,7 +5,7 @@ import (
 	"log"
 )
 
-// InitNewsAggregatorCmd initializes root cmd and attaches fetchNews command to our main command
+// InitNewsAggregatorCmd initializes root cmd and attaches fetchNews, trends and token commands to our main command
 func InitNewsAggregatorCmd() *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use:   "go-gator",
@@ -20,6 +20,8 @@ func InitNewsAggregatorCmd() *cobra.Command {
 		},
 	}
 	rootCmd.AddCommand(FetchNewsCmd())
+	rootCmd.AddCommand(TrendsCmd())
+	rootCmd.AddCommand(TokenCmd())
 
 	return rootCmd
 }
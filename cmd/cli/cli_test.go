@@ -19,11 +19,17 @@ func TestInitRootCmd(t *testing.T) {
 
 	// Verify subcommands
 	subCmd := cmd.Commands()
-	assert.Equal(t, 1, len(subCmd), "There should be one subcommand")
+	assert.Equal(t, 3, len(subCmd), "There should be three subcommands")
 
 	fetchNewsCmd := subCmd[0]
 	assert.Equal(t, "fetch", fetchNewsCmd.Use, "Subcommand use should be 'fetch-news'")
 	assert.Equal(t, "Fetching news from downloaded data", fetchNewsCmd.Short, "Subcommand short description should match")
+
+	tokenCmd := subCmd[1]
+	assert.Equal(t, "token", tokenCmd.Use, "Subcommand use should be 'token'")
+
+	trendsCmd := subCmd[2]
+	assert.Equal(t, "trends", trendsCmd.Use, "Subcommand use should be 'trends'")
 	reflect.DeepEqual(cmd.Run, func(cmd *cobra.Command, args []string) {
 		log.Println("[Go Gator] is a news fetching tool build in golang\n",
 			"Fetch news from multiple sources by running command `fetch`")
@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"context"
 	"github.com/spf13/cobra"
+	"gogator/cmd/dedup"
 	"gogator/cmd/filters"
 	"gogator/cmd/parsers"
+	"gogator/cmd/search"
 	"gogator/cmd/templates"
 	"gogator/cmd/types"
 	"gogator/cmd/validator"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 )
 
 const (
@@ -15,6 +22,14 @@ const (
 	DateFromFlag = "date-from"
 	DateEndFlag  = "date-end"
 	SourcesFlag  = "sources"
+	StrictFlag   = "strict"
+	CollapseFlag = "collapse"
+	SortFlag     = "sort"
+	MatchFlag    = "match"
+	TimezoneFlag = "tz"
+	CategoryFlag = "category"
+	AuthorFlag   = "author"
+	LanguageFlag = "lang"
 )
 
 // FetchNewsCmd initializes and returns command to fetch news
@@ -22,17 +37,34 @@ const (
 //
 // It accepts few flags: keywords, date-from, date-end, and sources.
 // All of them will be used to filter retrieved news, if asked:
-// Filtering by keyword will remove all articles that do not contain provided keywords. Should be separated by ','
+// Filtering by keyword will remove all articles that do not match the keywords query: terms separated by ',' or OR
+// are alternatives, terms separated by spaces or AND are all required, and '-' or NOT excludes the term.
+// Queries also support parentheses, "quoted phrases" and fields: title:, description: and source:
 // Date-From and Date-End are used to validate article publishing date: it will be included if it falls in range
-// specified ones
+// specified ones. They can be days, RFC 3339 timestamps, times of today, or relative dates like -6h, today or 7d.
+// Tz flag sets the time zone, which days and times without offset are interpreted in. By default, it is UTC.
 // Sources flag will be defining from what sources you want to get articles from: ABC, BBC, Usa Today, Washington Times
 // or all from above.
+// Strict flag makes the command fail if any of the sources can not be fetched. By default, articles from
+// healthy sources are displayed, together with the fetching result of every source.
+// Collapse flag merges near-identical articles of different publishers into a single one.
+// Category, author and lang flags retrieve news of the categories, authors and languages (ISO 639-1 codes, e.g. en,uk).
+// Match flag selects how keywords are matched: case-insensitive (default), exact, whole-word or regex.
+// Sort flag set to relevance displays only articles matching the keywords, from the most relevant one, with their scores.
 func FetchNewsCmd() *cobra.Command {
 	fetchNews := &cobra.Command{}
-	fetchNews.Flags().String(KeywordFlag, "", "Topic on which news will be fetched (if empty, all news will be fetched, regardless of the theme). Separate them with ',' ")
-	fetchNews.Flags().String(DateFromFlag, "", "Retrieve news based on their published date | Format 2024-05-24")
-	fetchNews.Flags().String(DateEndFlag, "", "Retrieve news, where published date is not more then this value | Format 2024-05-24")
+	fetchNews.Flags().String(KeywordFlag, "", "Query on which news will be fetched (if empty, all news will be fetched, regardless of the theme), e.g. '(Ukraine OR Poland) -Russia'")
+	fetchNews.Flags().String(DateFromFlag, "", "Retrieve news based on their published date | Format 2024-05-24, 2024-05-24T09:00:00Z, 09:00, today or -6h")
+	fetchNews.Flags().String(DateEndFlag, "", "Retrieve news, where published date is not more then this value | Format 2024-05-24, 2024-05-24T09:00:00Z, 09:00, today or -6h")
+	fetchNews.Flags().String(TimezoneFlag, "", "Time zone of dates without offset, e.g. Europe/Kyiv (UTC, if empty)")
 	fetchNews.Flags().String(SourcesFlag, "", "Supported sources: [abc, bbc, nbc, usatoday, washingtontimes, all]")
+	fetchNews.Flags().Bool(StrictFlag, false, "Fail if any of the sources can not be fetched")
+	fetchNews.Flags().Bool(CollapseFlag, false, "Show near-identical articles of different publishers as a single one")
+	fetchNews.Flags().String(MatchFlag, "", "How keywords are matched: case-insensitive (if empty), exact, whole-word or regex")
+	fetchNews.Flags().String(CategoryFlag, "", "Retrieve news of any of the categories, separated by ',' (case-insensitive)")
+	fetchNews.Flags().String(AuthorFlag, "", "Retrieve news of any of the authors, separated by ',' (case-insensitive, part of the name is enough)")
+	fetchNews.Flags().String(LanguageFlag, "", "Retrieve news in any of the languages, separated by ',' (ISO 639-1 codes, e.g. en,uk)")
+	fetchNews.Flags().String(SortFlag, "", "Order of news: date_desc (default), date_asc, source or relevance to the keywords")
 
 	fetchNews.Use = "fetch"
 	fetchNews.Short = "Fetching news from downloaded data"
@@ -67,22 +99,102 @@ func FetchNewsCmd() *cobra.Command {
 			log.Fatalln(err)
 		}
 
+		strict, err := cmd.Flags().GetBool(StrictFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		collapse, err := cmd.Flags().GetBool(CollapseFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		sort, err := cmd.Flags().GetString(SortFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		matchMode, err := cmd.Flags().GetString(MatchFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		timezone, err := cmd.Flags().GetString(TimezoneFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		categories, err := cmd.Flags().GetString(CategoryFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		authors, err := cmd.Flags().GetString(AuthorFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		lang, err := cmd.Flags().GetString(LanguageFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
 		v := validator.ArgValidator{}
-		err = v.Validate(sources, dateFrom, dateEnd)
+		err = v.Validate(keywords, matchMode, sources, dateFrom, dateEnd, timezone)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		err = validator.BySort(sort, keywords)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		err = validator.ByLanguage(lang)
 		if err != nil {
 			log.Fatalln(err)
 		}
 
 		f := types.NewFilteringParams(keywords, dateFrom, dateEnd, sources)
+		f.Sort = sort
+		f.MatchMode = matchMode
+		f.Timezone = timezone
+		f.Categories = categories
+		f.Authors = authors
+		f.Language = lang
+
+		err = filters.NormalizeDateRange(f, time.Now())
+		if err != nil {
+			log.Fatalln(err)
+		}
 
-		news, err := parsers.ParseBySource(sources)
+		// interrupting the command cancels requests, which are still in progress
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		news, results, err := parsers.FetchBySource(ctx, sources, strict)
 		if err != nil {
 			log.Fatalln("Error parsing news: ", err)
 		}
 
-		news = filters.Apply(news, f)
+		if f.Sort == types.RelevanceSort {
+			news = rankByRelevance(news, f)
+		} else {
+			news = filters.Apply(news, f)
+		}
+		if collapse {
+			news = dedup.Collapse(news)
+		}
 
-		err = templates.PrintTemplate(f, news)
+		err = templates.PrintTemplate(f, news, results)
 		if err != nil {
 			log.Fatalln(err)
 		}
@@ -90,3 +202,19 @@ func FetchNewsCmd() *cobra.Command {
 
 	return fetchNews
 }
+
+// rankByRelevance filters news by params, and ranks them by relevance to terms of the keywords query
+// using an index built over the fetched news
+func rankByRelevance(news []types.Article, params *types.FilteringParams) []types.Article {
+	news = filters.Apply(news, params)
+
+	query, err := filters.ParseQuery(params.Keywords, params.MatchMode)
+	if err != nil {
+		return news
+	}
+
+	index := search.NewIndex()
+	index.Add(news...)
+
+	return search.Rank(news, index.Score(strings.Join(filters.Terms(query), " ")))
+}
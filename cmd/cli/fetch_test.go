@@ -48,7 +48,7 @@ func TestAddFetchNewsCmd(t *testing.T) {
 		}
 
 		v := &validator.ArgValidator{}
-		err = v.Validate(sources, dateFrom, dateEnd)
+		err = v.Validate(keywords, "", sources, dateFrom, dateEnd, "")
 		if err != nil {
 			log.Fatalln(err)
 		}
@@ -62,7 +62,7 @@ func TestAddFetchNewsCmd(t *testing.T) {
 
 		news = filters.Apply(news, f)
 
-		err = templates.PrintTemplate(f, news)
+		err = templates.PrintTemplate(f, news, nil)
 		if err != nil {
 			log.Fatalln(err)
 		}
@@ -78,5 +78,10 @@ func TestAddFetchNewsCmd(t *testing.T) {
 	assert.NotNil(t, fetchNews.Flags().Lookup("date-from"), "Flag 'date-from' should be defined")
 	assert.NotNil(t, fetchNews.Flags().Lookup("date-end"), "Flag 'date-end' should be defined")
 	assert.NotNil(t, fetchNews.Flags().Lookup("sources"), "Flag 'sources' should be defined")
+	assert.NotNil(t, fetchNews.Flags().Lookup("strict"), "Flag 'strict' should be defined")
+	assert.NotNil(t, fetchNews.Flags().Lookup("collapse"), "Flag 'collapse' should be defined")
+	assert.NotNil(t, fetchNews.Flags().Lookup("sort"), "Flag 'sort' should be defined")
+	assert.NotNil(t, fetchNews.Flags().Lookup("match"), "Flag 'match' should be defined")
+	assert.NotNil(t, fetchNews.Flags().Lookup("tz"), "Flag 'tz' should be defined")
 	reflect.DeepEqual(fetchNews.Run, runFunc)
 }
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gogator/cmd/auth"
+	"gogator/cmd/filters"
+	"gogator/cmd/validator"
+	"log"
+	"time"
+)
+
+const (
+	SubjectFlag  = "subject"
+	RoleFlag     = "role"
+	TTLFlag      = "ttl"
+	AuthFileFlag = "auth"
+)
+
+// TokenCmd initializes and returns command to issue bearer tokens for the server
+//
+// Tokens are signed by the token secret of the server, which is read the same way the server reads it:
+// from the file of auth flag, or GOGATOR_AUTH_FILE, and from GOGATOR_TOKEN_SECRET environment variable.
+// Role flag sets the role of the token: reader (default) or admin.
+// TTL flag sets how long the token is valid, e.g. 12h, 30d or 0 for a token, which never expires.
+func TokenCmd() *cobra.Command {
+	tokenCmd := &cobra.Command{}
+
+	tokenCmd.Flags().String(SubjectFlag, "", "Name of the client, which the token is issued for")
+	tokenCmd.Flags().String(RoleFlag, auth.ReaderRole, "Role of the client: reader or admin")
+	tokenCmd.Flags().String(TTLFlag, "30d", "How long the token is valid, e.g. 12h or 30d. 0 means forever")
+	tokenCmd.Flags().String(AuthFileFlag, "", "Path to JSON file with the token secret of the server")
+
+	tokenCmd.Use = "token"
+	tokenCmd.Short = "Issue a bearer token for the server"
+	tokenCmd.Long = "This command issues a token, signed with the token secret of the server, " +
+		"which clients send in Authorization header"
+
+	tokenCmd.Run = func(cmd *cobra.Command, args []string) {
+		subject, err := cmd.Flags().GetString(SubjectFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		role, err := cmd.Flags().GetString(RoleFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		ttlValue, err := cmd.Flags().GetString(TTLFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		authFile, err := cmd.Flags().GetString(AuthFileFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		token, err := issueToken(subject, role, ttlValue, authFile, time.Now())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		fmt.Println(token)
+	}
+
+	return tokenCmd
+}
+
+// issueToken issues the token of the subject with the secret of the auth config
+func issueToken(subject, role, ttlValue, authFile string, now time.Time) (string, error) {
+	if subject == "" {
+		return "", errors.New("subject of the token is required")
+	}
+
+	var ttl time.Duration
+	if ttlValue != "0" {
+		var err error
+		ttl, err = filters.ParseDuration(ttlValue)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	config, err := auth.LoadConfig(authFile)
+	if err != nil {
+		return "", err
+	}
+
+	return auth.IssueToken([]byte(config.TokenSecret), subject, role, ttl, now)
+}
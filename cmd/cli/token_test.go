@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/auth"
+	"testing"
+	"time"
+)
+
+func TestIssueToken(t *testing.T) {
+	now := time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+	t.Setenv(auth.EnvTokenSecret, "secret")
+
+	token, err := issueToken("dashboard", auth.AdminRole, "12h", "", now)
+	assert.Nil(t, err)
+
+	claims, err := auth.ParseToken([]byte("secret"), token, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "dashboard", claims.Subject)
+	assert.Equal(t, auth.AdminRole, claims.Role)
+	assert.Equal(t, now.Add(12*time.Hour).Unix(), claims.ExpiresAt)
+
+	token, err = issueToken("dashboard", auth.ReaderRole, "0", "", now)
+	assert.Nil(t, err)
+	claims, err = auth.ParseToken([]byte("secret"), token, now)
+	assert.Nil(t, err)
+	assert.Zero(t, claims.ExpiresAt)
+
+	_, err = issueToken("", auth.ReaderRole, "12h", "", now)
+	assert.NotNil(t, err)
+
+	_, err = issueToken("dashboard", auth.ReaderRole, "soon", "", now)
+	assert.NotNil(t, err)
+
+	_, err = issueToken("dashboard", "root", "12h", "", now)
+	assert.ErrorIs(t, err, auth.ErrUnknownRole)
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	"gogator/cmd/filters"
+	"gogator/cmd/parsers"
+	"gogator/cmd/templates"
+	"gogator/cmd/trends"
+	"gogator/cmd/types"
+	"gogator/cmd/validator"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+)
+
+const (
+	WindowFlag   = "window"
+	BaselineFlag = "baseline"
+	LimitFlag    = "limit"
+)
+
+// TrendsCmd initializes and returns command to display trending terms of the latest news
+//
+// It fetches news like the fetch command does, and compares how many articles mentioned every term
+// during the last window with the amount predicted by the baseline period before it.
+// Window and baseline flags accept durations like 6h, 30m, 7d or 2w.
+// Baseline is limited to news, which feeds still publish, since the command does not read stored articles.
+// Sources and lang flags retrieve trends of news of the sources and languages only.
+// Limit flag sets the maximum amount of displayed trends.
+func TrendsCmd() *cobra.Command {
+	trendsCmd := &cobra.Command{}
+
+	trendsCmd.Flags().String(WindowFlag, "", "Period of trends, e.g. 6h (default), 30m or 2d")
+	trendsCmd.Flags().String(BaselineFlag, "", "Period before the window, which usual frequency of terms is computed for, e.g. 7d (default)")
+	trendsCmd.Flags().String(SourcesFlag, "", "Supported sources: [abc, bbc, nbc, usatoday, washingtontimes, all]")
+	trendsCmd.Flags().String(LanguageFlag, "", "Retrieve trends of news in any of the languages, separated by ',' (ISO 639-1 codes, e.g. en,uk)")
+	trendsCmd.Flags().Int(LimitFlag, trends.DefaultLimit, "Maximum amount of trends")
+	trendsCmd.Flags().Bool(StrictFlag, false, "Fail if any of the sources can not be fetched")
+
+	trendsCmd.Use = "trends"
+	trendsCmd.Short = "Display trending topics of the latest news"
+	trendsCmd.Long = "This command fetches news, and displays terms and named entities, which are mentioned more often " +
+		"in the last window than usual, together with examples of articles"
+
+	trendsCmd.Run = func(cmd *cobra.Command, args []string) {
+		window, err := cmd.Flags().GetString(WindowFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		baseline, err := cmd.Flags().GetString(BaselineFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		sources, err := cmd.Flags().GetString(SourcesFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		lang, err := cmd.Flags().GetString(LanguageFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		limit, err := cmd.Flags().GetInt(LimitFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		strict, err := cmd.Flags().GetBool(StrictFlag)
+		err = validator.CheckFlagErr(err)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		params, err := trends.NewParams(window, baseline, strconv.Itoa(limit), time.Now())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		err = validator.BySources(sources)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		err = validator.ByLanguage(lang)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		// interrupting the command cancels requests, which are still in progress
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		news, results, err := parsers.FetchBySource(ctx, sources, strict)
+		if err != nil {
+			log.Fatalln("Error parsing news: ", err)
+		}
+
+		f := types.NewFilteringParams("", "", "", sources)
+		f.Language = lang
+		news = filters.Apply(news, f)
+
+		err = templates.PrintTrends(params, len(news), trends.Compute(news, params), results)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	return trendsCmd
+}
@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/trends"
+	"testing"
+)
+
+func TestTrendsCmd(t *testing.T) {
+	cmd := TrendsCmd()
+
+	assert.Equal(t, "trends", cmd.Use)
+	for _, flag := range []string{WindowFlag, BaselineFlag, SourcesFlag, LanguageFlag, LimitFlag, StrictFlag} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), flag)
+	}
+
+	limit, err := cmd.Flags().GetInt(LimitFlag)
+	assert.Nil(t, err)
+	assert.Equal(t, trends.DefaultLimit, limit)
+}
@@ -0,0 +1,199 @@
+package cluster
+
+import (
+	"gogator/cmd/search"
+	"gogator/cmd/types"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SimilarityThreshold is the minimal cosine similarity of the article to the centroid of the story,
+	// at which the article is considered to be about the story
+	SimilarityThreshold = 0.35
+
+	// titleBoost is how many times terms of the title are counted, since the title describes the story best
+	titleBoost = 2
+)
+
+// Story is a group of articles about the same event.
+// It has several fields:
+// /  1. ID         - ID of the representative article
+// /  2. Headline   - Title of the representative article: the one, which is the most similar to the whole story
+// /  3. Publishers - Sorted publishers of articles of the story
+// /  4. Size       - Amount of articles of the story
+// /  5. FirstPublishedAt, LastPublishedAt - Publication dates of the oldest and the newest articles
+// /  6. Articles   - Articles of the story, in the order they were given
+type Story struct {
+	ID               string          `json:"id"`
+	Headline         string          `json:"headline"`
+	Publishers       []string        `json:"publishers"`
+	Size             int             `json:"size"`
+	FirstPublishedAt time.Time       `json:"firstPublishedAt"`
+	LastPublishedAt  time.Time       `json:"lastPublishedAt"`
+	Articles         []types.Article `json:"articles"`
+}
+
+// vector is a sparse vector of term weights
+type vector map[string]float64
+
+// group is a story, which is being built
+type group struct {
+	members  []int
+	centroid vector
+}
+
+// Group clusters articles into stories. Stories are ordered by their first article in the given order,
+// so the order of articles, e.g. by date, is kept. Every article belongs to exactly one story.
+func Group(articles []types.Article) []Story {
+	vectors := tfidf(articles)
+
+	var groups []*group
+	for i, v := range vectors {
+		best, bestSimilarity := -1, 0.0
+		for j, g := range groups {
+			similarity := cosine(v, g.centroid)
+			if similarity > bestSimilarity {
+				best, bestSimilarity = j, similarity
+			}
+		}
+
+		if best == -1 || bestSimilarity < SimilarityThreshold {
+			groups = append(groups, &group{members: []int{i}, centroid: copyVector(v)})
+			continue
+		}
+
+		groups[best].members = append(groups[best].members, i)
+		for term, weight := range v {
+			groups[best].centroid[term] += weight
+		}
+	}
+
+	stories := make([]Story, 0, len(groups))
+	for _, g := range groups {
+		stories = append(stories, newStory(articles, vectors, g))
+	}
+
+	return stories
+}
+
+// newStory builds the story of articles of the group
+func newStory(articles []types.Article, vectors []vector, g *group) Story {
+	representative, bestSimilarity := g.members[0], -1.0
+	publishers := make(map[string]bool)
+	story := Story{Size: len(g.members)}
+
+	for _, i := range g.members {
+		article := articles[i]
+		story.Articles = append(story.Articles, article)
+		publishers[article.Publisher] = true
+
+		if story.FirstPublishedAt.IsZero() || article.PublishedAt.Before(story.FirstPublishedAt) {
+			story.FirstPublishedAt = article.PublishedAt
+		}
+		if article.PublishedAt.After(story.LastPublishedAt) {
+			story.LastPublishedAt = article.PublishedAt
+		}
+
+		if similarity := cosine(vectors[i], g.centroid); similarity > bestSimilarity {
+			representative, bestSimilarity = i, similarity
+		}
+	}
+
+	story.ID = articles[representative].ID
+	story.Headline = strings.TrimSpace(articles[representative].Title)
+	for publisher := range publishers {
+		story.Publishers = append(story.Publishers, publisher)
+	}
+	sort.Strings(story.Publishers)
+
+	return story
+}
+
+// tfidf returns TF-IDF vectors of titles and descriptions of articles, normalized to unit length.
+// Inverse document frequency is computed over the given articles, so terms, which all stories share,
+// do not make articles similar.
+func tfidf(articles []types.Article) []vector {
+	frequencies := make([]map[string]float64, len(articles))
+	documentFrequency := make(map[string]int)
+
+	for i, article := range articles {
+		terms := make(map[string]float64)
+		for _, term := range search.AnalyzeLanguage(article.Title, article.Language) {
+			terms[term] += titleBoost
+		}
+		for _, term := range search.AnalyzeLanguage(article.Description, article.Language) {
+			terms[term]++
+		}
+
+		for term := range terms {
+			documentFrequency[term]++
+		}
+		frequencies[i] = terms
+	}
+
+	total := float64(len(articles))
+	vectors := make([]vector, len(articles))
+	for i, terms := range frequencies {
+		v := make(vector, len(terms))
+		for term, frequency := range terms {
+			idf := math.Log((total+1)/(float64(documentFrequency[term])+1)) + 1
+			v[term] = frequency * idf
+		}
+		vectors[i] = normalize(v)
+	}
+
+	return vectors
+}
+
+// normalize scales the vector to unit length. Empty vector is kept as it is.
+func normalize(v vector) vector {
+	norm := 0.0
+	for _, weight := range v {
+		norm += weight * weight
+	}
+	if norm == 0 {
+		return v
+	}
+
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+
+	return v
+}
+
+// cosine returns cosine similarity of two vectors. Empty vectors are not similar to anything.
+func cosine(a, b vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var dot, normA, normB float64
+	for term, weight := range a {
+		dot += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / math.Sqrt(normA*normB)
+}
+
+// copyVector returns a copy of the vector, so the centroid can be changed without changing the vector
+func copyVector(v vector) vector {
+	c := make(vector, len(v))
+	for term, weight := range v {
+		c[term] = weight
+	}
+
+	return c
+}
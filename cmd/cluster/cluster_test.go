@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	now := time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+
+	articles := []types.Article{
+		{
+			ID:          "1",
+			Title:       "Earthquake of magnitude 7 hits southern Japan",
+			Description: "A powerful earthquake struck off the coast of Kyushu, triggering tsunami warnings",
+			Publisher:   "bbc",
+			PublishedAt: now,
+		},
+		{
+			ID:          "2",
+			Title:       "Central bank keeps interest rates unchanged",
+			Description: "The central bank left rates on hold, citing slowing inflation",
+			Publisher:   "abc",
+			PublishedAt: now.Add(-time.Hour),
+		},
+		{
+			ID:          "3",
+			Title:       "Japan earthquake: tsunami warning issued after magnitude 7 quake",
+			Description: "Authorities warned residents of Kyushu to move away from the coast",
+			Publisher:   "abc",
+			PublishedAt: now.Add(-2 * time.Hour),
+		},
+		{
+			ID:          "4",
+			Title:       "Strong earthquake hits Japan, tsunami warning in place",
+			Description: "The earthquake struck southern Japan on Sunday",
+			Publisher:   "usatoday",
+			PublishedAt: now.Add(-3 * time.Hour),
+		},
+		{
+			ID:          "5",
+			Title:       "Football: national team wins the final",
+			Description: "",
+			Publisher:   "bbc",
+			PublishedAt: now.Add(-4 * time.Hour),
+		},
+	}
+
+	stories := Group(articles)
+	assert.Len(t, stories, 3)
+
+	earthquake := stories[0]
+	assert.Equal(t, 3, earthquake.Size)
+	assert.Equal(t, []string{"abc", "bbc", "usatoday"}, earthquake.Publishers)
+	assert.Equal(t, now.Add(-3*time.Hour), earthquake.FirstPublishedAt)
+	assert.Equal(t, now, earthquake.LastPublishedAt)
+	assert.Contains(t, []string{"1", "3", "4"}, earthquake.ID)
+	assert.Equal(t, "1", earthquake.Articles[0].ID, "order of articles is kept")
+
+	assert.Equal(t, "Central bank keeps interest rates unchanged", stories[1].Headline)
+	assert.Equal(t, 1, stories[1].Size)
+	assert.Equal(t, "5", stories[2].ID)
+}
+
+func TestGroup_Empty(t *testing.T) {
+	assert.Empty(t, Group(nil))
+	assert.Len(t, Group([]types.Article{{ID: "1"}, {ID: "2"}}), 2, "articles without terms are separate stories")
+}
+
+func TestCosine(t *testing.T) {
+	assert.InDelta(t, 1.0, cosine(vector{"a": 1, "b": 1}, vector{"a": 2, "b": 2}), 1e-9)
+	assert.Equal(t, 0.0, cosine(vector{"a": 1}, vector{"b": 1}))
+	assert.Equal(t, 0.0, cosine(vector{}, vector{"b": 1}))
+}
@@ -0,0 +1,7 @@
+// Package cluster is used to group articles of different publishers about the same story.
+//
+// Title and description of every article are turned into a TF-IDF vector of terms of the search analyzer,
+// with terms of the title weighing more. Articles are assigned to the story, whose centroid is the most similar
+// to them by cosine similarity, or start a new story, if no story is similar enough. Clustering is done
+// offline, over the given articles only, so results do not depend on any external service.
+package cluster
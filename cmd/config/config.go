@@ -0,0 +1,449 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gogator/cmd/auth"
+	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
+	"gogator/cmd/types"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvConfigFile is the environment variable with the path to the configuration file
+	EnvConfigFile = "GOGATOR_CONFIG"
+
+	// EnvPrefix is the prefix of all environment variables, which override values of the file
+	EnvPrefix = "GOGATOR_"
+
+	// HTTPSMode serves HTTP/1.1 and HTTP/2 over TLS
+	HTTPSMode = "https"
+
+	// HTTPMode serves plain HTTP/1.1, e.g. behind a TLS-terminating ingress
+	HTTPMode = "http"
+
+	// H2CMode serves plain HTTP/1.1 and HTTP/2 without TLS (h2c)
+	H2CMode = "h2c"
+
+	// DebugLevel logs debug output of the router and every request
+	DebugLevel = "debug"
+
+	// InfoLevel logs every request
+	InfoLevel = "info"
+
+	// ErrorLevel logs only errors
+	ErrorLevel = "error"
+
+	// StderrOutput writes logs to the standard error
+	StderrOutput = "stderr"
+
+	// StdoutOutput writes logs to the standard output
+	StdoutOutput = "stdout"
+
+	// redacted replaces secrets in the printed configuration
+	redacted = "<redacted>"
+)
+
+var (
+	// ErrInvalidConfig is returned, when the configuration can not be loaded or is not valid
+	ErrInvalidConfig = errors.New("invalid configuration")
+
+	// defaultCertsPath is the default directory with the certificate and the private key of the server
+	defaultCertsPath = filepath.Join("cmd", "server", "certs")
+
+	// defaultDataDirPath is the default directory, where sources and articles are stored
+	defaultDataDirPath = filepath.Join("cmd", "parsers", "data")
+)
+
+// Config is the configuration of the server.
+//
+// Example of the file:
+//
+//	server:
+//	  address: ":8443"
+//	  mode: https
+//	  drainDelay: 5s
+//	  shutdownTimeout: 20s
+//	tls:
+//	  certFile: /etc/go-gator/tls.crt
+//	  keyFile: /etc/go-gator/tls.key
+//	  clientCAFile: /etc/go-gator/ca.crt
+//	storage:
+//	  path: /var/lib/go-gator
+//	  backend: bolt
+//	fetch:
+//	  connectTimeout: 5s
+//	  readTimeout: 30s
+//	  maxRetries: 3
+//	sources:
+//	  - name: bbc
+//	    format: xml
+//	    endpoint: https://feeds.bbci.co.uk/news/rss.xml
+//	log:
+//	  level: info
+//	auth:
+//	  file: /etc/go-gator/auth.json
+//	  disabled: false
+type Config struct {
+	Server  Server   `yaml:"server"`
+	TLS     TLS      `yaml:"tls"`
+	Storage Storage  `yaml:"storage"`
+	Fetch   Fetch    `yaml:"fetch"`
+	Sources []Source `yaml:"sources,omitempty"`
+	Log     Log      `yaml:"log"`
+	Auth    Auth     `yaml:"auth"`
+}
+
+// Server contains settings of the listener
+type Server struct {
+	// Address is host and port, which the server listens on, e.g. ":443"
+	Address string `yaml:"address"`
+
+	// Mode is the protocol of the listener: https, http or h2c
+	Mode string `yaml:"mode"`
+
+	// DrainDelay is how long the server keeps accepting requests after the shutdown signal, while its
+	// readiness probe fails, so load balancers stop sending new requests before the listener is closed
+	DrainDelay time.Duration `yaml:"drainDelay"`
+
+	// ShutdownTimeout limits time, which requests in flight have to complete after the listener is closed.
+	// Requests, which are still running after it, are cancelled.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+// TLS contains settings of the https mode.
+//
+// The certificate and the key are reloaded, when their files change, so rotated certificates
+// are served without restarting the server.
+type TLS struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+
+	// SelfSigned generates a self-signed certificate in memory, instead of reading CertFile and KeyFile.
+	// It is meant for development only.
+	SelfSigned bool `yaml:"selfSigned,omitempty"`
+
+	// ClientCAFile is a path to certificates of authorities, which sign certificates of clients.
+	// If it is set, admin routes require a client certificate, signed by one of them (mutual TLS).
+	ClientCAFile string `yaml:"clientCAFile,omitempty"`
+}
+
+// Storage describes where sources and articles are kept
+type Storage struct {
+	// Path is the directory with sources and articles
+	Path string `yaml:"path"`
+
+	// Backend is the storage of articles inside of Path: json or bolt
+	Backend string `yaml:"backend"`
+}
+
+// Fetch contains settings of requests to the sources (see parsers.FetcherConfig)
+type Fetch struct {
+	ConnectTimeout time.Duration `yaml:"connectTimeout"`
+	ReadTimeout    time.Duration `yaml:"readTimeout"`
+	MaxRetries     int           `yaml:"maxRetries"`
+	UserAgent      string        `yaml:"userAgent"`
+}
+
+// Source is a feed, which is available before any source is added with the admin API.
+// If sources are configured, they replace the built-in ones.
+type Source struct {
+	Name        string `yaml:"name"`
+	Format      string `yaml:"format,omitempty"`
+	Endpoint    string `yaml:"endpoint"`
+	Readability bool   `yaml:"readability,omitempty"`
+}
+
+// Log contains settings of logging
+type Log struct {
+	// Level is debug, info or error
+	Level string `yaml:"level"`
+
+	// Output is stderr, stdout or path to a file, which logs are appended to
+	Output string `yaml:"output"`
+}
+
+// Auth contains credentials of clients (see package auth).
+// Credentials of File and of auth environment variables are added to the ones of the configuration.
+//
+// The server does not start without credentials, unless authentication is disabled explicitly.
+type Auth struct {
+	File        string        `yaml:"file,omitempty"`
+	APIKeys     []auth.APIKey `yaml:"apiKeys,omitempty"`
+	TokenSecret string        `yaml:"tokenSecret,omitempty"`
+
+	// Disabled allows the server to run without any credentials, so every route, including admin ones,
+	// is public. Credentials can not be configured together with it.
+	Disabled bool `yaml:"disabled,omitempty"`
+}
+
+// envVar is an environment variable, which overrides a field of the configuration
+type envVar struct {
+	name  string
+	field string
+	set   func(c *Config, value string) error
+}
+
+// envVars are all environment variables, which override the configuration
+var envVars = []envVar{
+	{EnvPrefix + "SERVER_ADDRESS", "server.address", func(c *Config, v string) error {
+		c.Server.Address = v
+		return nil
+	}},
+	{EnvPrefix + "SERVER_MODE", "server.mode", func(c *Config, v string) error {
+		c.Server.Mode = v
+		return nil
+	}},
+	{EnvPrefix + "SERVER_DRAIN_DELAY", "server.drainDelay", func(c *Config, v string) (err error) {
+		c.Server.DrainDelay, err = time.ParseDuration(v)
+		return err
+	}},
+	{EnvPrefix + "SERVER_SHUTDOWN_TIMEOUT", "server.shutdownTimeout", func(c *Config, v string) (err error) {
+		c.Server.ShutdownTimeout, err = time.ParseDuration(v)
+		return err
+	}},
+	{EnvPrefix + "TLS_CERT_FILE", "tls.certFile", func(c *Config, v string) error {
+		c.TLS.CertFile = v
+		return nil
+	}},
+	{EnvPrefix + "TLS_KEY_FILE", "tls.keyFile", func(c *Config, v string) error {
+		c.TLS.KeyFile = v
+		return nil
+	}},
+	{EnvPrefix + "TLS_SELF_SIGNED", "tls.selfSigned", func(c *Config, v string) (err error) {
+		c.TLS.SelfSigned, err = strconv.ParseBool(v)
+		return err
+	}},
+	{EnvPrefix + "TLS_CLIENT_CA_FILE", "tls.clientCAFile", func(c *Config, v string) error {
+		c.TLS.ClientCAFile = v
+		return nil
+	}},
+	{EnvPrefix + "STORAGE_PATH", "storage.path", func(c *Config, v string) error {
+		c.Storage.Path = v
+		return nil
+	}},
+	{EnvPrefix + "STORAGE_BACKEND", "storage.backend", func(c *Config, v string) error {
+		c.Storage.Backend = v
+		return nil
+	}},
+	{EnvPrefix + "FETCH_CONNECT_TIMEOUT", "fetch.connectTimeout", func(c *Config, v string) (err error) {
+		c.Fetch.ConnectTimeout, err = time.ParseDuration(v)
+		return err
+	}},
+	{EnvPrefix + "FETCH_READ_TIMEOUT", "fetch.readTimeout", func(c *Config, v string) (err error) {
+		c.Fetch.ReadTimeout, err = time.ParseDuration(v)
+		return err
+	}},
+	{EnvPrefix + "FETCH_MAX_RETRIES", "fetch.maxRetries", func(c *Config, v string) (err error) {
+		c.Fetch.MaxRetries, err = strconv.Atoi(v)
+		return err
+	}},
+	{EnvPrefix + "FETCH_USER_AGENT", "fetch.userAgent", func(c *Config, v string) error {
+		c.Fetch.UserAgent = v
+		return nil
+	}},
+	{EnvPrefix + "SOURCES", "sources", func(c *Config, v string) (err error) {
+		c.Sources, err = parseSources(v)
+		return err
+	}},
+	{EnvPrefix + "LOG_LEVEL", "log.level", func(c *Config, v string) error {
+		c.Log.Level = v
+		return nil
+	}},
+	{EnvPrefix + "LOG_OUTPUT", "log.output", func(c *Config, v string) error {
+		c.Log.Output = v
+		return nil
+	}},
+	{auth.EnvConfigFile, "auth.file", func(c *Config, v string) error {
+		c.Auth.File = v
+		return nil
+	}},
+	{EnvPrefix + "AUTH_DISABLED", "auth.disabled", func(c *Config, v string) (err error) {
+		c.Auth.Disabled, err = strconv.ParseBool(v)
+		return err
+	}},
+}
+
+// Default returns the configuration, which is used when neither the file nor environment variables are set
+func Default() Config {
+	fetch := parsers.FetcherConfig{}.WithDefaults()
+
+	return Config{
+		Server: Server{
+			Address:         ":443",
+			Mode:            HTTPSMode,
+			DrainDelay:      5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		TLS: TLS{
+			CertFile: filepath.Join(defaultCertsPath, "tls.crt"),
+			KeyFile:  filepath.Join(defaultCertsPath, "tls.key"),
+		},
+		Storage: Storage{
+			Path:    defaultDataDirPath,
+			Backend: storage.DefaultBackend,
+		},
+		Fetch: Fetch{
+			ConnectTimeout: fetch.ConnectTimeout,
+			ReadTimeout:    fetch.ReadTimeout,
+			MaxRetries:     fetch.MaxRetries,
+			UserAgent:      fetch.UserAgent,
+		},
+		Log: Log{
+			Level:  InfoLevel,
+			Output: StderrOutput,
+		},
+	}
+}
+
+// Load returns the default configuration, overridden by the YAML file and then by environment variables.
+// If path is empty, the file of EnvConfigFile is read, and if it is not set either, only environment is used.
+//
+// Unknown fields of the file are rejected, so typos do not silently fall back to defaults.
+func Load(path string) (Config, error) {
+	config := Default()
+
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		err = decoder.Decode(&config)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+		}
+	}
+
+	err := config.applyEnv(os.LookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// applyEnv overrides fields of the configuration with environment variables, which are set
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	for _, env := range envVars {
+		value, ok := lookup(env.name)
+		if !ok || value == "" {
+			continue
+		}
+
+		err := env.set(c, value)
+		if err != nil {
+			return fmt.Errorf("%w: %s (%s): %w", ErrInvalidConfig, env.name, env.field, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadAuth adds credentials of Auth.File and of auth environment variables (see auth.LoadConfig)
+// to the credentials of the configuration. The token secret of the file or environment takes precedence.
+func (c *Config) LoadAuth() error {
+	loaded, err := auth.LoadConfig(c.Auth.File)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	c.Auth.APIKeys = append(c.Auth.APIKeys, loaded.APIKeys...)
+	if loaded.TokenSecret != "" {
+		c.Auth.TokenSecret = loaded.TokenSecret
+	}
+
+	return nil
+}
+
+// AuthConfig returns credentials of clients
+func (c Config) AuthConfig() auth.Config {
+	return auth.Config{
+		APIKeys:     c.Auth.APIKeys,
+		TokenSecret: c.Auth.TokenSecret,
+		Disabled:    c.Auth.Disabled,
+	}
+}
+
+// FetcherConfig returns settings of requests to the sources
+func (c Config) FetcherConfig() parsers.FetcherConfig {
+	return parsers.FetcherConfig{
+		ConnectTimeout: c.Fetch.ConnectTimeout,
+		ReadTimeout:    c.Fetch.ReadTimeout,
+		MaxRetries:     c.Fetch.MaxRetries,
+		UserAgent:      c.Fetch.UserAgent,
+	}
+}
+
+// Feeds returns configured sources as feeds
+func (c Config) Feeds() []types.Feed {
+	feeds := make([]types.Feed, 0, len(c.Sources))
+	for _, source := range c.Sources {
+		feed := types.Feed{
+			Name:     source.Name,
+			Format:   source.Format,
+			Endpoint: source.Endpoint,
+		}
+		if source.Readability {
+			readability := true
+			feed.Readability = &readability
+		}
+
+		feeds = append(feeds, feed)
+	}
+
+	return feeds
+}
+
+// Print writes the configuration to w in YAML format. API keys and the token secret are redacted.
+func (c Config) Print(w io.Writer) error {
+	c.Auth.APIKeys = append([]auth.APIKey(nil), c.Auth.APIKeys...)
+	for i := range c.Auth.APIKeys {
+		c.Auth.APIKeys[i].Key = redacted
+	}
+	if c.Auth.TokenSecret != "" {
+		c.Auth.TokenSecret = redacted
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(c)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// parseSources parses sources in the format "name:format:endpoint,name:format:endpoint".
+// Format may be empty, e.g. "bbc::https://feeds.bbci.co.uk/news/rss.xml".
+func parseSources(value string) ([]Source, error) {
+	var sources []Source
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%q should be in the format name:format:endpoint", entry)
+		}
+
+		sources = append(sources, Source{Name: parts[0], Format: parts[1], Endpoint: parts[2]})
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/auth"
+	"gogator/cmd/storage"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeFile writes content to the file inside of the temporary directory of the test, and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0600)
+	assert.Nil(t, err)
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  address: ":8443"
+storage:
+  backend: bolt
+fetch:
+  readTimeout: 10s
+sources:
+  - name: bbc
+    endpoint: https://feeds.bbci.co.uk/news/rss.xml
+log:
+  level: debug
+`)
+
+	config, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, ":8443", config.Server.Address)
+	assert.Equal(t, storage.BoltBackend, config.Storage.Backend)
+	assert.Equal(t, Default().Storage.Path, config.Storage.Path)
+	assert.Equal(t, 10*time.Second, config.Fetch.ReadTimeout)
+	assert.Equal(t, Default().Fetch.ConnectTimeout, config.Fetch.ConnectTimeout)
+	assert.Equal(t, []Source{{Name: "bbc", Endpoint: "https://feeds.bbci.co.uk/news/rss.xml"}}, config.Sources)
+	assert.Equal(t, DebugLevel, config.Log.Level)
+
+	t.Setenv(EnvPrefix+"SERVER_ADDRESS", ":9443")
+	t.Setenv(EnvPrefix+"SERVER_MODE", HTTPMode)
+	t.Setenv(EnvPrefix+"TLS_SELF_SIGNED", "true")
+	t.Setenv(EnvPrefix+"AUTH_DISABLED", "true")
+	t.Setenv(EnvPrefix+"FETCH_READ_TIMEOUT", "1m")
+	t.Setenv(EnvPrefix+"FETCH_MAX_RETRIES", "-1")
+	t.Setenv(EnvPrefix+"SOURCES", "abc:xml:https://abcnews.go.com/abcnews/internationalheadlines,npr::https://feeds.npr.org/1001/rss.xml")
+
+	config, err = Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, ":9443", config.Server.Address)
+	assert.Equal(t, HTTPMode, config.Server.Mode)
+	assert.True(t, config.TLS.SelfSigned)
+	assert.True(t, config.Auth.Disabled)
+	assert.Equal(t, time.Minute, config.Fetch.ReadTimeout)
+	assert.Equal(t, -1, config.Fetch.MaxRetries)
+	assert.Equal(t, []Source{
+		{Name: "abc", Format: "xml", Endpoint: "https://abcnews.go.com/abcnews/internationalheadlines"},
+		{Name: "npr", Endpoint: "https://feeds.npr.org/1001/rss.xml"},
+	}, config.Sources)
+
+	t.Setenv(EnvConfigFile, path)
+	config, err = Load("")
+	assert.Nil(t, err)
+	assert.Equal(t, DebugLevel, config.Log.Level)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+	}{
+		{
+			name:    "Unknown field",
+			content: "server:\n  adress: \":8443\"\n",
+		},
+		{
+			name:    "Invalid duration",
+			content: "fetch:\n  readTimeout: soon\n",
+		},
+		{
+			name: "Invalid duration of environment variable",
+			env:  map[string]string{EnvPrefix + "FETCH_CONNECT_TIMEOUT": "5"},
+		},
+		{
+			name: "Invalid sources of environment variable",
+			env:  map[string]string{EnvPrefix + "SOURCES": "bbc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := Load(writeFile(t, "config.yaml", tt.content))
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() Config {
+		config := Default()
+		config.TLS.CertFile = writeFile(t, "tls.crt", "certificate")
+		config.TLS.KeyFile = writeFile(t, "tls.key", "key")
+		config.Storage.Path = t.TempDir()
+		config.Auth.TokenSecret = "secret"
+
+		return config
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(c *Config)
+		expectedErr []string
+	}{
+		{
+			name:   "Valid configuration",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "Invalid address, timeouts and backend",
+			modify: func(c *Config) {
+				c.Server.Address = ":99999"
+				c.Server.DrainDelay = -time.Second
+				c.Server.ShutdownTimeout = 0
+				c.Storage.Backend = "sqlite"
+			},
+			expectedErr: []string{
+				`server.address ":99999" (GOGATOR_SERVER_ADDRESS): port should be a number from 0 to 65535`,
+				`server.drainDelay "-1s" (GOGATOR_SERVER_DRAIN_DELAY): should not be negative`,
+				`server.shutdownTimeout "0s" (GOGATOR_SERVER_SHUTDOWN_TIMEOUT): should be positive`,
+				`storage.backend "sqlite" (GOGATOR_STORAGE_BACKEND): should be json or bolt`,
+			},
+		},
+		{
+			name: "Missing certificate",
+			modify: func(c *Config) {
+				c.TLS.CertFile = filepath.Join(t.TempDir(), "missing.crt")
+			},
+			expectedErr: []string{"tls.certFile", "GOGATOR_TLS_CERT_FILE"},
+		},
+		{
+			name: "Self-signed certificate does not need files",
+			modify: func(c *Config) {
+				c.TLS = TLS{SelfSigned: true}
+			},
+		},
+		{
+			name: "Certificate is not needed in http mode",
+			modify: func(c *Config) {
+				c.Server.Mode = H2CMode
+				c.TLS = TLS{}
+			},
+		},
+		{
+			name: "Invalid mode and client authorities without TLS",
+			modify: func(c *Config) {
+				c.Server.Mode = "quic"
+				c.TLS.ClientCAFile = c.TLS.CertFile
+			},
+			expectedErr: []string{
+				`server.mode "quic" (GOGATOR_SERVER_MODE): should be https, http or h2c`,
+				"tls.clientCAFile",
+			},
+		},
+		{
+			name: "Invalid sources",
+			modify: func(c *Config) {
+				c.Sources = []Source{
+					{Name: "bbc", Endpoint: "feeds.bbci.co.uk/news/rss.xml"},
+					{Name: "abc", Format: "csv", Endpoint: "https://abcnews.go.com"},
+					{Name: "abc", Endpoint: "https://abcnews.go.com"},
+				}
+			},
+			expectedErr: []string{
+				`sources "bbc" (GOGATOR_SOURCES): endpoint should be an absolute http(s) URL`,
+				`sources "abc" (GOGATOR_SOURCES): unknown source format: csv`,
+				`sources "abc" (GOGATOR_SOURCES): source is configured more than once`,
+			},
+		},
+		{
+			name: "Invalid log level and role",
+			modify: func(c *Config) {
+				c.Log.Level = "verbose"
+				c.Auth.APIKeys = []auth.APIKey{{Name: "operator", Role: "root", Key: "key"}}
+			},
+			expectedErr: []string{"log.level", "auth.apiKeys"},
+		},
+		{
+			name: "Credentials are required in https mode",
+			modify: func(c *Config) {
+				c.Auth = Auth{}
+			},
+			expectedErr: []string{`auth.disabled "false" (GOGATOR_AUTH_DISABLED)`},
+		},
+		{
+			name: "Disabled authentication does not need credentials",
+			modify: func(c *Config) {
+				c.Auth = Auth{Disabled: true}
+			},
+		},
+		{
+			name: "Credentials are required in http mode",
+			modify: func(c *Config) {
+				c.Server.Mode = HTTPMode
+				c.TLS = TLS{}
+				c.Auth = Auth{}
+			},
+			expectedErr: []string{`auth.disabled "false" (GOGATOR_AUTH_DISABLED)`},
+		},
+		{
+			name: "Disabled authentication with credentials",
+			modify: func(c *Config) {
+				c.Auth.Disabled = true
+			},
+			expectedErr: []string{`auth.disabled "true" (GOGATOR_AUTH_DISABLED): API keys or token secret are configured`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(&config)
+
+			err := config.Validate()
+			if len(tt.expectedErr) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			for _, expected := range tt.expectedErr {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadAuth(t *testing.T) {
+	path := writeFile(t, "auth.json", `{
+		"apiKeys": [{"name": "operator", "role": "admin", "key": "admin-key"}],
+		"tokenSecret": "file secret"
+	}`)
+
+	config := Default()
+	config.Auth = Auth{
+		File:        path,
+		APIKeys:     []auth.APIKey{{Name: "dashboard", Role: auth.ReaderRole, Key: "reader-key"}},
+		TokenSecret: "config secret",
+	}
+
+	err := config.LoadAuth()
+	assert.Nil(t, err)
+	assert.Equal(t, auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "dashboard", Role: auth.ReaderRole, Key: "reader-key"},
+			{Name: "operator", Role: auth.AdminRole, Key: "admin-key"},
+		},
+		TokenSecret: "file secret",
+	}, config.AuthConfig())
+}
+
+func TestConfig_Print(t *testing.T) {
+	config := Default()
+	config.Auth.APIKeys = []auth.APIKey{{Name: "operator", Role: auth.AdminRole, Key: "admin-key"}}
+	config.Auth.TokenSecret = "secret"
+
+	var out bytes.Buffer
+	err := config.Print(&out)
+	assert.Nil(t, err)
+
+	assert.Contains(t, out.String(), "address: :443")
+	assert.Contains(t, out.String(), "readTimeout: 30s")
+	assert.Contains(t, out.String(), "name: operator")
+	assert.NotContains(t, out.String(), "admin-key")
+	assert.NotContains(t, out.String(), "secret\n")
+	assert.Equal(t, "admin-key", config.Auth.APIKeys[0].Key)
+
+	printed, err := os.CreateTemp(t.TempDir(), "config-*.yaml")
+	assert.Nil(t, err)
+	_, err = printed.Write(out.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, printed.Close())
+
+	loaded, err := Load(printed.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, config.Server, loaded.Server)
+	assert.Equal(t, config.Fetch, loaded.Fetch)
+}
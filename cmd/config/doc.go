@@ -0,0 +1,7 @@
+// Package config is used to load configuration of the server from a YAML file and environment variables.
+//
+// Values are resolved in the following order, every next source overriding the previous one:
+// built-in defaults, the file (path of -config flag or GOGATOR_CONFIG), GOGATOR_* environment variables,
+// and explicitly set command-line flags. The effective configuration is validated at startup, and
+// can be printed with secrets redacted.
+package config
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/auth"
+	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+)
+
+// Validate checks every field of the configuration, which is used by the server.
+// All problems are reported at once, each of them naming the field and the environment variable, which sets it.
+func (c Config) Validate() error {
+	errs := c.validateServer()
+	errs = append(errs, c.validateTLS()...)
+	errs = append(errs, c.fetchingErrors()...)
+	errs = append(errs, c.validateLog(), c.validateAuth())
+
+	return join(errs...)
+}
+
+// ValidateFetching checks fields of the configuration, which are used to fetch and store news:
+// storage, fetch settings and sources.
+func (c Config) ValidateFetching() error {
+	return join(c.fetchingErrors()...)
+}
+
+// fetchingErrors returns problems of storage, fetch settings and sources
+func (c Config) fetchingErrors() []error {
+	var errs []error
+
+	switch c.Storage.Backend {
+	case storage.JsonBackend, storage.BoltBackend:
+	default:
+		errs = append(errs, fieldError("storage.backend", c.Storage.Backend,
+			fmt.Sprintf("should be %s or %s", storage.JsonBackend, storage.BoltBackend)))
+	}
+
+	info, err := os.Stat(c.Storage.Path)
+	if err != nil {
+		errs = append(errs, fieldError("storage.path", c.Storage.Path, err.Error()))
+	} else if !info.IsDir() {
+		errs = append(errs, fieldError("storage.path", c.Storage.Path, "should be a directory"))
+	}
+
+	if c.Fetch.ConnectTimeout < 0 {
+		errs = append(errs, fieldError("fetch.connectTimeout", c.Fetch.ConnectTimeout.String(), "should not be negative"))
+	}
+	if c.Fetch.ReadTimeout < 0 {
+		errs = append(errs, fieldError("fetch.readTimeout", c.Fetch.ReadTimeout.String(), "should not be negative"))
+	}
+
+	names := make(map[string]bool, len(c.Sources))
+	for _, feed := range c.Feeds() {
+		switch {
+		case feed.Name == "":
+			errs = append(errs, fieldError("sources", feed.Endpoint, "every source should have a name"))
+			continue
+		case names[feed.Name]:
+			errs = append(errs, fieldError("sources", feed.Name, "source is configured more than once"))
+			continue
+		}
+		names[feed.Name] = true
+
+		endpoint, err := url.Parse(feed.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			errs = append(errs, fieldError("sources", feed.Name, "endpoint should be an absolute http(s) URL"))
+			continue
+		}
+
+		_, err = parsers.NewParser(feed)
+		if err != nil {
+			errs = append(errs, fieldError("sources", feed.Name, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// validateServer checks that the address has a valid port, and the mode is supported
+func (c Config) validateServer() []error {
+	var errs []error
+
+	_, port, err := net.SplitHostPort(c.Server.Address)
+	if err != nil {
+		errs = append(errs, fieldError("server.address", c.Server.Address, err.Error()))
+	} else if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+		errs = append(errs, fieldError("server.address", c.Server.Address, "port should be a number from 0 to 65535"))
+	}
+
+	if c.Server.DrainDelay < 0 {
+		errs = append(errs, fieldError("server.drainDelay", c.Server.DrainDelay.String(), "should not be negative"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, fieldError("server.shutdownTimeout", c.Server.ShutdownTimeout.String(), "should be positive"))
+	}
+
+	switch c.Server.Mode {
+	case HTTPSMode, HTTPMode, H2CMode:
+	default:
+		errs = append(errs, fieldError("server.mode", c.Server.Mode,
+			fmt.Sprintf("should be %s, %s or %s", HTTPSMode, HTTPMode, H2CMode)))
+	}
+
+	return errs
+}
+
+// validateTLS checks that the certificate, the private key and authorities of clients exist.
+// They are checked only in https mode, and the certificate and the key are not needed, if it is self-signed.
+func (c Config) validateTLS() []error {
+	if c.Server.Mode != HTTPSMode {
+		if c.TLS.ClientCAFile != "" {
+			return []error{fieldError("tls.clientCAFile", c.TLS.ClientCAFile,
+				"client certificates can be verified only in "+HTTPSMode+" mode")}
+		}
+
+		return nil
+	}
+
+	var errs []error
+
+	if !c.TLS.SelfSigned {
+		for _, file := range []struct{ field, path string }{
+			{"tls.certFile", c.TLS.CertFile},
+			{"tls.keyFile", c.TLS.KeyFile},
+		} {
+			if file.path == "" {
+				errs = append(errs, fieldError(file.field, file.path, "is required, unless tls.selfSigned is enabled"))
+				continue
+			}
+
+			_, err := os.Stat(file.path)
+			if err != nil {
+				errs = append(errs, fieldError(file.field, file.path,
+					err.Error()+"; enable tls.selfSigned for development"))
+			}
+		}
+	}
+
+	if c.TLS.ClientCAFile != "" {
+		_, err := os.Stat(c.TLS.ClientCAFile)
+		if err != nil {
+			errs = append(errs, fieldError("tls.clientCAFile", c.TLS.ClientCAFile, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// validateLog checks the level of logging
+func (c Config) validateLog() error {
+	switch c.Log.Level {
+	case DebugLevel, InfoLevel, ErrorLevel:
+		return nil
+	default:
+		return fieldError("log.level", c.Log.Level,
+			fmt.Sprintf("should be %s, %s or %s", DebugLevel, InfoLevel, ErrorLevel))
+	}
+}
+
+// validateAuth checks roles and keys of clients. Credentials are required in every mode,
+// unless authentication is disabled explicitly, and disabled authentication can not have credentials.
+func (c Config) validateAuth() error {
+	_, err := auth.New(c.AuthConfig())
+	switch {
+	case errors.Is(err, auth.ErrNoCredentials):
+		return fieldError("auth.disabled", strconv.FormatBool(c.Auth.Disabled),
+			"API keys or token secret are required; set "+auth.EnvAPIKeys+", "+auth.EnvTokenSecret+
+				" or auth.file, or disable authentication explicitly to run without them")
+	case errors.Is(err, auth.ErrDisabledWithCredentials):
+		return fieldError("auth.disabled", strconv.FormatBool(c.Auth.Disabled),
+			"API keys or token secret are configured, remove them or enable authentication")
+	case err != nil:
+		return fmt.Errorf("auth.apiKeys: %w", err)
+	}
+
+	return nil
+}
+
+// fieldError describes the problem with the field, and names the environment variable which sets it
+func fieldError(field, value, reason string) error {
+	for _, env := range envVars {
+		if env.field == field {
+			return fmt.Errorf("%s %q (%s): %s", field, value, env.name, reason)
+		}
+	}
+
+	return fmt.Errorf("%s %q: %s", field, value, reason)
+}
+
+// join combines errors into one, wrapping ErrInvalidConfig. Nil is returned, if all errors are nil.
+func join(errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n%w", ErrInvalidConfig, err)
+}
@@ -0,0 +1,91 @@
+package dedup
+
+import (
+	"gogator/cmd/types"
+	"strings"
+	"unicode"
+)
+
+// SimilarTitleThreshold is the minimal similarity of titles (share of common words), at which
+// articles of different publishers are considered to be the same story
+const SimilarTitleThreshold = 0.8
+
+// Collapse merges articles of different publishers with near-identical titles into a single article.
+//
+// The first article of the group is kept, and the rest of the group is listed in its AlternateSources.
+// Articles of the same publisher are never collapsed, since they are different articles even with
+// similar titles (e.g. live updates). Order of articles is preserved.
+func Collapse(articles []types.Article) []types.Article {
+	var (
+		collapsed []types.Article
+
+		// words and publishers of the collapsed articles, to compare the next ones against
+		words      []map[string]bool
+		publishers []map[string]bool
+	)
+
+	for _, article := range Deduplicate(articles) {
+		articleWords := titleWords(article.Title)
+
+		group := -1
+		for i := range collapsed {
+			if publishers[i][article.Publisher] {
+				continue
+			}
+			if similarity(words[i], articleWords) >= SimilarTitleThreshold {
+				group = i
+				break
+			}
+		}
+
+		if group == -1 {
+			collapsed = append(collapsed, article)
+			words = append(words, articleWords)
+			publishers = append(publishers, map[string]bool{article.Publisher: true})
+			continue
+		}
+
+		collapsed[group].AlternateSources = append(collapsed[group].AlternateSources, types.AlternateSource{
+			ID:        article.ID,
+			Publisher: article.Publisher,
+			Link:      article.Link,
+		})
+		publishers[group][article.Publisher] = true
+	}
+
+	return collapsed
+}
+
+// normalizeTitle lower-cases the title, removes punctuation and repeating spaces
+func normalizeTitle(title string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// titleWords returns set of words of the normalized title
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(normalizeTitle(title)) {
+		words[word] = true
+	}
+
+	return words
+}
+
+// similarity returns Jaccard index of two sets of words: amount of common words, divided
+// by amount of all words. Empty titles are not similar to anything.
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,64 @@
+package dedup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestCollapse(t *testing.T) {
+	testCases := []struct {
+		name       string
+		articles   []types.Article
+		expected   []string
+		alternates map[string][]string
+	}{
+		{
+			name: "Same story from different publishers",
+			articles: []types.Article{
+				{Title: "Earthquake hits Japan's northern coast", Publisher: "bbc", Link: "https://bbc.com/1"},
+				{Title: "Earthquake hits Japan northern coast", Publisher: "abc", Link: "https://abc.com/1"},
+				{Title: "Elections in France", Publisher: "abc", Link: "https://abc.com/2"},
+			},
+			expected: []string{"Earthquake hits Japan's northern coast", "Elections in France"},
+			alternates: map[string][]string{
+				"Earthquake hits Japan's northern coast": {"abc"},
+			},
+		},
+		{
+			name: "Similar titles of the same publisher",
+			articles: []types.Article{
+				{Title: "Live updates: elections", Publisher: "bbc", Link: "https://bbc.com/1"},
+				{Title: "Live updates: elections", Publisher: "bbc", Link: "https://bbc.com/2"},
+			},
+			expected: []string{"Live updates: elections", "Live updates: elections"},
+		},
+		{
+			name: "Different stories",
+			articles: []types.Article{
+				{Title: "Markets fall after rate decision", Publisher: "bbc", Link: "https://bbc.com/1"},
+				{Title: "Markets rise after rate decision in Asia", Publisher: "abc", Link: "https://abc.com/1"},
+			},
+			expected: []string{"Markets fall after rate decision", "Markets rise after rate decision in Asia"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			collapsed := Collapse(tt.articles)
+
+			var titles []string
+			for _, article := range collapsed {
+				titles = append(titles, article.Title)
+
+				var publishers []string
+				for _, alternate := range article.AlternateSources {
+					publishers = append(publishers, alternate.Publisher)
+				}
+				assert.Equal(t, tt.alternates[article.Title], publishers)
+			}
+
+			assert.Equal(t, tt.expected, titles)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package dedup
+
+import "gogator/cmd/types"
+
+// Deduplicate removes articles with repeating IDs, keeping the first occurrence.
+// IDs are assigned to articles which do not have them yet. Order of articles is preserved.
+func Deduplicate(articles []types.Article) []types.Article {
+	seen := make(map[string]bool, len(articles))
+
+	var unique []types.Article
+	for _, article := range articles {
+		if article.ID == "" {
+			article.ID = ArticleID(article)
+		}
+		if seen[article.ID] {
+			continue
+		}
+
+		seen[article.ID] = true
+		unique = append(unique, article)
+	}
+
+	return unique
+}
+
+// Merge adds incoming articles to the existing ones.
+//
+// Existing article is replaced by the incoming one with the same ID, since it is a more recent
+// version of the article. New articles are appended in their order.
+func Merge(existing, incoming []types.Article) []types.Article {
+	merged := Deduplicate(existing)
+
+	idToIndex := make(map[string]int, len(merged))
+	for i, article := range merged {
+		idToIndex[article.ID] = i
+	}
+
+	for _, article := range Deduplicate(incoming) {
+		if i, exists := idToIndex[article.ID]; exists {
+			merged[i] = article
+			continue
+		}
+
+		idToIndex[article.ID] = len(merged)
+		merged = append(merged, article)
+	}
+
+	return merged
+}
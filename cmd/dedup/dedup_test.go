@@ -0,0 +1,71 @@
+package dedup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestDeduplicate(t *testing.T) {
+	articles := []types.Article{
+		{Title: "First", Link: "https://bbc.com/1", Publisher: "bbc"},
+		{Title: "Second", Link: "https://bbc.com/2", Publisher: "bbc"},
+		{Title: "First again", Link: "https://www.bbc.com/1/", Publisher: "abc"},
+	}
+
+	unique := Deduplicate(articles)
+
+	assert.Len(t, unique, 2)
+	assert.Equal(t, "First", unique[0].Title)
+	assert.Equal(t, "Second", unique[1].Title)
+	assert.Equal(t, ArticleID(articles[0]), unique[0].ID)
+}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing []types.Article
+		incoming []types.Article
+		expected []string
+	}{
+		{
+			name:     "Nothing stored",
+			existing: nil,
+			incoming: []types.Article{
+				{Title: "First", Link: "https://bbc.com/1"},
+			},
+			expected: []string{"First"},
+		},
+		{
+			name: "Update stored article and append new one",
+			existing: []types.Article{
+				{Title: "First", Link: "https://bbc.com/1"},
+				{Title: "Second", Link: "https://bbc.com/2"},
+			},
+			incoming: []types.Article{
+				{Title: "Third", Link: "https://bbc.com/3"},
+				{Title: "First updated", Link: "https://bbc.com/1"},
+			},
+			expected: []string{"First updated", "Second", "Third"},
+		},
+		{
+			name: "Nothing fetched",
+			existing: []types.Article{
+				{Title: "First", Link: "https://bbc.com/1"},
+			},
+			incoming: nil,
+			expected: []string{"First"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []string
+			for _, article := range Merge(tt.existing, tt.incoming) {
+				titles = append(titles, article.Title)
+			}
+
+			assert.Equal(t, tt.expected, titles)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package dedup is used to identify articles and remove duplicates among them.
+//
+// Every article gets a stable ID, which is a hash of its canonicalized link, or of its title and
+// publisher, if the link is missing. Articles with equal IDs are considered the same article,
+// regardless of the feed they were retrieved from.
+//
+// Besides exact duplicates, package is able to collapse near-identical articles of different publishers
+// into a single one, which lists other publishers as alternate sources.
+package dedup
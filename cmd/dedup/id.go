@@ -0,0 +1,84 @@
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"gogator/cmd/types"
+	"net/url"
+	"strings"
+)
+
+const (
+	// idLength is the amount of bytes of the hash, used as article ID
+	idLength = 16
+
+	// linkPrefix and titlePrefix separate hashes of links from hashes of titles,
+	// so they never collide with each other
+	linkPrefix  = "link:"
+	titlePrefix = "title:"
+)
+
+// trackingParams are query parameters, which do not change the article, but only track where it was opened from
+var trackingParams = map[string]bool{
+	"fbclid":      true,
+	"gclid":       true,
+	"ocid":        true,
+	"cmpid":       true,
+	"at_medium":   true,
+	"at_campaign": true,
+}
+
+// ArticleID returns stable identifier of the article.
+//
+// It is a hash of the canonicalized link, so the same article has the same ID in every feed.
+// Articles without link are identified by their normalized title and publisher.
+func ArticleID(article types.Article) string {
+	key := titlePrefix + normalizeTitle(article.Title) + "|" + strings.ToLower(strings.TrimSpace(article.Publisher))
+	if link := canonicalLink(article.Link); link != "" {
+		key = linkPrefix + link
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:idLength])
+}
+
+// AssignIDs sets ID of every article
+func AssignIDs(articles []types.Article) {
+	for i := range articles {
+		articles[i].ID = ArticleID(articles[i])
+	}
+}
+
+// canonicalLink brings link to the form, which is equal for all variations of the same URL:
+// scheme and host are lower-cased, "www." prefix, fragment, trailing slash and tracking
+// parameters are removed, and the rest of the parameters are sorted.
+//
+// Returns an empty string, if link is empty or is not an absolute URL.
+func canonicalLink(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	u.Host = strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	query := u.Query()
+	for param := range query {
+		if trackingParams[strings.ToLower(param)] || strings.HasPrefix(strings.ToLower(param), "utm_") {
+			query.Del(param)
+		}
+	}
+	// Encode sorts parameters by key
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,68 @@
+package dedup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestArticleID(t *testing.T) {
+	testCases := []struct {
+		name  string
+		a     types.Article
+		b     types.Article
+		equal bool
+	}{
+		{
+			name:  "Same link",
+			a:     types.Article{Link: "https://bbc.com/news/1", Title: "First", Publisher: "bbc"},
+			b:     types.Article{Link: "https://bbc.com/news/1", Title: "Updated title", Publisher: "abc"},
+			equal: true,
+		},
+		{
+			name:  "Link variations",
+			a:     types.Article{Link: "http://www.BBC.com/news/1/?utm_source=rss&b=2&a=1#comments"},
+			b:     types.Article{Link: "https://bbc.com/news/1?a=1&b=2"},
+			equal: true,
+		},
+		{
+			name:  "Different links",
+			a:     types.Article{Link: "https://bbc.com/news/1"},
+			b:     types.Article{Link: "https://bbc.com/news/2"},
+			equal: false,
+		},
+		{
+			name:  "Different query parameters",
+			a:     types.Article{Link: "https://bbc.com/news?id=1"},
+			b:     types.Article{Link: "https://bbc.com/news?id=2"},
+			equal: false,
+		},
+		{
+			name:  "Without link, same title and publisher",
+			a:     types.Article{Title: "Breaking: news!", Publisher: "bbc"},
+			b:     types.Article{Title: "breaking news", Publisher: "BBC"},
+			equal: true,
+		},
+		{
+			name:  "Without link, different publishers",
+			a:     types.Article{Title: "Breaking news", Publisher: "bbc"},
+			b:     types.Article{Title: "Breaking news", Publisher: "abc"},
+			equal: false,
+		},
+		{
+			name:  "Relative link is ignored",
+			a:     types.Article{Title: "Breaking news", Publisher: "bbc", Link: "/news/1"},
+			b:     types.Article{Title: "Breaking news", Publisher: "bbc", Link: "/news/2"},
+			equal: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := ArticleID(tt.a), ArticleID(tt.b)
+
+			assert.Len(t, a, idLength*2)
+			assert.Equal(t, tt.equal, a == b)
+		})
+	}
+}
@@ -1,26 +1,40 @@
 package filters
 
-import "gogator/cmd/types"
+import (
+	"gogator/cmd/types"
+	"time"
+)
 
 var (
 	f InstructionFactory
 )
 
-// Apply filters news by provided FilteringParams
+// Apply filters news by provided FilteringParams.
+// Keywords and the date range are parsed once, so every article is filtered with the same query and bounds.
 func Apply(articles []types.Article, params *types.FilteringParams) []types.Article {
 	var filteredArticles []types.Article
 
-	filters := []func(article types.Article, params *types.FilteringParams) bool{
-		f.CreateSourcesInstruction().Apply,
-		f.CreateApplyDataRangeInstruction().Apply,
-		f.CreateApplyKeywordInstruction().Apply,
+	filters := []Instruction{
+		f.CreateSourcesInstruction(),
+		f.CreateApplyDataRangeInstruction(),
+		f.CreateCategoriesInstruction(),
+		f.CreateAuthorsInstruction(),
+		f.CreateLanguageInstruction(),
+		f.CreateApplyKeywordInstruction(),
+	}
+
+	now := time.Now()
+	for i, filter := range filters {
+		if compilable, ok := filter.(CompilableInstruction); ok {
+			filters[i] = compilable.Compile(params, now)
+		}
 	}
 
 	for _, article := range articles {
 		applyAllFilters := true
 
 		for _, filter := range filters {
-			if !filter(article, params) {
+			if !filter.Apply(article, params) {
 				applyAllFilters = false
 				break
 			}
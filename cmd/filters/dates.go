@@ -0,0 +1,217 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	// the database of time zones is embedded, because runtime images (alpine) do not have zoneinfo
+	_ "time/tzdata"
+)
+
+const (
+	// Now is the current moment
+	Now = "now"
+
+	// Today is the start of the current day in the time zone, or its end, if it is the end of the range
+	Today = "today"
+
+	// Yesterday is the start of the previous day in the time zone, or its end, if it is the end of the range
+	Yesterday = "yesterday"
+
+	// day is the duration of a day in relative dates
+	day = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidDate is returned, when date of the range is not in any of the supported formats
+	ErrInvalidDate = errors.New("invalid date")
+
+	// ErrUnknownTimezone is returned, when time zone is not a known IANA time zone name
+	ErrUnknownTimezone = errors.New("unknown time zone")
+
+	// ErrInvalidDuration is returned, when duration is not a positive amount of minutes, hours, days or weeks
+	ErrInvalidDuration = errors.New("invalid duration")
+
+	// ErrInvalidDateRange is returned, when the start of the range is after its end
+	ErrInvalidDateRange = errors.New("date from can not be after date end")
+
+	// relativeUnits are units of relative dates
+	relativeUnits = map[byte]time.Duration{
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': day,
+		'w': 7 * day,
+	}
+
+	// localLayouts are layouts of dates and times without offset, which are interpreted in the time zone
+	localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", time.DateTime, "2006-01-02 15:04"}
+
+	// clockLayouts are layouts of times of the current day in the time zone
+	clockLayouts = []string{time.TimeOnly, "15:04"}
+)
+
+// LoadTimezone returns the location of IANA time zone name, e.g. "Europe/Kyiv". Empty name means UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTimezone, name)
+	}
+
+	return location, nil
+}
+
+// ParseDateBound converts a bound of the date range into a moment of time in UTC.
+//
+// Supported formats are:
+// /  1. Date "2024-05-24" - start of the day in the location, or its end, if end is true
+// /  2. RFC 3339 timestamp "2024-05-24T09:00:00+03:00" - the moment as it is, ignoring the location
+// /  3. Date and time without offset "2024-05-24T09:00" or "2024-05-24 09:00" - the moment in the location
+// /  4. Time of the current day "09:00" - the moment of today in the location
+// /  5. "now", "today" and "yesterday" - today and yesterday mean the whole day, like dates
+// /  6. Relative "-6h", "30m", "7d" or "2w" - the moment, which was that long ago. Sign is optional.
+//
+// Empty value means the bound is not set, and zero time is returned.
+func ParseDateBound(value string, location *time.Location, now time.Time, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	localNow := now.In(location)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, location)
+
+	switch strings.ToLower(value) {
+	case Now:
+		return now.UTC(), nil
+	case Today:
+		return dayBound(today, end), nil
+	case Yesterday:
+		return dayBound(today.AddDate(0, 0, -1), end), nil
+	}
+
+	if date, err := time.ParseInLocation(time.DateOnly, value, location); err == nil {
+		return dayBound(date, end), nil
+	}
+
+	if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return date.UTC(), nil
+	}
+
+	for _, layout := range localLayouts {
+		if date, err := time.ParseInLocation(layout, value, location); err == nil {
+			return date.UTC(), nil
+		}
+	}
+
+	for _, layout := range clockLayouts {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return time.Date(today.Year(), today.Month(), today.Day(),
+				clock.Hour(), clock.Minute(), clock.Second(), 0, location).UTC(), nil
+		}
+	}
+
+	if ago, ok := parseRelative(value); ok {
+		return now.Add(-ago).UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w %q: expected YYYY-MM-DD, RFC 3339 timestamp, HH:MM, "+
+		"now, today, yesterday or relative duration like -6h or 7d", ErrInvalidDate, value)
+}
+
+// ParseDuration parses a positive duration in the format of relative dates: "6h", "30m", "7d" or "2w"
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if duration, ok := parseRelative(value); ok && duration > 0 && !strings.HasPrefix(value, "-") {
+		return duration, nil
+	}
+
+	return 0, fmt.Errorf("%w %q: expected positive duration like 6h, 30m, 7d or 2w", ErrInvalidDuration, value)
+}
+
+// DateRange returns bounds of the date range of params in UTC, interpreted in the time zone of params.
+// Zero bound means it is not set. Bounds are inclusive.
+func DateRange(params *types.FilteringParams, now time.Time) (time.Time, time.Time, error) {
+	location, err := LoadTimezone(params.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from, err := ParseDateBound(params.StartingTimestamp, location, now, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := ParseDateBound(params.EndingTimestamp, location, now, true)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+
+	return from, to, nil
+}
+
+// NormalizeDateRange replaces bounds of the date range of params with RFC 3339 timestamps in UTC,
+// so relative dates are resolved once, and the range does not depend on the time zone anymore.
+func NormalizeDateRange(params *types.FilteringParams, now time.Time) error {
+	from, to, err := DateRange(params, now)
+	if err != nil {
+		return err
+	}
+
+	params.StartingTimestamp = formatBound(from)
+	params.EndingTimestamp = formatBound(to)
+	params.Timezone = ""
+
+	return nil
+}
+
+// formatBound formats the bound as RFC 3339 timestamp. Zero bound is formatted as empty string.
+func formatBound(bound time.Time) string {
+	if bound.IsZero() {
+		return ""
+	}
+
+	return bound.Format(time.RFC3339Nano)
+}
+
+// dayBound returns the start of the day, or its last nanosecond, if end is true
+func dayBound(date time.Time, end bool) time.Time {
+	if end {
+		date = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return date.UTC()
+}
+
+// parseRelative parses durations like "-6h", "30m", "7d" or "2w".
+// Amounts, which do not fit into time.Duration, are rejected.
+func parseRelative(value string) (time.Duration, bool) {
+	value = strings.TrimPrefix(value, "-")
+	if len(value) < 2 {
+		return 0, false
+	}
+
+	unit, exists := relativeUnits[value[len(value)-1]]
+	if !exists {
+		return 0, false
+	}
+
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || amount < 0 || int64(amount) > math.MaxInt64/int64(unit) {
+		return 0, false
+	}
+
+	return time.Duration(amount) * unit, true
+}
@@ -0,0 +1,236 @@
+package filters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+	"time"
+)
+
+func TestParseDateBound(t *testing.T) {
+	now := time.Date(2024, 7, 21, 13, 30, 0, 0, time.UTC)
+	kyiv, err := LoadTimezone("Europe/Kyiv")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		value    string
+		location *time.Location
+		end      bool
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "Empty value is not set",
+			value:    "",
+			location: time.UTC,
+			expected: time.Time{},
+		},
+		{
+			name:     "Date is the start of the day",
+			value:    "2024-07-20",
+			location: time.UTC,
+			expected: time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Date is the end of the day at the end of the range",
+			value:    "2024-07-20",
+			location: time.UTC,
+			end:      true,
+			expected: time.Date(2024, 7, 20, 23, 59, 59, int(time.Second-time.Nanosecond), time.UTC),
+		},
+		{
+			name:     "Date is interpreted in the time zone",
+			value:    "2024-07-20",
+			location: kyiv,
+			expected: time.Date(2024, 7, 19, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 3339 timestamp ignores the time zone",
+			value:    "2024-07-20T09:00:00+03:00",
+			location: kyiv,
+			expected: time.Date(2024, 7, 20, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Date and time without offset",
+			value:    "2024-07-20T09:00",
+			location: kyiv,
+			expected: time.Date(2024, 7, 20, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Date and time separated by space",
+			value:    "2024-07-20 09:00:30",
+			location: time.UTC,
+			expected: time.Date(2024, 7, 20, 9, 0, 30, 0, time.UTC),
+		},
+		{
+			name:     "Time of today",
+			value:    "09:00",
+			location: kyiv,
+			expected: time.Date(2024, 7, 21, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Now",
+			value:    "now",
+			location: kyiv,
+			expected: now,
+		},
+		{
+			name:     "Today in the time zone",
+			value:    "Today",
+			location: kyiv,
+			expected: time.Date(2024, 7, 20, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Yesterday at the end of the range",
+			value:    "yesterday",
+			location: time.UTC,
+			end:      true,
+			expected: time.Date(2024, 7, 20, 23, 59, 59, int(time.Second-time.Nanosecond), time.UTC),
+		},
+		{
+			name:     "Relative hours",
+			value:    "-6h",
+			location: time.UTC,
+			expected: time.Date(2024, 7, 21, 7, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Relative days without sign",
+			value:    "7d",
+			location: time.UTC,
+			expected: time.Date(2024, 7, 14, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Relative weeks",
+			value:    "-2w",
+			location: time.UTC,
+			expected: time.Date(2024, 7, 7, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Unknown unit",
+			value:    "-6y",
+			location: time.UTC,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid date",
+			value:    "2024-02-30",
+			location: time.UTC,
+			wantErr:  true,
+		},
+		{
+			name:     "Unsupported format",
+			value:    "20/07/2024",
+			location: time.UTC,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bound, err := ParseDateBound(tc.value, tc.location, now, tc.end)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDate)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.Equal(bound), "expected %v, got %v", tc.expected, bound)
+		})
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	location, err := LoadTimezone("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, location)
+
+	location, err = LoadTimezone("Europe/Kyiv")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Kyiv", location.String())
+
+	_, err = LoadTimezone("Mars/Olympus")
+	assert.ErrorIs(t, err, ErrUnknownTimezone)
+}
+
+func TestParseDuration(t *testing.T) {
+	duration, err := ParseDuration("6h")
+	assert.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, duration)
+
+	duration, err = ParseDuration("2w")
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, duration)
+
+	for _, value := range []string{"", "0h", "-6h", "6y", "h", "9999999999w", "15251w", "9223372036854775807m"} {
+		_, err = ParseDuration(value)
+		assert.ErrorIs(t, err, ErrInvalidDuration, value)
+	}
+}
+
+func TestNormalizeDateRange(t *testing.T) {
+	now := time.Date(2024, 7, 21, 13, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		params       types.FilteringParams
+		expectedFrom string
+		expectedEnd  string
+		expectedErr  error
+	}{
+		{
+			name:   "Empty range",
+			params: types.FilteringParams{},
+		},
+		{
+			name: "Days in the time zone",
+			params: types.FilteringParams{
+				StartingTimestamp: "2024-07-20",
+				EndingTimestamp:   "2024-07-20",
+				Timezone:          "Europe/Kyiv",
+			},
+			expectedFrom: "2024-07-19T21:00:00Z",
+			expectedEnd:  "2024-07-20T20:59:59.999999999Z",
+		},
+		{
+			name: "Relative start without end",
+			params: types.FilteringParams{
+				StartingTimestamp: "-6h",
+			},
+			expectedFrom: "2024-07-21T07:30:00Z",
+		},
+		{
+			name: "Start after end",
+			params: types.FilteringParams{
+				StartingTimestamp: "today",
+				EndingTimestamp:   "yesterday",
+			},
+			expectedErr: ErrInvalidDateRange,
+		},
+		{
+			name: "Unknown time zone",
+			params: types.FilteringParams{
+				StartingTimestamp: "today",
+				Timezone:          "Mars/Olympus",
+			},
+			expectedErr: ErrUnknownTimezone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := tc.params
+
+			err := NormalizeDateRange(&params, now)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFrom, params.StartingTimestamp)
+			assert.Equal(t, tc.expectedEnd, params.EndingTimestamp)
+			assert.Empty(t, params.Timezone)
+		})
+	}
+}
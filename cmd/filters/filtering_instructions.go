@@ -1,6 +1,7 @@
 package filters
 
 import (
+	"gogator/cmd/language"
 	"gogator/cmd/types"
 	"strings"
 	"time"
@@ -8,51 +9,68 @@ import (
 
 type ApplyKeywordsInstruction struct{}
 
-// Apply is a method in ApplyKeywordsInstruction which is used to filter article by keyword
+// Apply is a method in ApplyKeywordsInstruction which is used to filter article by keywords query,
+// matched according to the match mode. See Query for the syntax.
+// Keywords, which can not be parsed, match no articles: they are rejected by the validator before filtering.
 func (a ApplyKeywordsInstruction) Apply(article types.Article, params *types.FilteringParams) bool {
-	keywords := strings.Split(params.Keywords, ",")
-	for _, keyword := range keywords {
-		if strings.Contains(article.Title, keyword) || strings.Contains(article.Description, keyword) {
-			return true
-		}
-	}
+	return a.Compile(params, time.Now()).Apply(article, params)
+}
 
-	return false
+// Compile parses the keywords query once, so it is matched against every article without parsing it again
+func (a ApplyKeywordsInstruction) Compile(params *types.FilteringParams, _ time.Time) Instruction {
+	query, err := ParseQuery(params.Keywords, params.MatchMode)
+	return keywordsQueryInstruction{query: query, err: err}
+}
+
+// keywordsQueryInstruction filters articles by the parsed keywords query
+type keywordsQueryInstruction struct {
+	query Query
+	err   error
+}
+
+// Apply in keywordsQueryInstruction checks if the article matches the parsed query
+func (k keywordsQueryInstruction) Apply(article types.Article, _ *types.FilteringParams) bool {
+	return k.err == nil && k.query.Match(article)
 }
 
 type ApplyDateRangeInstruction struct{}
 
-// Apply in ApplyDateRangeInstruction is a method which is used to filter article by data range
+// Apply in ApplyDateRangeInstruction is a method which is used to filter article by data range.
+// Bounds of the range are inclusive, see ParseDateBound for their formats.
+// Articles are filtered by their normalized publication date (see types.Article.NormalizePubDate).
 func (a ApplyDateRangeInstruction) Apply(article types.Article, params *types.FilteringParams) bool {
+	return a.Compile(params, time.Now()).Apply(article, params)
+}
 
-	var publicationDate time.Time
-	var err error
+// Compile resolves bounds of the date range once, so relative bounds, e.g. -6h, are the same for every article
+func (a ApplyDateRangeInstruction) Compile(params *types.FilteringParams, now time.Time) Instruction {
+	if params.StartingTimestamp == "" && params.EndingTimestamp == "" {
+		return dateBoundsInstruction{}
+	}
 
-	if article.PubDate != "" {
-		publicationDate, err = parseDate(article.PubDate)
-		if err != nil {
-			return false
-		}
+	from, to, err := DateRange(params, now)
+	return dateBoundsInstruction{from: from, to: to, err: err}
+}
+
+// dateBoundsInstruction filters articles by resolved bounds of the date range. Zero bound is not checked.
+type dateBoundsInstruction struct {
+	from time.Time
+	to   time.Time
+	err  error
+}
+
+// Apply in dateBoundsInstruction checks if the article is published inside of the bounds
+func (d dateBoundsInstruction) Apply(article types.Article, _ *types.FilteringParams) bool {
+	if d.err != nil {
+		return false
 	}
 
-	if params.StartingTimestamp != "" {
-		startingTime, err := parseDate(params.StartingTimestamp)
-		if err != nil {
-			return false
-		}
-		if publicationDate.Before(startingTime) {
-			return false
-		}
+	if !d.from.IsZero() && article.PublishedAt.Before(d.from) {
+		return false
 	}
 
-	if params.EndingTimestamp != "" {
-		endingTime, err := parseDate(params.EndingTimestamp)
-		if err != nil {
-			return false
-		}
-		if publicationDate.After(endingTime) {
-			return false
-		}
+	if !d.to.IsZero() && article.PublishedAt.After(d.to) {
+		return false
 	}
 
 	return true
@@ -76,22 +94,63 @@ func (a ApplySourcesInstruction) Apply(article types.Article, params *types.Filt
 	return false
 }
 
-// parseDate is utility function which parses date to time.Time object
-func parseDate(dateStr string) (time.Time, error) {
-	var err error
-	var date time.Time
-	timeFormats := []string{
-		time.Layout, time.ANSIC, time.UnixDate, time.RubyDate, time.RFC822, time.RFC822Z,
-		time.RFC850, time.RFC1123, time.RFC1123Z, time.RFC3339, time.RFC3339Nano,
-		time.Kitchen, time.Stamp, time.StampMilli, time.StampMicro, time.StampNano,
-		time.DateTime, time.DateOnly, time.TimeOnly, "January 2, 2006",
+type ApplyCategoriesInstruction struct{}
+
+// Apply in ApplyCategoriesInstruction is a method which is used to filter articles by categories.
+// Article matches, if any of its categories is equal to any of the comma separated categories, ignoring case.
+func (a ApplyCategoriesInstruction) Apply(article types.Article, params *types.FilteringParams) bool {
+	if params.Categories == "" {
+		return true
+	}
+
+	for _, category := range strings.Split(params.Categories, ",") {
+		category = strings.TrimSpace(category)
+		for _, articleCategory := range article.Categories {
+			if strings.EqualFold(articleCategory, category) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type ApplyAuthorsInstruction struct{}
+
+// Apply in ApplyAuthorsInstruction is a method which is used to filter articles by author.
+// Article matches, if its author contains any of the comma separated names, ignoring case,
+// since feeds often decorate names, e.g. "news@example.com (John Doe)".
+func (a ApplyAuthorsInstruction) Apply(article types.Article, params *types.FilteringParams) bool {
+	if params.Authors == "" {
+		return true
+	}
+
+	author := strings.ToLower(article.Author)
+	for _, name := range strings.Split(params.Authors, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && strings.Contains(author, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ApplyLanguageInstruction struct{}
+
+// Apply in ApplyLanguageInstruction is a method which is used to filter articles by language.
+// Article matches, if its language is any of the comma separated codes. Regions are ignored, so "en-GB" means "en".
+func (a ApplyLanguageInstruction) Apply(article types.Article, params *types.FilteringParams) bool {
+	if params.Language == "" {
+		return true
 	}
 
-	for _, format := range timeFormats {
-		date, err = time.Parse(format, dateStr)
-		if err == nil {
-			return date, nil
+	articleLanguage := language.Base(article.Language)
+	for _, code := range strings.Split(params.Language, ",") {
+		if articleLanguage != "" && language.Base(code) == articleLanguage {
+			return true
 		}
 	}
-	return time.Time{}, err
+
+	return false
 }
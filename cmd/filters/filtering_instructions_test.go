@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gogator/cmd/types"
 	"testing"
+	"time"
 )
 
 func TestApplyKeywordsInstruction_Apply(t *testing.T) {
@@ -68,7 +69,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-12",
+					PublishedAt: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-11",
@@ -82,7 +83,8 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-55",
+					PubDate:        "2024-05-55",
+					InvalidPubDate: true,
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-12",
@@ -96,7 +98,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-12",
+					PublishedAt: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-55",
@@ -110,7 +112,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-12",
+					PublishedAt: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					EndingTimestamp: "2024-05-55",
@@ -124,7 +126,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-10",
+					PublishedAt: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-12",
@@ -138,7 +140,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-14",
+					PublishedAt: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					EndingTimestamp: "2024-05-13",
@@ -152,7 +154,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-14",
+					PublishedAt: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-12",
@@ -167,7 +169,7 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 				Params  *types.FilteringParams
 			}{
 				Article: types.Article{
-					PubDate: "2024-05-19",
+					PublishedAt: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC),
 				},
 				Params: &types.FilteringParams{
 					StartingTimestamp: "2024-05-12",
@@ -187,3 +189,102 @@ func TestApplyDateRangeInstruction_Apply(t *testing.T) {
 		assert.Equal(t, match, testCase.ExpectedOutput)
 	}
 }
+
+func TestApplyDateRangeInstruction_Compile(t *testing.T) {
+	now := time.Date(2024, 7, 20, 12, 0, 0, 0, time.UTC)
+	params := &types.FilteringParams{StartingTimestamp: "-6h"}
+
+	instruction := ApplyDateRangeInstruction{}.Compile(params, now)
+
+	assert.True(t, instruction.Apply(types.Article{PublishedAt: now.Add(-6 * time.Hour)}, params),
+		"relative bound is resolved against the moment of compilation")
+	assert.False(t, instruction.Apply(types.Article{PublishedAt: now.Add(-7 * time.Hour)}, params))
+
+	invalid := &types.FilteringParams{StartingTimestamp: "yesterday-ish"}
+	assert.False(t, ApplyDateRangeInstruction{}.Compile(invalid, now).Apply(types.Article{PublishedAt: now}, invalid))
+}
+
+func TestApplyKeywordsInstruction_Compile(t *testing.T) {
+	params := &types.FilteringParams{Keywords: "ukraine -russia"}
+	instruction := ApplyKeywordsInstruction{}.Compile(params, time.Now())
+
+	assert.True(t, instruction.Apply(types.Article{Title: "elections in ukraine"}, params))
+	assert.False(t, instruction.Apply(types.Article{Title: "ukraine and russia"}, params))
+
+	invalid := &types.FilteringParams{Keywords: "(ukraine"}
+	assert.False(t, ApplyKeywordsInstruction{}.Compile(invalid, time.Now()).Apply(types.Article{Title: "ukraine"}, invalid))
+}
+
+func TestApplyCategoriesInstruction_Apply(t *testing.T) {
+	article := types.Article{Categories: []string{"World", "Europe"}}
+
+	testCases := []struct {
+		name       string
+		categories string
+		expected   bool
+	}{
+		{name: "Without categories", categories: "", expected: true},
+		{name: "Category of the article", categories: "Europe", expected: true},
+		{name: "Category in other case", categories: "sport,world", expected: true},
+		{name: "Other category", categories: "Sport", expected: false},
+	}
+
+	instruction := ApplyCategoriesInstruction{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match := instruction.Apply(article, &types.FilteringParams{Categories: tc.categories})
+			assert.Equal(t, tc.expected, match)
+		})
+	}
+}
+
+func TestApplyAuthorsInstruction_Apply(t *testing.T) {
+	article := types.Article{Author: "news@example.com (John Doe)"}
+
+	testCases := []struct {
+		name     string
+		authors  string
+		expected bool
+	}{
+		{name: "Without authors", authors: "", expected: true},
+		{name: "Name of the author", authors: "John Doe", expected: true},
+		{name: "Part of the name in other case", authors: "jane,doe", expected: true},
+		{name: "Other author", authors: "Jane Roe", expected: false},
+	}
+
+	instruction := ApplyAuthorsInstruction{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match := instruction.Apply(article, &types.FilteringParams{Authors: tc.authors})
+			assert.Equal(t, tc.expected, match)
+		})
+	}
+
+	assert.False(t, instruction.Apply(types.Article{}, &types.FilteringParams{Authors: "Doe"}))
+}
+
+func TestApplyLanguageInstruction_Apply(t *testing.T) {
+	article := types.Article{Language: "uk"}
+
+	testCases := []struct {
+		name     string
+		language string
+		expected bool
+	}{
+		{name: "Without language", language: "", expected: true},
+		{name: "Language of the article", language: "uk", expected: true},
+		{name: "One of the languages", language: "en, UK", expected: true},
+		{name: "Language with region", language: "uk-UA", expected: true},
+		{name: "Other language", language: "en", expected: false},
+	}
+
+	instruction := ApplyLanguageInstruction{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match := instruction.Apply(article, &types.FilteringParams{Language: tc.language})
+			assert.Equal(t, tc.expected, match)
+		})
+	}
+
+	assert.False(t, instruction.Apply(types.Article{}, &types.FilteringParams{Language: "en"}))
+}
@@ -2,6 +2,7 @@ package filters
 
 import (
 	"gogator/cmd/types"
+	"time"
 )
 
 type FactoryInterface interface {
@@ -14,6 +15,13 @@ type Instruction interface {
 	Apply(article types.Article, params *types.FilteringParams) bool
 }
 
+// CompilableInstruction is an Instruction, which parses its parameters once per request.
+// Compile returns the Instruction, which applies the parsed parameters to every article.
+type CompilableInstruction interface {
+	Instruction
+	Compile(params *types.FilteringParams, now time.Time) Instruction
+}
+
 type InstructionFactory struct{}
 
 // CreateApplyKeywordInstruction initializes keyword instruction.
@@ -33,3 +41,21 @@ func (g InstructionFactory) CreateApplyDataRangeInstruction() Instruction {
 func (g InstructionFactory) CreateSourcesInstruction() Instruction {
 	return ApplySourcesInstruction{}
 }
+
+// CreateCategoriesInstruction initializes categories instruction.
+// It is used to check if article belongs to any of given categories
+func (g InstructionFactory) CreateCategoriesInstruction() Instruction {
+	return ApplyCategoriesInstruction{}
+}
+
+// CreateAuthorsInstruction initializes authors instruction.
+// It is used to check if article is written by any of given authors
+func (g InstructionFactory) CreateAuthorsInstruction() Instruction {
+	return ApplyAuthorsInstruction{}
+}
+
+// CreateLanguageInstruction initializes language instruction.
+// It is used to check if article is written in any of given languages
+func (g InstructionFactory) CreateLanguageInstruction() Instruction {
+	return ApplyLanguageInstruction{}
+}
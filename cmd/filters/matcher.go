@@ -0,0 +1,165 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrUnsupportedMatchMode is returned, when match mode is not one of the supported ones
+	ErrUnsupportedMatchMode = errors.New("unsupported match mode")
+
+	// ErrInvalidPattern is returned, when keyword is not a valid regular expression in RegexMatch mode
+	ErrInvalidPattern = errors.New("invalid regular expression")
+)
+
+// MatchModes are supported match modes
+var MatchModes = []string{types.ExactMatch, types.CaseInsensitiveMatch, types.WholeWordMatch, types.RegexMatch}
+
+// NewMatcher creates a matcher of the term, according to the match mode. Empty mode means types.CaseInsensitiveMatch.
+func NewMatcher(term, mode string) (types.TermMatcher, error) {
+	switch mode {
+	case types.ExactMatch:
+		return literalMatcher(term), nil
+	case types.CaseInsensitiveMatch, "":
+		return regexMatcher{re: regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))}, nil
+	case types.WholeWordMatch:
+		return wordMatcher(term), nil
+	case types.RegexMatch:
+		re, err := regexp.Compile(term)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidPattern, term, err)
+		}
+		return regexMatcher{re: re}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s. Supported modes are: %v", ErrUnsupportedMatchMode, mode, MatchModes)
+}
+
+// ValidateMatchMode checks if the match mode is supported. Empty mode is valid.
+func ValidateMatchMode(mode string) error {
+	_, err := NewMatcher("", mode)
+	return err
+}
+
+// Highlight wraps all occurrences of the terms in the text with marks.
+// Overlapping occurrences of different terms are marked once.
+func Highlight(text string, matchers []types.TermMatcher, mark string) string {
+	var ranges [][]int
+	for _, matcher := range matchers {
+		for _, r := range matcher.FindAll(text) {
+			if r[1] > r[0] {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	if len(ranges) == 0 {
+		return text
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+
+	var result strings.Builder
+	last := 0
+	for i := 0; i < len(ranges); i++ {
+		start, end := ranges[i][0], ranges[i][1]
+		for i+1 < len(ranges) && ranges[i+1][0] <= end {
+			i++
+			end = max(end, ranges[i][1])
+		}
+
+		result.WriteString(text[last:start])
+		result.WriteString(mark + text[start:end] + mark)
+		last = end
+	}
+	result.WriteString(text[last:])
+
+	return result.String()
+}
+
+// literalMatcher finds the term as it is
+type literalMatcher string
+
+func (m literalMatcher) Match(text string) bool {
+	return strings.Contains(text, string(m))
+}
+
+func (m literalMatcher) FindAll(text string) [][]int {
+	return findAll(text, string(m), func(string, int, int) bool {
+		return true
+	})
+}
+
+// wordMatcher finds the term, which is not a part of a longer word
+type wordMatcher string
+
+func (m wordMatcher) Match(text string) bool {
+	return len(m.FindAll(text)) > 0
+}
+
+func (m wordMatcher) FindAll(text string) [][]int {
+	return findAll(text, string(m), isWholeWord)
+}
+
+// regexMatcher finds matches of the regular expression
+type regexMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m regexMatcher) Match(text string) bool {
+	return m.re.MatchString(text)
+}
+
+func (m regexMatcher) FindAll(text string) [][]int {
+	return m.re.FindAllStringIndex(text, -1)
+}
+
+// findAll returns indexes of non-overlapping occurrences of the term in the text, which are accepted
+func findAll(text, term string, accept func(text string, start, end int) bool) [][]int {
+	if term == "" {
+		return nil
+	}
+
+	var ranges [][]int
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			break
+		}
+
+		start := offset + i
+		end := start + len(term)
+		if accept(text, start, end) {
+			ranges = append(ranges, []int{start, end})
+			offset = end
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+
+	return ranges
+}
+
+// isWholeWord reports whether text[start:end] is not surrounded by letters or digits
+func isWholeWord(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+
+	return !isWordRune(before) && !isWordRune(after)
+}
+
+// isWordRune reports whether the rune is a part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
@@ -0,0 +1,124 @@
+package filters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestNewMatcher(t *testing.T) {
+	testCases := []struct {
+		name     string
+		term     string
+		mode     string
+		text     string
+		expected [][]int
+	}{
+		{
+			name:     "Exact match respects case",
+			term:     "covid",
+			mode:     types.ExactMatch,
+			text:     "COVID and covid",
+			expected: [][]int{{10, 15}},
+		},
+		{
+			name:     "Empty mode is case-insensitive",
+			term:     "covid",
+			mode:     "",
+			text:     "COVID and covid",
+			expected: [][]int{{0, 5}, {10, 15}},
+		},
+		{
+			name:     "Case-insensitive match",
+			term:     "covid",
+			mode:     types.CaseInsensitiveMatch,
+			text:     "COVID and covid",
+			expected: [][]int{{0, 5}, {10, 15}},
+		},
+		{
+			name:     "Case-insensitive match escapes special characters",
+			term:     "u.s.",
+			mode:     types.CaseInsensitiveMatch,
+			text:     "U.S. and USSR",
+			expected: [][]int{{0, 4}},
+		},
+		{
+			name:     "Whole-word match skips parts of words",
+			term:     "art",
+			mode:     types.WholeWordMatch,
+			text:     "artists, art-deco and state art",
+			expected: [][]int{{9, 12}, {28, 31}},
+		},
+		{
+			name:     "Whole-word match of non-ASCII words",
+			term:     "Київ",
+			mode:     types.WholeWordMatch,
+			text:     "Київщина, Київ",
+			expected: [][]int{{18, 26}},
+		},
+		{
+			name:     "Regex match",
+			term:     "COVID-?19",
+			mode:     types.RegexMatch,
+			text:     "COVID19 and COVID-19",
+			expected: [][]int{{0, 7}, {12, 20}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(tt.term, tt.mode)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, matcher.FindAll(tt.text))
+			assert.Equal(t, len(tt.expected) > 0, matcher.Match(tt.text))
+		})
+	}
+}
+
+func TestNewMatcher_Errors(t *testing.T) {
+	_, err := NewMatcher("covid", "fuzzy")
+	assert.ErrorIs(t, err, ErrUnsupportedMatchMode)
+
+	_, err = NewMatcher("COVID-(19", types.RegexMatch)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
+
+func TestHighlight(t *testing.T) {
+	exact := func(term string) types.TermMatcher {
+		matcher, err := NewMatcher(term, types.ExactMatch)
+		assert.NoError(t, err)
+		return matcher
+	}
+
+	testCases := []struct {
+		name     string
+		text     string
+		matchers []types.TermMatcher
+		expected string
+	}{
+		{
+			name:     "No matchers",
+			text:     "hello world",
+			expected: "hello world",
+		},
+		{
+			name:     "Several occurrences",
+			text:     "hello world, hello",
+			matchers: []types.TermMatcher{exact("hello")},
+			expected: "[!]hello[!] world, [!]hello[!]",
+		},
+		{
+			name:     "Overlapping terms are marked once",
+			text:     "hello world",
+			matchers: []types.TermMatcher{exact("hello wo"), exact("world"), exact("lo")},
+			expected: "[!]hello world[!]",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Highlight(tt.text, tt.matchers, "[!]"))
+		})
+	}
+}
@@ -0,0 +1,477 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// TitleField scopes the term to the title of the article: title:ukraine
+	TitleField = "title"
+
+	// DescriptionField scopes the term to the description of the article: description:ukraine
+	DescriptionField = "description"
+
+	// SourceField matches the publisher of the article: source:bbc
+	SourceField = "source"
+
+	// ContentField scopes the term to the full content of the article: content:ukraine
+	ContentField = "content"
+)
+
+// ErrQuerySyntax is returned, when keywords query can not be parsed
+var ErrQuerySyntax = errors.New("invalid keywords query")
+
+// Query is a parsed keywords query, which decides whether the article matches it.
+//
+// Keywords query is a small boolean language:
+// /  1. Terms separated by spaces or AND must all be present: ukraine war
+// /  2. Terms separated by commas or OR are alternatives: ukraine,poland or ukraine OR poland
+// /  3. NOT or '-' excludes the term: ukraine -russia or ukraine NOT russia
+// /  4. Parentheses group terms: (ukraine OR poland) AND election
+// /  5. Double quotes match exact phrases: "prime minister"
+// /  6. Fields scope terms to a part of the article: title:election, description:rain, content:rain, source:bbc
+//
+// Operators are recognized only in upper case. AND binds tighter than OR, so "a b, c" means "(a AND b) OR c".
+// Terms without a field are matched in the title, the description and the content. Empty query matches every article.
+// In types.RegexMatch mode every term is a regular expression, so patterns containing spaces, commas
+// or parentheses should be quoted: "(covid|corona)-?19".
+type Query interface {
+	Match(article types.Article) bool
+}
+
+// ParseQuery parses keywords into a Query, which matches terms according to the match mode
+// (see NewMatcher). Returned errors wrap ErrQuerySyntax, ErrUnsupportedMatchMode or ErrInvalidPattern.
+//
+// Parsed queries are cached, so filtering many articles, validating and highlighting the same keywords
+// parses them and compiles their regular expressions once.
+func ParseQuery(keywords, mode string) (Query, error) {
+	key := queryKey{keywords: keywords, mode: mode}
+
+	queryCache.mu.Lock()
+	defer queryCache.mu.Unlock()
+
+	if parsed, exists := queryCache.keyToQuery[key]; exists {
+		return parsed.query, parsed.err
+	}
+
+	query, err := parseQuery(keywords, mode)
+
+	if len(queryCache.keyToQuery) >= queryCacheSize {
+		clear(queryCache.keyToQuery)
+	}
+	queryCache.keyToQuery[key] = parsedQuery{query: query, err: err}
+
+	return query, err
+}
+
+// parseQuery parses keywords into a Query without caching
+func parseQuery(keywords, mode string) (Query, error) {
+	err := ValidateMatchMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenize(keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return matchAll{}, nil
+	}
+
+	p := &queryParser{tokens: tokens, mode: mode}
+	query, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	return query, nil
+}
+
+// Terms returns texts of the terms, which articles should contain, e.g. to rank them.
+// Excluded terms and sources are not returned.
+func Terms(query Query) []string {
+	var terms []string
+	for _, term := range positiveTerms(query, false) {
+		terms = append(terms, term.text)
+	}
+
+	return terms
+}
+
+// Matchers returns matchers of the terms, which articles should contain, e.g. to highlight them.
+// Excluded terms and sources are not returned.
+func Matchers(query Query) []types.TermMatcher {
+	var matchers []types.TermMatcher
+	for _, term := range positiveTerms(query, false) {
+		matchers = append(matchers, term.matcher)
+	}
+
+	return matchers
+}
+
+// positiveTerms returns terms of the query, which are not negated and are matched in the text of articles
+func positiveTerms(query Query, negated bool) []termQuery {
+	var terms []termQuery
+
+	switch q := query.(type) {
+	case termQuery:
+		if !negated && q.field != SourceField {
+			terms = append(terms, q)
+		}
+	case notQuery:
+		terms = append(terms, positiveTerms(q.query, !negated)...)
+	case andQuery:
+		for _, sub := range q {
+			terms = append(terms, positiveTerms(sub, negated)...)
+		}
+	case orQuery:
+		for _, sub := range q {
+			terms = append(terms, positiveTerms(sub, negated)...)
+		}
+	}
+
+	return terms
+}
+
+// matchAll matches every article
+type matchAll struct{}
+
+func (matchAll) Match(types.Article) bool {
+	return true
+}
+
+// termQuery matches articles, which contain the text in the field. Empty field means title, description or content.
+// Publisher of the article is compared with the text as it is, other fields are matched with the matcher.
+type termQuery struct {
+	field   string
+	text    string
+	matcher types.TermMatcher
+}
+
+func (q termQuery) Match(article types.Article) bool {
+	switch q.field {
+	case TitleField:
+		return q.matcher.Match(article.Title)
+	case DescriptionField:
+		return q.matcher.Match(article.Description)
+	case ContentField:
+		return q.matcher.Match(article.Content)
+	case SourceField:
+		return article.Publisher == q.text
+	}
+
+	return q.matcher.Match(article.Title) || q.matcher.Match(article.Description) || q.matcher.Match(article.Content)
+}
+
+// notQuery matches articles, which do not match the query
+type notQuery struct {
+	query Query
+}
+
+func (q notQuery) Match(article types.Article) bool {
+	return !q.query.Match(article)
+}
+
+// andQuery matches articles, which match all of the queries
+type andQuery []Query
+
+func (q andQuery) Match(article types.Article) bool {
+	for _, sub := range q {
+		if !sub.Match(article) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// orQuery matches articles, which match any of the queries
+type orQuery []Query
+
+func (q orQuery) Match(article types.Article) bool {
+	for _, sub := range q {
+		if sub.Match(article) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenKind is a kind of lexical token of the query
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	phraseToken
+	andToken
+	orToken
+	notToken
+	openToken
+	closeToken
+)
+
+// token is a lexical token of the query. Field is set for scoped words and phrases.
+type token struct {
+	kind  tokenKind
+	text  string
+	field string
+	pos   int
+}
+
+// fields are names of fields, which terms can be scoped to
+var fields = map[string]bool{
+	TitleField:       true,
+	DescriptionField: true,
+	ContentField:     true,
+	SourceField:      true,
+}
+
+// tokenize splits the query into tokens. '-' before a term is returned as NOT, and commas as OR.
+func tokenize(keywords string) ([]token, error) {
+	var tokens []token
+	runes := []rune(keywords)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: openToken, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: closeToken, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: orToken, text: ",", pos: i})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ',':
+			tokens = append(tokens, token{kind: notToken, text: "-", pos: i})
+			i++
+		default:
+			t, next, err := readTerm(runes, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, t)
+			i = next
+		}
+	}
+
+	return tokens, nil
+}
+
+// readTerm reads a word or a phrase starting at position start, with an optional field prefix,
+// and returns it together with the position after it
+func readTerm(runes []rune, start int) (token, int, error) {
+	i := start
+	for i < len(runes) && !isDelimiter(runes[i]) && runes[i] != '"' {
+		i++
+	}
+	word := string(runes[start:i])
+
+	field := ""
+	if name, value, found := strings.Cut(word, ":"); found && fields[strings.ToLower(name)] {
+		field = strings.ToLower(name)
+		word = value
+	}
+
+	if word == "" && i < len(runes) && runes[i] == '"' {
+		end := i + 1
+		for end < len(runes) && runes[end] != '"' {
+			end++
+		}
+		if end == len(runes) {
+			return token{}, 0, fmt.Errorf("%w: unterminated phrase at position %d", ErrQuerySyntax, i)
+		}
+
+		phrase := strings.TrimSpace(string(runes[i+1 : end]))
+		if phrase == "" {
+			return token{}, 0, fmt.Errorf("%w: empty phrase at position %d", ErrQuerySyntax, i)
+		}
+
+		return token{kind: phraseToken, text: phrase, field: field, pos: start}, end + 1, nil
+	}
+
+	if word == "" {
+		return token{}, 0, fmt.Errorf("%w: missing term after %q at position %d", ErrQuerySyntax, field+":", start)
+	}
+
+	if field == "" {
+		switch word {
+		case "AND":
+			return token{kind: andToken, text: word, pos: start}, i, nil
+		case "OR":
+			return token{kind: orToken, text: word, pos: start}, i, nil
+		case "NOT":
+			return token{kind: notToken, text: word, pos: start}, i, nil
+		}
+	}
+
+	return token{kind: wordToken, text: word, field: field, pos: start}, i, nil
+}
+
+// isDelimiter reports whether the rune ends a word
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == ','
+}
+
+// queryParser is a recursive descent parser of the query:
+//
+//	or    = and { OR and }
+//	and   = unary { [AND] unary }
+//	unary = NOT unary | "(" or ")" | term
+type queryParser struct {
+	tokens []token
+	pos    int
+	mode   string
+}
+
+func (p *queryParser) parseOr() (Query, error) {
+	query, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := orQuery{query}
+	for !p.done() && p.peek().kind == orToken {
+		p.pos++
+
+		query, err = p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return queries, nil
+}
+
+func (p *queryParser) parseAnd() (Query, error) {
+	query, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := andQuery{query}
+	for !p.done() {
+		kind := p.peek().kind
+		if kind == orToken || kind == closeToken {
+			break
+		}
+		if kind == andToken {
+			p.pos++
+		}
+
+		query, err = p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return queries, nil
+}
+
+func (p *queryParser) parseUnary() (Query, error) {
+	if p.done() {
+		return nil, p.errorf("unexpected end of query")
+	}
+
+	t := p.peek()
+	p.pos++
+
+	switch t.kind {
+	case notToken:
+		query, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{query: query}, nil
+	case openToken:
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != closeToken {
+			return nil, fmt.Errorf("%w: unclosed parenthesis at position %d", ErrQuerySyntax, t.pos)
+		}
+		p.pos++
+		return query, nil
+	case wordToken, phraseToken:
+		if t.field == SourceField {
+			return termQuery{field: t.field, text: t.text}, nil
+		}
+
+		matcher, err := NewMatcher(t.text, p.mode)
+		if err != nil {
+			return nil, err
+		}
+		return termQuery{field: t.field, text: t.text, matcher: matcher}, nil
+	}
+
+	p.pos--
+	return nil, p.errorf("unexpected %q", t.text)
+}
+
+// peek returns the current token
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// done reports whether all tokens are parsed
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// errorf returns a syntax error at the position of the current token
+func (p *queryParser) errorf(format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if p.done() {
+		return fmt.Errorf("%w: %s", ErrQuerySyntax, message)
+	}
+
+	return fmt.Errorf("%w: %s at position %d", ErrQuerySyntax, message, p.peek().pos)
+}
+
+// queryCacheSize limits amount of cached queries. When it is reached, the cache is cleared.
+const queryCacheSize = 128
+
+// queryKey identifies parsed queries in the cache
+type queryKey struct {
+	keywords string
+	mode     string
+}
+
+// parsedQuery is a result of parsing, which is cached
+type parsedQuery struct {
+	query Query
+	err   error
+}
+
+// queryCache keeps recently parsed queries
+var queryCache = struct {
+	mu         sync.Mutex
+	keyToQuery map[queryKey]parsedQuery
+}{
+	keyToQuery: make(map[queryKey]parsedQuery),
+}
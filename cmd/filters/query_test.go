@@ -0,0 +1,200 @@
+package filters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestParseQuery_Match(t *testing.T) {
+	articles := []types.Article{
+		{
+			Title:       "Ukraine election results",
+			Description: "Prime minister wins",
+			Publisher:   "bbc",
+		},
+		{
+			Title:       "Poland election",
+			Description: "Results are expected tomorrow",
+			Content:     "Turnout was record high in Warsaw",
+			Publisher:   "abc",
+		},
+		{
+			Title:       "Russia and Ukraine talks",
+			Description: "The prime minister of Ukraine comments",
+			Publisher:   "abc",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		keywords string
+		expected []bool
+	}{
+		{
+			name:     "Empty query",
+			keywords: "  ",
+			expected: []bool{true, true, true},
+		},
+		{
+			name:     "Single term",
+			keywords: "Ukraine",
+			expected: []bool{true, false, true},
+		},
+		{
+			name:     "Comma-separated terms are alternatives",
+			keywords: "Poland,Russia",
+			expected: []bool{false, true, true},
+		},
+		{
+			name:     "OR operator",
+			keywords: "Poland OR Russia",
+			expected: []bool{false, true, true},
+		},
+		{
+			name:     "Terms separated by spaces are all required",
+			keywords: "Ukraine election",
+			expected: []bool{true, false, false},
+		},
+		{
+			name:     "AND operator",
+			keywords: "Ukraine AND election",
+			expected: []bool{true, false, false},
+		},
+		{
+			name:     "Excluded term",
+			keywords: "Ukraine -Russia",
+			expected: []bool{true, false, false},
+		},
+		{
+			name:     "NOT operator",
+			keywords: "NOT Ukraine",
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "AND binds tighter than OR",
+			keywords: "Ukraine talks, Poland",
+			expected: []bool{false, true, true},
+		},
+		{
+			name:     "Parentheses",
+			keywords: "(Ukraine OR Poland) AND election -results",
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "Phrase",
+			keywords: `"prime minister of"`,
+			expected: []bool{false, false, true},
+		},
+		{
+			name:     "Title field",
+			keywords: "title:Ukraine",
+			expected: []bool{true, false, true},
+		},
+		{
+			name:     "Description field with phrase",
+			keywords: `description:"of Ukraine"`,
+			expected: []bool{false, false, true},
+		},
+		{
+			name:     "Term in the content",
+			keywords: "Warsaw",
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "Content field",
+			keywords: "content:Warsaw -title:Warsaw",
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "Source field",
+			keywords: "election source:abc",
+			expected: []bool{false, true, false},
+		},
+		{
+			name:     "Hyphen inside of the term",
+			keywords: "Russia-Ukraine",
+			expected: []bool{false, false, false},
+		},
+		{
+			name:     "Lower case operators are terms",
+			keywords: "Poland not",
+			expected: []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ParseQuery(tt.keywords, types.ExactMatch)
+			assert.NoError(t, err)
+
+			for i, article := range articles {
+				assert.Equal(t, tt.expected[i], query.Match(article), article.Title)
+			}
+		})
+	}
+}
+
+func TestParseQuery_MatchMode(t *testing.T) {
+	article := types.Article{
+		Title:       "COVID-19 cases in Ukraine",
+		Description: "Statistics of the week",
+	}
+
+	testCases := []struct {
+		keywords string
+		mode     string
+		expected bool
+	}{
+		{keywords: "covid", mode: types.ExactMatch, expected: false},
+		{keywords: "covid", mode: types.CaseInsensitiveMatch, expected: true},
+		{keywords: "case", mode: types.WholeWordMatch, expected: false},
+		{keywords: "cases", mode: types.WholeWordMatch, expected: true},
+		{keywords: "COVID-?19 -title:Poland", mode: types.RegexMatch, expected: true},
+		{keywords: `"(covid|corona)-?19"`, mode: types.RegexMatch, expected: false},
+		{keywords: `"(?i)(covid|corona)-?19"`, mode: types.RegexMatch, expected: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.mode+" "+tt.keywords, func(t *testing.T) {
+			query, err := ParseQuery(tt.keywords, tt.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, query.Match(article))
+		})
+	}
+
+	_, err := ParseQuery("covid", "fuzzy")
+	assert.ErrorIs(t, err, ErrUnsupportedMatchMode)
+
+	_, err = ParseQuery(`"COVID-(19"`, types.RegexMatch)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
+
+func TestParseQuery_SyntaxError(t *testing.T) {
+	testCases := []string{
+		"(Ukraine OR Poland",
+		"Ukraine)",
+		`"prime minister`,
+		`""`,
+		"Ukraine OR",
+		"AND Ukraine",
+		"Ukraine,,Poland",
+		"title:",
+		"NOT",
+		"()",
+	}
+
+	for _, keywords := range testCases {
+		t.Run(keywords, func(t *testing.T) {
+			_, err := ParseQuery(keywords, "")
+			assert.ErrorIs(t, err, ErrQuerySyntax)
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	query, err := ParseQuery(`(Ukraine OR "prime minister") -Russia source:bbc title:election NOT (NOT talks)`, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Ukraine", "prime minister", "election", "talks"}, Terms(query))
+}
@@ -0,0 +1,2 @@
+// Package fsutil contains helpers for files, which are shared by packages persisting data of the application.
+package fsutil
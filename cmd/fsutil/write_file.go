@@ -0,0 +1,42 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFile replaces the file at path with data atomically.
+//
+// Data is written and synced to a temporary file in the same directory, which is then renamed,
+// so readers and a process killed in the middle of writing never observe a truncated file.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(file.Name(), perm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
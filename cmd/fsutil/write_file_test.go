@@ -0,0 +1,34 @@
+package fsutil
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sources.json")
+
+	err := WriteFile(path, []byte(`[{"name":"bbc"}]`), 0644)
+	assert.Nil(t, err)
+
+	err = WriteFile(path, []byte(`[]`), 0644)
+	assert.Nil(t, err)
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+
+	err = WriteFile(filepath.Join(dir, "missing", "sources.json"), []byte(`[]`), 0644)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,232 @@
+package language
+
+import (
+	"gogator/cmd/types"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	English   = "en"
+	Ukrainian = "uk"
+	Russian   = "ru"
+	Polish    = "pl"
+	German    = "de"
+	French    = "fr"
+	Spanish   = "es"
+
+	// minLetters is amount of letters, starting from which the language of the text is detected.
+	// Shorter texts, e.g. a single word of the title, have too few n-grams to tell similar languages apart.
+	minLetters = 12
+
+	// minSimilarity is the similarity of the text to the closest profile, below which the language is unknown
+	minSimilarity = 0.1
+
+	// maxTextLength is amount of runes of the text, which are analyzed. The beginning of long articles is enough.
+	maxTextLength = 3000
+)
+
+var (
+	// languageToScript maps supported languages to the script they are written in
+	languageToScript = map[string]*unicode.RangeTable{
+		English:   unicode.Latin,
+		Ukrainian: unicode.Cyrillic,
+		Russian:   unicode.Cyrillic,
+		Polish:    unicode.Latin,
+		German:    unicode.Latin,
+		French:    unicode.Latin,
+		Spanish:   unicode.Latin,
+	}
+
+	// profiles map supported languages to n-gram profiles of their samples
+	profiles = buildProfiles()
+
+	// codePattern matches language tags like "en", "uk" or "en-GB"
+	codePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{1,8})*$`)
+)
+
+// profile is a vector of n-gram frequencies of unit length
+type profile map[string]float64
+
+// Supported returns sorted codes of languages, which can be detected
+func Supported() []string {
+	codes := make([]string, 0, len(profiles))
+	for code := range profiles {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// IsSupported reports whether the language of the code can be detected. Regions are ignored, e.g. "en-GB" is supported.
+func IsSupported(code string) bool {
+	_, exists := profiles[Base(code)]
+	return exists
+}
+
+// IsCode reports whether the value looks like a language tag, e.g. "en" or "en-GB"
+func IsCode(value string) bool {
+	return codePattern.MatchString(value)
+}
+
+// Base returns the lower-cased primary language of the tag: "en-GB" -> "en"
+func Base(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+
+	return code
+}
+
+// Detect returns the code of the language the text is written in.
+// Empty string is returned, if the text is too short, or is not similar enough to any supported language.
+func Detect(text string) string {
+	if runes := []rune(text); len(runes) > maxTextLength {
+		text = string(runes[:maxTextLength])
+	}
+
+	script := dominantScript(text)
+	if script == nil {
+		return ""
+	}
+
+	ngrams, letters := countNgrams(text)
+	if letters < minLetters {
+		return ""
+	}
+
+	var (
+		best           string
+		bestSimilarity float64
+	)
+	for _, code := range Supported() {
+		if languageToScript[code] != script {
+			continue
+		}
+
+		similarity := profiles[code].similarity(ngrams)
+		if similarity > bestSimilarity {
+			best, bestSimilarity = code, similarity
+		}
+	}
+
+	if bestSimilarity < minSimilarity {
+		return ""
+	}
+
+	return best
+}
+
+// Assign sets the language of articles to the language of their title, description and content.
+// If it can not be detected, the language declared by the feed is kept, reduced to its base code.
+func Assign(articles []types.Article) {
+	for i := range articles {
+		text := articles[i].Title + "\n" + articles[i].Description + "\n" + articles[i].Content
+
+		detected := Detect(text)
+		if detected == "" {
+			detected = Base(articles[i].Language)
+		}
+
+		articles[i].Language = detected
+	}
+}
+
+// dominantScript returns the script of the most letters of the text: Latin or Cyrillic.
+// Nil is returned, if the text has no letters or is written in another script.
+func dominantScript(text string) *unicode.RangeTable {
+	var latin, cyrillic, other int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.IsLetter(r):
+			other++
+		}
+	}
+
+	switch {
+	case latin > cyrillic && latin > other:
+		return unicode.Latin
+	case cyrillic > latin && cyrillic > other:
+		return unicode.Cyrillic
+	}
+
+	return nil
+}
+
+// countNgrams returns frequencies of character n-grams up to trigrams of the lower-cased words of the text,
+// padded with spaces, so beginnings and endings of words are distinguished, and amount of letters.
+// Single letters and bigrams are counted as well, since letters like "ї" or "ß" are specific to a language.
+func countNgrams(text string) (map[string]float64, int) {
+	ngrams := make(map[string]float64)
+	letters := 0
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		letters += len(runes) - 2
+
+		for n := 1; n <= 3; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if n == 1 && runes[i] == ' ' {
+					continue
+				}
+				ngrams[string(runes[i:i+n])]++
+			}
+		}
+	}
+
+	return ngrams, letters
+}
+
+// buildProfiles builds n-gram profiles of samples
+func buildProfiles() map[string]profile {
+	codeToProfile := make(map[string]profile, len(samples))
+	for code, sample := range samples {
+		ngrams, _ := countNgrams(sample)
+		codeToProfile[code] = normalize(ngrams)
+	}
+
+	return codeToProfile
+}
+
+// normalize scales the vector of frequencies to unit length
+func normalize(ngrams map[string]float64) profile {
+	norm := 0.0
+	for _, frequency := range ngrams {
+		norm += frequency * frequency
+	}
+	norm = math.Sqrt(norm)
+
+	normalized := make(profile, len(ngrams))
+	for ngram, frequency := range ngrams {
+		normalized[ngram] = frequency / norm
+	}
+
+	return normalized
+}
+
+// similarity returns the cosine similarity of the profile and n-gram frequencies of the text
+func (p profile) similarity(ngrams map[string]float64) float64 {
+	var dot, norm float64
+	for ngram, frequency := range ngrams {
+		dot += p[ngram] * frequency
+		norm += frequency * frequency
+	}
+
+	if norm == 0 {
+		return 0
+	}
+
+	return dot / math.Sqrt(norm)
+}
@@ -0,0 +1,110 @@
+package language
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "English",
+			text:     "Stocks fell sharply on Wall Street after the Federal Reserve signalled more rate hikes",
+			expected: English,
+		},
+		{
+			name:     "Ukrainian",
+			text:     "Верховна Рада ухвалила закон про державний бюджет на наступний рік",
+			expected: Ukrainian,
+		},
+		{
+			name:     "Russian",
+			text:     "Центральный банк сохранил ключевую ставку и предупредил о росте инфляции",
+			expected: Russian,
+		},
+		{
+			name:     "Polish",
+			text:     "Sejm przyjął ustawę budżetową, a opozycja zapowiedziała skargę do trybunału",
+			expected: Polish,
+		},
+		{
+			name:     "German",
+			text:     "Die Bundesregierung will die Steuern für Unternehmen im nächsten Jahr senken",
+			expected: German,
+		},
+		{
+			name:     "French",
+			text:     "Le président a présenté un nouveau plan pour réduire la dette publique du pays",
+			expected: French,
+		},
+		{
+			name:     "Spanish",
+			text:     "El Congreso aprobó la reforma de las pensiones tras un largo debate con los sindicatos",
+			expected: Spanish,
+		},
+		{
+			name:     "Too short",
+			text:     "Bitcoin",
+			expected: "",
+		},
+		{
+			name:     "Unsupported script",
+			text:     "東京で新しい経済対策が発表されました。政府は来年度の予算を増やす方針です。",
+			expected: "",
+		},
+		{
+			name:     "Empty",
+			text:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(tt.text))
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	articles := []types.Article{
+		{Title: "Parliament approved the budget for the next year after a long debate", Language: "uk"},
+		{Title: "Brexit", Language: "en-GB"},
+		{Title: "Brexit"},
+	}
+
+	Assign(articles)
+
+	assert.Equal(t, English, articles[0].Language)
+	assert.Equal(t, English, articles[1].Language)
+	assert.Equal(t, "", articles[2].Language)
+}
+
+func TestBase(t *testing.T) {
+	assert.Equal(t, "en", Base("en-GB"))
+	assert.Equal(t, "uk", Base(" UK "))
+	assert.Equal(t, "pt", Base("pt_BR"))
+	assert.Equal(t, "", Base(""))
+}
+
+func TestIsCode(t *testing.T) {
+	assert.True(t, IsCode("en"))
+	assert.True(t, IsCode("en-GB"))
+	assert.True(t, IsCode("fil"))
+	assert.False(t, IsCode("english"))
+	assert.False(t, IsCode("e"))
+	assert.False(t, IsCode("en-"))
+	assert.False(t, IsCode(""))
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported("uk"))
+	assert.True(t, IsSupported("en-US"))
+	assert.False(t, IsSupported("it"))
+	assert.Equal(t, []string{"de", "en", "es", "fr", "pl", "ru", "uk"}, Supported())
+}
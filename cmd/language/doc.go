@@ -0,0 +1,7 @@
+// Package language is used to detect the language of articles offline.
+//
+// Every supported language has a profile of character n-grams (letters, bigrams and trigrams),
+// built from a sample text of the language. Text is detected by comparing its own n-grams
+// with the profiles, and the closest profile wins.
+// Languages are identified by ISO 639-1 codes, e.g. "en" or "uk".
+package language
@@ -0,0 +1,85 @@
+package language
+
+// samples are texts in supported languages, which their n-gram profiles are built from.
+// They are written in the register of news, so profiles reflect the vocabulary of articles.
+var samples = map[string]string{
+	English: `The government said on Monday that it would raise taxes on large companies to pay for new schools
+and hospitals, after months of talks with the opposition. The prime minister told reporters that the plan
+was the only way to keep the budget under control while the economy is still growing slowly. Critics say
+the changes will hurt small businesses and push prices higher for ordinary families. Officials from the
+central bank have warned that inflation could rise again this year if energy prices remain high. Meanwhile,
+thousands of people gathered in the capital to protest against the reform, and police said the rally was
+peaceful. The president is expected to sign the law next week, although several members of parliament
+have asked for more time to review the details. Analysts believe that the market will react calmly to the
+news, because investors have been waiting for this decision for a long time. Football fans celebrated the
+victory of the national team in the evening, and the match was watched by millions of people around the world.`,
+
+	Ukrainian: `Уряд у понеділок заявив, що підвищить податки для великих компаній, щоб фінансувати нові школи
+та лікарні, після кількох місяців переговорів з опозицією. Прем'єр-міністр повідомив журналістам, що цей план
+є єдиним способом утримати бюджет під контролем, поки економіка зростає повільно. Критики кажуть, що зміни
+зашкодять малому бізнесу та призведуть до зростання цін для звичайних родин. Представники Національного банку
+попередили, що інфляція цього року може знову зрости, якщо ціни на енергоносії залишаться високими. Тим часом
+тисячі людей зібралися у столиці, щоб протестувати проти реформи, а поліція повідомила, що акція була мирною.
+Очікується, що президент підпише закон наступного тижня, хоча кілька народних депутатів попросили більше часу
+для розгляду деталей. Аналітики вважають, що ринок спокійно відреагує на новини, адже інвестори давно чекали
+на це рішення. Увечері вболівальники святкували перемогу збірної України, і матч дивилися мільйони людей у світі.`,
+
+	Russian: `Правительство в понедельник заявило, что повысит налоги для крупных компаний, чтобы финансировать
+новые школы и больницы, после нескольких месяцев переговоров с оппозицией. Премьер-министр сообщил журналистам,
+что этот план является единственным способом удержать бюджет под контролем, пока экономика растёт медленно.
+Критики говорят, что изменения навредят малому бизнесу и приведут к росту цен для обычных семей. Представители
+центрального банка предупредили, что инфляция в этом году может снова вырасти, если цены на энергоносители
+останутся высокими. Тем временем тысячи людей собрались в столице, чтобы протестовать против реформы, а полиция
+сообщила, что акция была мирной. Ожидается, что президент подпишет закон на следующей неделе, хотя несколько
+депутатов попросили больше времени для рассмотрения деталей. Аналитики считают, что рынок спокойно отреагирует
+на новости, ведь инвесторы давно ждали этого решения. Вечером болельщики праздновали победу сборной, и матч
+смотрели миллионы людей во всём мире.`,
+
+	Polish: `Rząd poinformował w poniedziałek, że podniesie podatki dla dużych firm, aby sfinansować nowe szkoły
+i szpitale, po kilku miesiącach rozmów z opozycją. Premier powiedział dziennikarzom, że ten plan jest jedynym
+sposobem, by utrzymać budżet pod kontrolą, dopóki gospodarka rośnie powoli. Krytycy twierdzą, że zmiany
+zaszkodzą małym przedsiębiorstwom i spowodują wzrost cen dla zwykłych rodzin. Przedstawiciele banku centralnego
+ostrzegli, że inflacja może w tym roku ponownie wzrosnąć, jeśli ceny energii pozostaną wysokie. Tymczasem
+tysiące ludzi zebrało się w stolicy, aby protestować przeciwko reformie, a policja przekazała, że manifestacja
+była pokojowa. Prezydent ma podpisać ustawę w przyszłym tygodniu, chociaż kilku posłów poprosiło o więcej czasu
+na analizę szczegółów. Analitycy uważają, że rynek spokojnie zareaguje na te wiadomości, ponieważ inwestorzy
+długo czekali na tę decyzję. Wieczorem kibice świętowali zwycięstwo reprezentacji, a mecz oglądały miliony
+ludzi na całym świecie.`,
+
+	German: `Die Regierung hat am Montag angekündigt, die Steuern für große Unternehmen zu erhöhen, um neue Schulen
+und Krankenhäuser zu finanzieren, nachdem sie monatelang mit der Opposition verhandelt hatte. Der Regierungschef
+sagte den Journalisten, dass dieser Plan der einzige Weg sei, den Haushalt unter Kontrolle zu halten, während die
+Wirtschaft nur langsam wächst. Kritiker sagen, dass die Änderungen kleinen Betrieben schaden und die Preise für
+normale Familien erhöhen werden. Vertreter der Zentralbank warnten, dass die Inflation in diesem Jahr wieder
+steigen könnte, wenn die Energiepreise hoch bleiben. Unterdessen versammelten sich tausende Menschen in der
+Hauptstadt, um gegen die Reform zu protestieren, und die Polizei teilte mit, dass die Kundgebung friedlich war.
+Der Präsident wird das Gesetz voraussichtlich nächste Woche unterzeichnen, obwohl mehrere Abgeordnete mehr Zeit
+für die Prüfung der Einzelheiten verlangt haben. Analysten glauben, dass der Markt ruhig auf die Nachrichten
+reagieren wird, weil die Anleger lange auf diese Entscheidung gewartet haben. Am Abend feierten die Fans den
+Sieg der Nationalmannschaft, und das Spiel wurde von Millionen Menschen auf der ganzen Welt gesehen.`,
+
+	French: `Le gouvernement a annoncé lundi qu'il allait augmenter les impôts des grandes entreprises pour financer
+de nouvelles écoles et des hôpitaux, après plusieurs mois de négociations avec l'opposition. Le premier ministre
+a déclaré aux journalistes que ce plan était le seul moyen de garder le budget sous contrôle pendant que
+l'économie progresse lentement. Les critiques estiment que ces changements vont nuire aux petites entreprises et
+faire monter les prix pour les familles ordinaires. Les représentants de la banque centrale ont averti que
+l'inflation pourrait repartir à la hausse cette année si les prix de l'énergie restent élevés. Pendant ce temps,
+des milliers de personnes se sont rassemblées dans la capitale pour protester contre la réforme, et la police a
+indiqué que la manifestation était pacifique. Le président devrait signer la loi la semaine prochaine, bien que
+plusieurs députés aient demandé plus de temps pour examiner les détails. Les analystes pensent que le marché
+réagira calmement à ces nouvelles, car les investisseurs attendaient cette décision depuis longtemps. Le soir,
+les supporters ont fêté la victoire de l'équipe nationale, et le match a été suivi par des millions de personnes
+dans le monde entier.`,
+
+	Spanish: `El gobierno anunció el lunes que subirá los impuestos a las grandes empresas para financiar nuevas
+escuelas y hospitales, después de varios meses de negociaciones con la oposición. El presidente del gobierno
+dijo a los periodistas que este plan es la única manera de mantener el presupuesto bajo control mientras la
+economía crece lentamente. Los críticos afirman que los cambios perjudicarán a las pequeñas empresas y harán
+subir los precios para las familias. Los representantes del banco central advirtieron que la inflación podría
+volver a aumentar este año si los precios de la energía siguen siendo altos. Mientras tanto, miles de personas
+se reunieron en la capital para protestar contra la reforma, y la policía informó de que la manifestación fue
+pacífica. Se espera que el rey firme la ley la próxima semana, aunque varios diputados han pedido más tiempo
+para revisar los detalles. Los analistas creen que el mercado reaccionará con calma a la noticia, porque los
+inversores llevaban mucho tiempo esperando esta decisión. Por la noche, los aficionados celebraron la victoria
+de la selección nacional, y el partido fue visto por millones de personas en todo el mundo.`,
+}
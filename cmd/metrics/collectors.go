@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gogator/cmd/storage"
+	"gogator/cmd/types"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	storedArticlesDesc = prometheus.NewDesc(namespace+"_stored_articles",
+		"Amount of stored articles per publication day.", []string{"day"}, nil)
+
+	storedSourceArticlesDesc = prometheus.NewDesc(namespace+"_stored_source_articles",
+		"Amount of stored articles per publisher.", []string{"source"}, nil)
+
+	fetchSuccessDesc = prometheus.NewDesc(namespace+"_source_fetch_success",
+		"Whether the last fetching of the source succeeded (1) or failed (0).", []string{"source"}, nil)
+
+	fetchDurationDesc = prometheus.NewDesc(namespace+"_source_fetch_duration_seconds",
+		"Duration of the last fetching of the source.", []string{"source"}, nil)
+
+	fetchArticlesDesc = prometheus.NewDesc(namespace+"_source_fetch_articles",
+		"Amount of articles, received in the last fetching of the source.", []string{"source"}, nil)
+
+	fetchTimestampDesc = prometheus.NewDesc(namespace+"_source_fetch_timestamp_seconds",
+		"Unix time of the last fetching of the source.", []string{"source"}, nil)
+)
+
+// StatsStore is the storage, which stored articles are counted in
+type StatsStore interface {
+	Stats() (storage.Stats, error)
+	ModTime() (time.Time, error)
+}
+
+// RegisterStorage registers collectors of stored articles and of the last fetch report in Registry.
+// readReport should return os.ErrNotExist, if news were not fetched yet.
+// If collectors are already registered, e.g. the server was configured again, they are switched
+// to the given store and report.
+func RegisterStorage(store StatsStore, readReport func() ([]types.FetchResult, error)) error {
+	storageColl := &storageCollector{store: store}
+	existing, err := register(storageColl)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existing.(*storageCollector).setStore(store)
+	}
+
+	reportColl := &fetchReportCollector{readReport: readReport}
+	existing, err = register(reportColl)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existing.(*fetchReportCollector).setReadReport(readReport)
+	}
+
+	return nil
+}
+
+// register registers collector in Registry. If the same collector is already registered,
+// it is returned instead of an error.
+func register(collector prometheus.Collector) (prometheus.Collector, error) {
+	err := Registry.Register(collector)
+
+	var registeredErr prometheus.AlreadyRegisteredError
+	if errors.As(err, &registeredErr) {
+		return registeredErr.ExistingCollector, nil
+	}
+
+	return nil, err
+}
+
+// storageCollector counts stored articles. Counting reads all articles, so stats are cached,
+// until the storage is modified.
+type storageCollector struct {
+	store StatsStore
+
+	mu      sync.Mutex
+	stats   storage.Stats
+	modTime time.Time
+	loaded  bool
+}
+
+// Describe implements prometheus.Collector
+func (s *storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storedArticlesDesc
+	ch <- storedSourceArticlesDesc
+}
+
+// Collect implements prometheus.Collector
+func (s *storageCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := s.currentStats()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(storedArticlesDesc, err)
+		return
+	}
+
+	for day, amount := range stats.DayToArticles {
+		ch <- prometheus.MustNewConstMetric(storedArticlesDesc, prometheus.GaugeValue, float64(amount), day)
+	}
+	for source, amount := range stats.SourceToArticles {
+		ch <- prometheus.MustNewConstMetric(storedSourceArticlesDesc, prometheus.GaugeValue, float64(amount), source)
+	}
+}
+
+// setStore replaces the counted store and drops cached stats
+func (s *storageCollector) setStore(store StatsStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store, s.stats, s.modTime, s.loaded = store, storage.Stats{}, time.Time{}, false
+}
+
+// currentStats returns cached stats, or counts articles again, if the storage was modified since then
+func (s *storageCollector) currentStats() (storage.Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modTime, err := s.store.ModTime()
+	if err != nil {
+		return storage.Stats{}, err
+	}
+
+	if s.loaded && modTime.Equal(s.modTime) {
+		return s.stats, nil
+	}
+
+	stats, err := s.store.Stats()
+	if err != nil {
+		return storage.Stats{}, err
+	}
+
+	s.stats, s.modTime, s.loaded = stats, modTime, true
+	return stats, nil
+}
+
+// fetchReportCollector exposes results of the last news fetching of every source
+type fetchReportCollector struct {
+	mu         sync.Mutex
+	readReport func() ([]types.FetchResult, error)
+}
+
+// setReadReport replaces the function, which reads the last fetch report
+func (f *fetchReportCollector) setReadReport(readReport func() ([]types.FetchResult, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.readReport = readReport
+}
+
+// Describe implements prometheus.Collector
+func (f *fetchReportCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- fetchSuccessDesc
+	ch <- fetchDurationDesc
+	ch <- fetchArticlesDesc
+	ch <- fetchTimestampDesc
+}
+
+// Collect implements prometheus.Collector. Nothing is collected, if news were not fetched yet.
+func (f *fetchReportCollector) Collect(ch chan<- prometheus.Metric) {
+	f.mu.Lock()
+	readReport := f.readReport
+	f.mu.Unlock()
+
+	results, err := readReport()
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(fetchSuccessDesc, err)
+		return
+	}
+
+	for _, result := range results {
+		success := 1.0
+		if result.Failed() {
+			success = 0
+		}
+
+		ch <- prometheus.MustNewConstMetric(fetchSuccessDesc, prometheus.GaugeValue, success, result.Source)
+		ch <- prometheus.MustNewConstMetric(fetchDurationDesc, prometheus.GaugeValue,
+			result.Duration.Seconds(), result.Source)
+		ch <- prometheus.MustNewConstMetric(fetchArticlesDesc, prometheus.GaugeValue,
+			float64(result.Articles), result.Source)
+		ch <- prometheus.MustNewConstMetric(fetchTimestampDesc, prometheus.GaugeValue,
+			float64(result.FetchedAt.Unix()), result.Source)
+	}
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/storage"
+	"gogator/cmd/types"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockStatsStore counts calculations of stats
+type mockStatsStore struct {
+	stats   storage.Stats
+	modTime time.Time
+	calls   int
+}
+
+func (m *mockStatsStore) Stats() (storage.Stats, error) {
+	m.calls++
+	return m.stats, nil
+}
+
+func (m *mockStatsStore) ModTime() (time.Time, error) {
+	return m.modTime, nil
+}
+
+func TestStorageCollector(t *testing.T) {
+	store := &mockStatsStore{
+		stats: storage.Stats{
+			TotalArticles:    3,
+			SourceToArticles: map[string]int{"bbc": 2, "abc": 1},
+			DayToArticles:    map[string]int{"2024-07-19": 1, "2024-07-20": 2},
+		},
+		modTime: time.Now(),
+	}
+	collector := &storageCollector{store: store}
+
+	expected := `
+# HELP gogator_stored_articles Amount of stored articles per publication day.
+# TYPE gogator_stored_articles gauge
+gogator_stored_articles{day="2024-07-19"} 1
+gogator_stored_articles{day="2024-07-20"} 2
+# HELP gogator_stored_source_articles Amount of stored articles per publisher.
+# TYPE gogator_stored_source_articles gauge
+gogator_stored_source_articles{source="abc"} 1
+gogator_stored_source_articles{source="bbc"} 2
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	assert.Nil(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, store.calls, "stats are cached, until the storage is modified")
+
+	store.modTime = store.modTime.Add(time.Minute)
+	store.stats.DayToArticles["2024-07-21"] = 1
+
+	assert.Equal(t, 5, testutil.CollectAndCount(collector))
+	assert.Equal(t, 2, store.calls)
+}
+
+func TestFetchReportCollector(t *testing.T) {
+	fetchedAt := time.Date(2024, 7, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		results  []types.FetchResult
+		err      error
+		expected string
+	}{
+		{
+			name: "Succeeded and failed sources",
+			results: []types.FetchResult{
+				{Source: "bbc", Articles: 10, Duration: 1500 * time.Millisecond, FetchedAt: fetchedAt},
+				{Source: "abc", Duration: time.Second, FetchedAt: fetchedAt, Error: "timeout"},
+			},
+			expected: `
+# HELP gogator_source_fetch_articles Amount of articles, received in the last fetching of the source.
+# TYPE gogator_source_fetch_articles gauge
+gogator_source_fetch_articles{source="abc"} 0
+gogator_source_fetch_articles{source="bbc"} 10
+# HELP gogator_source_fetch_duration_seconds Duration of the last fetching of the source.
+# TYPE gogator_source_fetch_duration_seconds gauge
+gogator_source_fetch_duration_seconds{source="abc"} 1
+gogator_source_fetch_duration_seconds{source="bbc"} 1.5
+# HELP gogator_source_fetch_success Whether the last fetching of the source succeeded (1) or failed (0).
+# TYPE gogator_source_fetch_success gauge
+gogator_source_fetch_success{source="abc"} 0
+gogator_source_fetch_success{source="bbc"} 1
+# HELP gogator_source_fetch_timestamp_seconds Unix time of the last fetching of the source.
+# TYPE gogator_source_fetch_timestamp_seconds gauge
+gogator_source_fetch_timestamp_seconds{source="abc"} 1.7214768e+09
+gogator_source_fetch_timestamp_seconds{source="bbc"} 1.7214768e+09
+`,
+		},
+		{
+			name: "News were not fetched yet",
+			err:  &os.PathError{Op: "open", Path: "fetch-report.json", Err: os.ErrNotExist},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &fetchReportCollector{readReport: func() ([]types.FetchResult, error) {
+				return tt.results, tt.err
+			}}
+
+			if tt.expected == "" {
+				assert.Equal(t, 0, testutil.CollectAndCount(collector))
+				return
+			}
+
+			err := testutil.CollectAndCompare(collector, strings.NewReader(tt.expected))
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestFetchReportCollector_Error(t *testing.T) {
+	collector := &fetchReportCollector{readReport: func() ([]types.FetchResult, error) {
+		return nil, errors.New("broken report")
+	}}
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(""))
+	assert.NotNil(t, err)
+}
+
+func TestRegisterStorage_Twice(t *testing.T) {
+	previous := Registry
+	Registry = prometheus.NewRegistry()
+	t.Cleanup(func() { Registry = previous })
+
+	noReport := func() ([]types.FetchResult, error) { return nil, os.ErrNotExist }
+
+	first := &mockStatsStore{
+		stats:   storage.Stats{DayToArticles: map[string]int{"2024-07-19": 1}},
+		modTime: time.Now(),
+	}
+	assert.Nil(t, RegisterStorage(first, noReport))
+
+	second := &mockStatsStore{
+		stats:   storage.Stats{DayToArticles: map[string]int{"2024-07-19": 1, "2024-07-20": 2}},
+		modTime: time.Now(),
+	}
+	assert.Nil(t, RegisterStorage(second, noReport), "registering again should not fail")
+
+	count, err := testutil.GatherAndCount(Registry, namespace+"_stored_articles")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count, "articles of the last registered store should be counted")
+	assert.Equal(t, 0, first.calls)
+}
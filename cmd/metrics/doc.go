@@ -0,0 +1,6 @@
+// Package metrics is used to expose metrics of the server in Prometheus format.
+//
+// Latency of requests is measured per route by the gin middleware, and handlers record amount of returned articles.
+// Stored articles and results of the last news fetching are collected from the storage, when metrics are scraped,
+// because news are fetched by a separate job, which writes its report into the storage.
+package metrics
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// namespace prefixes names of all metrics
+	namespace = "gogator"
+
+	// unmatchedRoute labels requests, which did not match any route, so unknown paths do not create new series
+	unmatchedRoute = "unmatched"
+)
+
+var (
+	// Registry contains all metrics of the server, including metrics of the Go runtime and the process
+	Registry = prometheus.NewRegistry()
+
+	// requestDuration measures latency of requests per route
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests per route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// articlesReturned measures amount of articles in responses
+	articlesReturned = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "articles_returned",
+		Help:      "Amount of articles returned in a single response.",
+		Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"route"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestDuration,
+		articlesReturned,
+	)
+}
+
+// Middleware measures latency of requests. Requests are labelled with the route template
+// (e.g. /admin/sources/:source), not with the requested path.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		requestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveArticles records amount of articles, which were returned by the route
+func ObserveArticles(route string, amount int) {
+	articlesReturned.WithLabelValues(route).Observe(float64(amount))
+}
+
+// Handler serves metrics of Registry in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
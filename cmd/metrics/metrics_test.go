@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sampleCount returns amount of observations of the histogram with the labels
+func sampleCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	var m dto.Metric
+	err := vec.WithLabelValues(labels...).(prometheus.Histogram).Write(&m)
+	assert.Nil(t, err)
+
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/admin/sources/:source", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		labels []string
+	}{
+		{
+			name:   "Route template is used as label",
+			path:   "/admin/sources/bbc",
+			labels: []string{http.MethodGet, "/admin/sources/:source", "200"},
+		},
+		{
+			name:   "Unknown path",
+			path:   "/unknown/path",
+			labels: []string{http.MethodGet, unmatchedRoute, "404"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := sampleCount(t, requestDuration, tt.labels...)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, before+1, sampleCount(t, requestDuration, tt.labels...))
+		})
+	}
+}
+
+func TestObserveArticles(t *testing.T) {
+	before := sampleCount(t, articlesReturned, "/test")
+
+	ObserveArticles("/test", 10)
+
+	assert.Equal(t, before+1, sampleCount(t, articlesReturned, "/test"))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveArticles("/news", 3)
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.True(t, strings.Contains(body, `gogator_articles_returned_count{route="/news"}`))
+	assert.True(t, strings.Contains(body, "go_goroutines"))
+}
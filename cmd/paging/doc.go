@@ -0,0 +1,6 @@
+// Package paging splits sorted articles into pages.
+//
+// A page is selected either by offset, or by an opaque cursor, returned with the previous page.
+// The cursor remembers the last article of the page, so the next page starts right after it,
+// even if newer articles were stored in the meantime.
+package paging
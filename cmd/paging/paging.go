@@ -0,0 +1,164 @@
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gogator/cmd/sorting"
+	"gogator/cmd/types"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultLimit is amount of articles on a page, when limit is not specified
+	DefaultLimit = 100
+
+	// MaxLimit is the biggest amount of articles on a page
+	MaxLimit = 1000
+)
+
+var (
+	// ErrInvalidLimit is returned, when limit is not a number between 1 and MaxLimit
+	ErrInvalidLimit = fmt.Errorf("limit should be a number from 1 to %d", MaxLimit)
+
+	// ErrInvalidOffset is returned, when offset is not a non-negative number
+	ErrInvalidOffset = errors.New("offset should be a non-negative number")
+
+	// ErrInvalidCursor is returned, when cursor was not returned by the server, or was returned for another sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrCursorWithOffset is returned, when both cursor and offset are specified
+	ErrCursorWithOffset = errors.New("cursor and offset can not be used together")
+)
+
+// Request describes the requested page. If After is set, the page starts after the article it points to,
+// otherwise it starts at Offset.
+type Request struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// Page is a part of sorted articles. Next is set, if there are articles after the page.
+type Page struct {
+	Articles []types.Article
+	Offset   int
+	Next     *Cursor
+}
+
+// Cursor points to the article, which the next page starts after. It is encoded into an opaque token.
+type Cursor struct {
+	Sort        string    `json:"s,omitempty"`
+	ID          string    `json:"i"`
+	PublishedAt time.Time `json:"d"`
+	Publisher   string    `json:"p,omitempty"`
+	Score       float64   `json:"r,omitempty"`
+}
+
+// ParseRequest parses limit, offset and cursor parameters of the page of articles sorted in the order.
+// Empty parameters are not set: the first page of DefaultLimit articles is requested.
+func ParseRequest(limit, offset, cursor, order string) (Request, error) {
+	request := Request{Limit: DefaultLimit}
+
+	if limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > MaxLimit {
+			return Request{}, ErrInvalidLimit
+		}
+		request.Limit = value
+	}
+
+	if offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return Request{}, ErrInvalidOffset
+		}
+		request.Offset = value
+	}
+
+	if cursor != "" {
+		if offset != "" {
+			return Request{}, ErrCursorWithOffset
+		}
+
+		after, err := DecodeCursor(cursor)
+		if err != nil {
+			return Request{}, err
+		}
+		if after.Sort != order {
+			return Request{}, fmt.Errorf("%w: it was returned for another sort", ErrInvalidCursor)
+		}
+		request.After = after
+	}
+
+	return request, nil
+}
+
+// Paginate returns the requested page of articles, which are sorted in the order
+func Paginate(articles []types.Article, request Request, order string) Page {
+	start := min(request.Offset, len(articles))
+	if request.After != nil {
+		after := request.After.article()
+		start = sort.Search(len(articles), func(i int) bool {
+			return sorting.Compare(articles[i], after, order) > 0
+		})
+	}
+
+	end := min(start+request.Limit, len(articles))
+	page := Page{
+		Articles: articles[start:end],
+		Offset:   start,
+	}
+
+	if end < len(articles) && end > start {
+		page.Next = NewCursor(articles[end-1], order)
+	}
+
+	return page
+}
+
+// NewCursor creates a cursor, pointing to the article in the order
+func NewCursor(article types.Article, order string) *Cursor {
+	return &Cursor{
+		Sort:        order,
+		ID:          article.ID,
+		PublishedAt: article.PublishedAt,
+		Publisher:   article.Publisher,
+		Score:       article.Score,
+	}
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses the token, returned by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	err = json.Unmarshal(data, cursor)
+	if err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// article returns the article with fields, which articles are sorted by
+func (c *Cursor) article() types.Article {
+	return types.Article{
+		ID:          c.ID,
+		PublishedAt: c.PublishedAt,
+		Publisher:   c.Publisher,
+		Score:       c.Score,
+	}
+}
@@ -0,0 +1,174 @@
+package paging
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+	"time"
+)
+
+func TestParseRequest(t *testing.T) {
+	published := time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)
+	cursor := NewCursor(types.Article{ID: "a", PublishedAt: published}, types.DateAscSort).Encode()
+
+	testCases := []struct {
+		name        string
+		limit       string
+		offset      string
+		cursor      string
+		order       string
+		expected    Request
+		expectedErr error
+	}{
+		{
+			name:     "Defaults",
+			expected: Request{Limit: DefaultLimit},
+		},
+		{
+			name:     "Limit and offset",
+			limit:    "10",
+			offset:   "20",
+			expected: Request{Limit: 10, Offset: 20},
+		},
+		{
+			name:     "Cursor",
+			limit:    "10",
+			cursor:   cursor,
+			order:    types.DateAscSort,
+			expected: Request{Limit: 10, After: &Cursor{Sort: types.DateAscSort, ID: "a", PublishedAt: published}},
+		},
+		{
+			name:        "Zero limit",
+			limit:       "0",
+			expectedErr: ErrInvalidLimit,
+		},
+		{
+			name:        "Too big limit",
+			limit:       "1001",
+			expectedErr: ErrInvalidLimit,
+		},
+		{
+			name:        "Negative offset",
+			offset:      "-1",
+			expectedErr: ErrInvalidOffset,
+		},
+		{
+			name:        "Cursor with offset",
+			offset:      "10",
+			cursor:      cursor,
+			order:       types.DateAscSort,
+			expectedErr: ErrCursorWithOffset,
+		},
+		{
+			name:        "Cursor of another sort",
+			cursor:      cursor,
+			order:       types.DateDescSort,
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			name:        "Malformed cursor",
+			cursor:      "not a cursor",
+			expectedErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := ParseRequest(tc.limit, tc.offset, tc.cursor, tc.order)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, request)
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	articles := []types.Article{
+		{ID: "e", PublishedAt: time.Date(2024, 7, 25, 0, 0, 0, 0, time.UTC)},
+		{ID: "d", PublishedAt: time.Date(2024, 7, 24, 0, 0, 0, 0, time.UTC)},
+		{ID: "c", PublishedAt: time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC)},
+		{ID: "b", PublishedAt: time.Date(2024, 7, 22, 0, 0, 0, 0, time.UTC)},
+		{ID: "a", PublishedAt: time.Date(2024, 7, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	testCases := []struct {
+		name           string
+		request        Request
+		expected       []string
+		expectedOffset int
+		expectedNext   string
+	}{
+		{
+			name:         "First page",
+			request:      Request{Limit: 2},
+			expected:     []string{"e", "d"},
+			expectedNext: "d",
+		},
+		{
+			name:           "Page by offset",
+			request:        Request{Limit: 2, Offset: 2},
+			expected:       []string{"c", "b"},
+			expectedOffset: 2,
+			expectedNext:   "b",
+		},
+		{
+			name:           "Last page",
+			request:        Request{Limit: 2, Offset: 4},
+			expected:       []string{"a"},
+			expectedOffset: 4,
+		},
+		{
+			name:           "Offset after the end",
+			request:        Request{Limit: 2, Offset: 10},
+			expectedOffset: 5,
+		},
+		{
+			name:           "Page after cursor",
+			request:        Request{Limit: 2, After: NewCursor(articles[1], types.DateDescSort)},
+			expected:       []string{"c", "b"},
+			expectedOffset: 2,
+			expectedNext:   "b",
+		},
+		{
+			name: "Cursor of removed article",
+			request: Request{Limit: 2, After: &Cursor{
+				Sort: types.DateDescSort, ID: "x", PublishedAt: time.Date(2024, 7, 23, 12, 0, 0, 0, time.UTC),
+			}},
+			expected:       []string{"c", "b"},
+			expectedOffset: 2,
+			expectedNext:   "b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := Paginate(articles, tc.request, types.DateDescSort)
+
+			var ids []string
+			for _, article := range page.Articles {
+				ids = append(ids, article.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+			assert.Equal(t, tc.expectedOffset, page.Offset)
+
+			if tc.expectedNext == "" {
+				assert.Nil(t, page.Next)
+				return
+			}
+			assert.Equal(t, tc.expectedNext, page.Next.ID)
+		})
+	}
+}
+
+func TestCursor_Encode(t *testing.T) {
+	published := time.Date(2024, 7, 21, 0, 0, 0, 0, time.UTC)
+	cursor := NewCursor(types.Article{ID: "a", Publisher: "BBC", PublishedAt: published, Score: 1.5}, types.RelevanceSort)
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
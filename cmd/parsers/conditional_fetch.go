@@ -0,0 +1,120 @@
+package parsers
+
+import (
+	"encoding/json"
+	"errors"
+	"gogator/cmd/fsutil"
+	"gogator/cmd/types"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// FetchCacheFile is the filename, where validators of the last responses of sources are stored
+	FetchCacheFile = "fetch_cache" + JsonExtension
+
+	// fetchCachePermissions are file permissions of the fetch cache
+	fetchCachePermissions = 0644
+)
+
+// ErrNotModified is returned, when source responded with 304 Not Modified, so it has no new articles
+var ErrNotModified = errors.New("source is not modified since the last fetch")
+
+// validatorCache keeps validators of the last successful responses by source names
+type validatorCache struct {
+	mu                 sync.Mutex
+	sourceToValidators map[string]types.FetchValidators
+}
+
+// get returns validators of the source. They are empty, if the source was never fetched,
+// or was fetched from another endpoint.
+func (c *validatorCache) get(source, endpoint string) types.FetchValidators {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	validators := c.sourceToValidators[source]
+	if validators.Endpoint != endpoint {
+		return types.FetchValidators{}
+	}
+
+	return validators
+}
+
+// set remembers validators of the source. Responses without validators are forgotten,
+// since they can not be used in conditional requests.
+func (c *validatorCache) set(source string, validators types.FetchValidators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if validators.Empty() {
+		delete(c.sourceToValidators, source)
+		return
+	}
+
+	c.sourceToValidators[source] = validators
+}
+
+// forget removes validators of the source, so the next request to it is not conditional
+func (c *validatorCache) forget(source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.sourceToValidators, source)
+}
+
+// EnableConditionalFetch makes parsers send conditional requests, using validators stored in FetchCacheFile
+// inside of StoragePath. Sources, which have not changed since the last fetch, are reported with ErrNotModified.
+//
+// Missing cache file is not an error. If the file is malformed, conditional fetching is enabled with
+// empty cache, and the error is returned, so it can be reported.
+func EnableConditionalFetch() error {
+	cache := &validatorCache{
+		sourceToValidators: make(map[string]types.FetchValidators),
+	}
+	updateFetcher(func(current *Fetcher) *Fetcher {
+		enabled := *current
+		enabled.validators = cache
+		return &enabled
+	})
+
+	data, err := os.ReadFile(filepath.Join(StoragePath, FetchCacheFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var sourceToValidators map[string]types.FetchValidators
+	err = json.Unmarshal(data, &sourceToValidators)
+	if err != nil {
+		return err
+	}
+
+	for source, validators := range sourceToValidators {
+		cache.set(source, validators)
+	}
+
+	return nil
+}
+
+// SaveFetchCache stores validators of the last responses into FetchCacheFile inside of StoragePath.
+//
+// It should be called after fetched articles are persisted: otherwise, articles of sources which respond
+// with 304 Not Modified on the next fetch are lost. Does nothing, if conditional fetching is not enabled.
+func SaveFetchCache() error {
+	cache := fetcher.Load().validators
+	if cache == nil {
+		return nil
+	}
+
+	cache.mu.Lock()
+	data, err := json.Marshal(cache.sourceToValidators)
+	cache.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFile(filepath.Join(StoragePath, FetchCacheFile), data, fetchCachePermissions)
+}
@@ -9,4 +9,8 @@
 // Factory can create objects to parse RSS, HTML or JSON data. Because of factory approach it is
 // easier to update code and add new parsers.
 // They will be used in other parts of the program to decode data into an array of articles.
+//
+// Format of every source is resolved through the parsers registry. New parsers can be added
+// from their own package by calling Register with a format name and a ParserConstructor,
+// after which sources with that format can be registered through the admin API or sources.json.
 package parsers
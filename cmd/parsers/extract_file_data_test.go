@@ -10,25 +10,25 @@ func Test_extractFileData(t *testing.T) {
 	testCases := []struct {
 		name        string
 		filename    string
-		expectedNil bool
+		expectedErr bool
 	}{
 		{
 			name:        "Successful execution",
 			filename:    "sources" + JsonExtension,
-			expectedNil: false,
+			expectedErr: false,
 		},
 		{
 			name:        "Invalid filename",
 			filename:    "not-file",
-			expectedNil: true,
+			expectedErr: true,
 		},
 	}
 
 	for _, tt := range testCases {
 		data, err := extractFileData(tt.filename)
 
-		if tt.expectedNil {
-			assert.Nil(t, err)
+		if tt.expectedErr {
+			assert.NotNil(t, err)
 			assert.Equal(t, 0, len(data))
 		} else {
 			assert.NotEqual(t, 0, len(data))
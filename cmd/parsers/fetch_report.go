@@ -0,0 +1,45 @@
+package parsers
+
+import (
+	"encoding/json"
+	"gogator/cmd/fsutil"
+	"gogator/cmd/types"
+	"path/filepath"
+)
+
+const (
+	// FetchReportFile is the filename of the report with results of the last news fetching
+	FetchReportFile = "fetch_report" + JsonExtension
+
+	// fetchReportPermissions are file permissions of the fetch report
+	fetchReportPermissions = 0644
+)
+
+// WriteFetchReport stores results of the last news fetching into FetchReportFile inside of storageDir.
+// The file is replaced atomically on each call.
+func WriteFetchReport(storageDir string, results []types.FetchResult) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFile(filepath.Join(storageDir, FetchReportFile), data, fetchReportPermissions)
+}
+
+// ReadFetchReport returns results of the last news fetching, stored in StoragePath.
+//
+// Throws os.ErrNotExist, if news were not fetched yet.
+func ReadFetchReport() ([]types.FetchResult, error) {
+	data, err := extractFileData(FetchReportFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.FetchResult
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
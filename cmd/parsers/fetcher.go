@@ -0,0 +1,371 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultUserAgent identifies Go-Gator in requests to the sources
+	DefaultUserAgent = "Go-Gator (+https://github.com/werniq/Go-Gator)"
+
+	// defaultConnectTimeout limits time of establishing connection to the source
+	defaultConnectTimeout = 5 * time.Second
+
+	// defaultReadTimeout limits time of a single attempt: from sending request till reading the whole body
+	defaultReadTimeout = 30 * time.Second
+
+	// defaultMaxRetries is amount of retries after the first failed attempt
+	defaultMaxRetries = 3
+
+	// defaultInitialBackoff is a delay before the first retry
+	defaultInitialBackoff = 500 * time.Millisecond
+
+	// defaultMaxBackoff is the upper limit of delay between retries
+	defaultMaxBackoff = 10 * time.Second
+
+	// defaultMaxRetryAfter is the longest Retry-After delay, which fetcher agrees to wait
+	defaultMaxRetryAfter = time.Minute
+)
+
+var (
+	// fetcher is shared by all parsers to retrieve data from sources endpoints.
+	// It is replaced as a whole, so parsers, which are running, keep using the previous one.
+	fetcher atomic.Pointer[Fetcher]
+
+	// fetcherMu serializes replacing of fetcher, so changes made concurrently are not lost
+	fetcherMu sync.Mutex
+)
+
+func init() {
+	fetcher.Store(NewFetcher(FetcherConfig{}))
+}
+
+// FetcherConfig contains settings of Fetcher. Zero values are replaced with defaults.
+type FetcherConfig struct {
+	// ConnectTimeout limits time of establishing connection (including TLS handshake)
+	ConnectTimeout time.Duration
+
+	// ReadTimeout limits time of a single attempt: from sending request till reading the whole body
+	ReadTimeout time.Duration
+
+	// MaxRetries is amount of retries after the first failed attempt. Negative value disables retries.
+	MaxRetries int
+
+	// InitialBackoff is a delay before the first retry, which is doubled on every next one
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the upper limit of delay between retries
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter is the longest Retry-After delay which is honoured. If server asks to wait longer,
+	// fetching fails immediately.
+	MaxRetryAfter time.Duration
+
+	// UserAgent is sent in User-Agent header of every request
+	UserAgent string
+}
+
+// Fetcher retrieves data from sources endpoints over HTTP.
+//
+// It enforces timeouts, retries transient errors (network failures, 429 and 5xx responses)
+// with exponential backoff and jitter, and honours Retry-After header.
+// Responses with non-2xx status are rejected with *HTTPStatusError.
+type Fetcher struct {
+	// Client is used to perform requests
+	Client *http.Client
+
+	config FetcherConfig
+
+	// validators are used to make conditional requests in FetchSource. Nil, if conditional fetching is disabled.
+	validators *validatorCache
+}
+
+// HTTPStatusError is returned when source responded with non-2xx status code
+type HTTPStatusError struct {
+	Url        string
+	StatusCode int
+
+	// RetryAfter is a delay requested by the source in Retry-After header, if any
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status from %s: %d %s", e.Url, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// WithDefaults returns a copy of config, which zero values are replaced with defaults
+func (config FetcherConfig) WithDefaults() FetcherConfig {
+	if config.ConnectTimeout == 0 {
+		config.ConnectTimeout = defaultConnectTimeout
+	}
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = defaultReadTimeout
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.MaxRetryAfter == 0 {
+		config.MaxRetryAfter = defaultMaxRetryAfter
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+
+	return config
+}
+
+// NewFetcher creates an instance of Fetcher, replacing zero values of config with defaults
+func NewFetcher(config FetcherConfig) *Fetcher {
+	config = config.WithDefaults()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   config.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = config.ConnectTimeout
+	transport.ResponseHeaderTimeout = config.ReadTimeout
+
+	return &Fetcher{
+		Client: &http.Client{
+			Transport: transport,
+		},
+		config: config,
+	}
+}
+
+// ConfigureFetcher replaces the fetcher shared by all parsers with a new one, created from config.
+// Validators of conditional fetching are preserved. It is safe to call, while sources are fetched.
+func ConfigureFetcher(config FetcherConfig) {
+	updateFetcher(func(current *Fetcher) *Fetcher {
+		configured := NewFetcher(config)
+		configured.validators = current.validators
+		return configured
+	})
+}
+
+// updateFetcher replaces the shared fetcher with the one, returned by update for the current fetcher
+func updateFetcher(update func(current *Fetcher) *Fetcher) {
+	fetcherMu.Lock()
+	defer fetcherMu.Unlock()
+
+	fetcher.Store(update(fetcher.Load()))
+}
+
+// Fetch retrieves the body of the given url.
+//
+// Transient failures are retried, until MaxRetries is reached or ctx is cancelled.
+// Returns *HTTPStatusError, if the source responded with non-2xx status.
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	data, _, err := f.fetch(ctx, url, types.FetchValidators{})
+	return data, err
+}
+
+// FetchSource works like Fetch, but if conditional fetching is enabled, it sends validators of the
+// previous response of the source, and remembers validators of the new one.
+//
+// Returns ErrNotModified, if the source responded with 304 Not Modified.
+func (f *Fetcher) FetchSource(ctx context.Context, source, url string) ([]byte, error) {
+	if f.validators == nil {
+		return f.Fetch(ctx, url)
+	}
+
+	data, validators, err := f.fetch(ctx, url, f.validators.get(source, url))
+	if err != nil {
+		return nil, err
+	}
+
+	f.validators.set(source, validators)
+	return data, nil
+}
+
+// fetch retrieves the body of the given url, retrying transient failures.
+// If cached validators are not empty, the request is conditional.
+func (f *Fetcher) fetch(ctx context.Context, url string, cached types.FetchValidators) ([]byte, types.FetchValidators, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= f.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay, ok := f.retryDelay(attempt, lastErr)
+			if !ok {
+				break
+			}
+
+			err := sleep(ctx, delay)
+			if err != nil {
+				return nil, types.FetchValidators{}, err
+			}
+		}
+
+		data, validators, err := f.fetchOnce(ctx, url, cached)
+		if err == nil {
+			return data, validators, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !isTransient(err) {
+			return nil, types.FetchValidators{}, err
+		}
+	}
+
+	return nil, types.FetchValidators{}, lastErr
+}
+
+// fetchOnce makes a single attempt to retrieve the body of the given url.
+// Returns the body together with validators of the response.
+func (f *Fetcher) fetchOnce(ctx context.Context, url string, cached types.FetchValidators) ([]byte, types.FetchValidators, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.config.ReadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, types.FetchValidators{}, err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	res, err := f.Client.Do(req)
+	if err != nil {
+		return nil, types.FetchValidators{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && !cached.Empty() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil, cached, ErrNotModified
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, res.Body)
+
+		return nil, types.FetchValidators{}, &HTTPStatusError{
+			Url:        url,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, types.FetchValidators{}, err
+	}
+
+	return data, types.FetchValidators{
+		Endpoint:     url,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// retryDelay returns delay before the given attempt.
+//
+// Delay grows exponentially from InitialBackoff up to MaxBackoff, and is randomized in [delay/2, delay],
+// so sources are not hit by many clients at the same moment.
+// If the source asked to wait with Retry-After, its delay is used instead. Returns false, if
+// requested delay is longer than MaxRetryAfter.
+func (f *Fetcher) retryDelay(attempt int, lastErr error) (time.Duration, bool) {
+	var statusErr *HTTPStatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		if statusErr.RetryAfter > f.config.MaxRetryAfter {
+			return 0, false
+		}
+		return statusErr.RetryAfter, true
+	}
+
+	delay := f.config.InitialBackoff << (attempt - 1)
+	if delay <= 0 || delay > f.config.MaxBackoff {
+		delay = f.config.MaxBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// isTransient reports whether the failed attempt is worth retrying: reset or refused connections,
+// timeouts, too many requests, and server errors. Unknown hosts, TLS failures and other permanent errors
+// are not retried.
+func isTransient(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter parses value of Retry-After header, which is either amount of seconds, or HTTP date.
+// Returns zero, if header is empty or malformed.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+
+	return date.Sub(now)
+}
+
+// sleep waits for the given delay, or returns an error if ctx is cancelled earlier
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,290 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFetcher_Fetch(t *testing.T) {
+	testCases := []struct {
+		name             string
+		statuses         []int
+		retryAfter       string
+		expectedStatus   int
+		expectedAttempts int32
+		expectError      bool
+	}{
+		{
+			name:             "Successful fetch",
+			statuses:         []int{http.StatusOK},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Retry server errors",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Honour Retry-After",
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "1",
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Give up when Retry-After is too long",
+			statuses:         []int{http.StatusTooManyRequests},
+			retryAfter:       "3600",
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:             "Do not retry client errors",
+			statuses:         []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusNotFound,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:             "Give up after max retries",
+			statuses:         []int{http.StatusInternalServerError},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedAttempts: 3,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				status := tt.statuses[len(tt.statuses)-1]
+				if int(attempt) <= len(tt.statuses) {
+					status = tt.statuses[attempt-1]
+				}
+
+				assert.Equal(t, "test-agent", r.UserAgent())
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte("body"))
+			}))
+			defer server.Close()
+
+			f := NewFetcher(FetcherConfig{
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     5 * time.Millisecond,
+				MaxRetryAfter:  2 * time.Second,
+				UserAgent:      "test-agent",
+			})
+
+			data, err := f.Fetch(context.Background(), server.URL)
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+
+			if !tt.expectError {
+				assert.NoError(t, err)
+				assert.Equal(t, "body", string(data))
+				return
+			}
+
+			var statusErr *HTTPStatusError
+			assert.True(t, errors.As(err, &statusErr))
+			assert.Equal(t, tt.expectedStatus, statusErr.StatusCode)
+		})
+	}
+}
+
+func TestFetcher_FetchCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(FetcherConfig{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := f.Fetch(ctx, server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{
+			name:     "Empty header",
+			value:    "",
+			expected: 0,
+		},
+		{
+			name:     "Seconds",
+			value:    "120",
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "HTTP date",
+			value:    now.Add(30 * time.Second).Format(http.TimeFormat),
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "Date in the past",
+			value:    now.Add(-time.Hour).Format(http.TimeFormat),
+			expected: 0,
+		},
+		{
+			name:     "Malformed header",
+			value:    "soon",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "Unknown host",
+			err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{
+				Err: "no such host", Name: "example.invalid", IsNotFound: true,
+			}},
+			expected: false,
+		},
+		{
+			name:     "Timeout of DNS lookup",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "timeout", IsTimeout: true}},
+			expected: true,
+		},
+		{
+			name:     "Refused connection",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			expected: true,
+		},
+		{
+			name:     "Reset connection",
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+			expected: true,
+		},
+		{
+			name:     "TLS handshake failure",
+			err:      &net.OpError{Op: "remote error", Err: errors.New("tls: handshake failure")},
+			expected: false,
+		},
+		{
+			name:     "Server error",
+			err:      &HTTPStatusError{StatusCode: http.StatusBadGateway},
+			expected: true,
+		},
+		{
+			name:     "Not found",
+			err:      &HTTPStatusError{StatusCode: http.StatusNotFound},
+			expected: false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTransient(tt.err))
+		})
+	}
+}
+
+func TestFetcher_FetchSourceConditional(t *testing.T) {
+	const etag = `"v1"`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("feed"))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(FetcherConfig{})
+	f.validators = &validatorCache{
+		sourceToValidators: make(map[string]types.FetchValidators),
+	}
+
+	data, err := f.FetchSource(context.Background(), "source", server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "feed", string(data))
+
+	_, err = f.FetchSource(context.Background(), "source", server.URL)
+	assert.ErrorIs(t, err, ErrNotModified)
+
+	// validators are not reused for another endpoint
+	data, err = f.FetchSource(context.Background(), "source", server.URL+"/other")
+	assert.NoError(t, err)
+	assert.Equal(t, "feed", string(data))
+
+	f.validators.forget("source")
+	data, err = f.FetchSource(context.Background(), "source", server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "feed", string(data))
+}
+
+func TestConfigureFetcher_Concurrent(t *testing.T) {
+	previous := fetcher.Load()
+	t.Cleanup(func() { fetcher.Store(previous) })
+
+	cache := &validatorCache{
+		sourceToValidators: make(map[string]types.FetchValidators),
+	}
+	updateFetcher(func(current *Fetcher) *Fetcher {
+		enabled := *current
+		enabled.validators = cache
+		return &enabled
+	})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ConfigureFetcher(FetcherConfig{MaxRetries: i})
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotNil(t, fetcher.Load().Client)
+		}()
+	}
+	wg.Wait()
+
+	assert.Same(t, cache, fetcher.Load().validators, "validators are preserved by every configuration")
+}
@@ -2,11 +2,12 @@ package parsers
 
 import (
 	"bytes"
+	"context"
 	"github.com/PuerkitoBio/goquery"
 	"gogator/cmd/types"
-	"io"
-	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
 const (
@@ -24,46 +25,176 @@ const (
 
 	// LinkAttribute is the attribute name used to get the URL link from the element
 	LinkAttribute = "href"
+
+	// ImageAttribute is the attribute name used to get the URL of the image from the element
+	ImageAttribute = "src"
+
+	// languageAttribute is the attribute of the <html> element, which contains language of the page
+	languageAttribute = "lang"
 )
 
-// HtmlParser is a struct implementing a Parser for HTML content from a specific source
+// HtmlParser is a struct implementing a Parser for HTML content from a specific source.
+//
+// Articles are extracted from the page using Profile. If source has no profile,
+// one of the default profiles is used (see scrapingProfile method).
 type HtmlParser struct {
-	Source string
+	Source  string
+	Profile *types.ScrapingProfile
 }
 
 // Parse function for HtmlParser struct
 func (hp HtmlParser) Parse() ([]types.Article, error) {
+	return hp.ParseContext(context.Background())
+}
+
+// ParseContext works like Parse, but aborts fetching the page once ctx is cancelled
+func (hp HtmlParser) ParseContext(ctx context.Context) ([]types.Article, error) {
 	var news []types.Article
 
-	res, err := http.Get(sourceToEndpoint[hp.Source])
+	endpoint := sourceEndpoint(hp.Source)
+
+	data, err := fetcher.Load().FetchSource(ctx, hp.Source, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := io.ReadAll(res.Body)
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
 
-	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	profile := hp.scrapingProfile()
+
+	baseUrl := profile.BaseUrl
+	if baseUrl == "" {
+		baseUrl = endpoint
+	}
+	base, err := url.Parse(baseUrl)
 	if err != nil {
 		return nil, err
 	}
 
-	doc.Find(UsaTodayKeySelector).Each(func(i int, selection *goquery.Selection) {
-		title := strings.TrimSpace(selection.Find(TitleSelector).Text())
-		timestamp := strings.TrimSpace(selection.Find(TimestampSelector).AttrOr(TimestampAttribute, ""))
-		link := strings.TrimSpace(selection.AttrOr(LinkAttribute, ""))
-		description := selection.Text()
+	linkAttribute := profile.LinkAttribute
+	if linkAttribute == "" {
+		linkAttribute = LinkAttribute
+	}
+
+	imageAttribute := profile.ImageAttribute
+	if imageAttribute == "" {
+		imageAttribute = ImageAttribute
+	}
 
-		news = append(news, types.Article{
+	language := strings.TrimSpace(doc.Find("html").AttrOr(languageAttribute, ""))
+
+	doc.Find(profile.ItemSelector).Each(func(i int, selection *goquery.Selection) {
+		title := extractField(selection, profile.TitleSelector, profile.TitleAttribute)
+		timestamp := extractField(selection, profile.DateSelector, profile.DateAttribute)
+		link := extractField(selection, profile.LinkSelector, linkAttribute)
+		description := extractField(selection, profile.DescriptionSelector, profile.DescriptionAttribute)
+
+		article := types.Article{
 			Title:       title,
 			Description: description,
+			PublishedAt: parseScrapedDate(timestamp, profile.DateLayout),
 			PubDate:     timestamp,
 			Publisher:   hp.Source,
-			Link:        link,
-		})
+			Link:        resolveLink(base, link),
+			Language:    language,
+		}
+
+		// optional fields are extracted only if profile has selectors for them,
+		// since the article block itself would be used otherwise
+		if profile.AuthorSelector != "" {
+			article.Author = extractField(selection, profile.AuthorSelector, profile.AuthorAttribute)
+		}
+		if profile.CategorySelector != "" {
+			article.Categories = extractFields(selection, profile.CategorySelector, profile.CategoryAttribute)
+		}
+		if profile.ImageSelector != "" {
+			if image := extractField(selection, profile.ImageSelector, imageAttribute); image != "" {
+				article.ImageUrl = resolveLink(base, image)
+			}
+		}
+		if profile.ContentSelector != "" {
+			article.Content = extractField(selection, profile.ContentSelector, "")
+		}
+
+		news = append(news, article)
 	})
 
 	return news, nil
 }
+
+// scrapingProfile returns profile of the parser.
+// If it is not configured, default profile of the source or GenericScrapingProfile is returned.
+func (hp HtmlParser) scrapingProfile() types.ScrapingProfile {
+	if hp.Profile != nil {
+		return *hp.Profile
+	}
+
+	if profile, exists := defaultScrapingProfiles[hp.Source]; exists {
+		return profile
+	}
+
+	return GenericScrapingProfile
+}
+
+// extractField returns trimmed text or attribute value of the first element, matched by selector inside of the item.
+// If selector is empty, the item itself is used.
+func extractField(item *goquery.Selection, selector, attribute string) string {
+	element := item
+	if selector != "" {
+		element = item.Find(selector).First()
+	}
+
+	if attribute != "" {
+		return strings.TrimSpace(element.AttrOr(attribute, ""))
+	}
+
+	return strings.TrimSpace(element.Text())
+}
+
+// extractFields returns trimmed texts or attribute values of all elements, matched by selector inside of the item.
+// Blank values are skipped.
+func extractFields(item *goquery.Selection, selector, attribute string) []string {
+	var values []string
+	item.Find(selector).Each(func(i int, element *goquery.Selection) {
+		if attribute != "" {
+			values = append(values, element.AttrOr(attribute, ""))
+			return
+		}
+		values = append(values, element.Text())
+	})
+
+	return trimAll(values)
+}
+
+// parseScrapedDate parses date in the custom layout of the scraping profile.
+// If layout is empty, or date does not match it, zero time is returned, so the date is normalized
+// in common layouts later (see types.Article.NormalizePubDate).
+func parseScrapedDate(date, layout string) time.Time {
+	if layout == "" || date == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(layout, date)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t.UTC()
+}
+
+// resolveLink resolves relative link against the base URL of the source
+func resolveLink(base *url.URL, link string) string {
+	if link == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	return base.ResolveReference(ref).String()
+}
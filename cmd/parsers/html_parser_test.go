@@ -4,8 +4,10 @@ import (
 	"errors"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestHtmlParser_Parse(t *testing.T) {
@@ -21,9 +23,6 @@ func TestHtmlParser_Parse(t *testing.T) {
 		{
 			name: "Default run",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(
 						http.StatusOK,
@@ -34,9 +33,6 @@ func TestHtmlParser_Parse(t *testing.T) {
 		{
 			name: "HTTP request failure",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewErrorResponder(errors.New("http request failed")))
 			},
@@ -45,31 +41,22 @@ func TestHtmlParser_Parse(t *testing.T) {
 		{
 			name: "Empty response body",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(http.StatusOK, ""))
 			},
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name: "Invalid HTML",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(http.StatusOK, "<html><head><title>Test</title></head><body><div><h1>Invalid HTML</h1></div>"))
 			},
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name: "Missing selectors",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(http.StatusOK, `<!DOCTYPE html><html><head><title>Test</title></head><body><div><h1>No News Item</h1></div></body></html>`))
 			},
@@ -78,9 +65,6 @@ func TestHtmlParser_Parse(t *testing.T) {
 		{
 			name: "Attributes missing",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(http.StatusOK, `<!DOCTYPE html><html><head><title>Test</title></head><body><div class="news-item"><h1 class="title">Test News</h1><p>Description</p></div></body></html>`))
 			},
@@ -90,6 +74,9 @@ func TestHtmlParser_Parse(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
+			httpmock.ActivateNonDefault(fetcher.Load().Client)
+			defer httpmock.DeactivateAndReset()
+
 			tt.setupMock()
 			_, err := parser.Parse()
 			if tt.expectError {
@@ -100,3 +87,74 @@ func TestHtmlParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestHtmlParser_ParseWithProfile(t *testing.T) {
+	const (
+		source   = "html-profile-source"
+		endpoint = "https://example.com/news/"
+		page     = `<!DOCTYPE html><html lang="en-GB"><body>
+			<div class="story">
+				<h2 class="headline">First story</h2>
+				<a class="more" href="/first">Read more</a>
+				<span class="date">Jul 23, 2024 10:00</span>
+				<p class="teaser">First teaser</p>
+				<span class="byline">John Doe</span>
+				<a class="tag">World</a><a class="tag">Europe</a>
+				<img src="/images/first.jpg">
+			</div>
+			<div class="story">
+				<h2 class="headline">Second story</h2>
+				<a class="more" href="https://other.com/second">Read more</a>
+				<span class="date">yesterday</span>
+				<p class="teaser">Second teaser</p>
+			</div>
+		</body></html>`
+	)
+
+	sourceToEndpoint[source] = endpoint
+	defer delete(sourceToEndpoint, source)
+
+	httpmock.ActivateNonDefault(fetcher.Load().Client)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", endpoint, httpmock.NewStringResponder(http.StatusOK, page))
+
+	parser := HtmlParser{
+		Source: source,
+		Profile: &types.ScrapingProfile{
+			ItemSelector:        "div.story",
+			TitleSelector:       "h2.headline",
+			LinkSelector:        "a.more",
+			DateSelector:        "span.date",
+			DateLayout:          "Jan 2, 2006 15:04",
+			DescriptionSelector: "p.teaser",
+			AuthorSelector:      "span.byline",
+			CategorySelector:    "a.tag",
+			ImageSelector:       "img",
+		},
+	}
+
+	news, err := parser.Parse()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Article{
+		{
+			Title:       "First story",
+			PublishedAt: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+			PubDate:     "Jul 23, 2024 10:00",
+			Description: "First teaser",
+			Publisher:   source,
+			Link:        "https://example.com/first",
+			Author:      "John Doe",
+			Categories:  []string{"World", "Europe"},
+			ImageUrl:    "https://example.com/images/first.jpg",
+			Language:    "en-GB",
+		},
+		{
+			Title:       "Second story",
+			PubDate:     "yesterday",
+			Description: "Second teaser",
+			Publisher:   source,
+			Link:        "https://other.com/second",
+			Language:    "en-GB",
+		},
+	}, news)
+}
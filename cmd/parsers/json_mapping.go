@@ -0,0 +1,219 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"strconv"
+	"strings"
+)
+
+const (
+	// jsonPathRoot is an optional prefix of mapping paths, which points to the root of the document
+	jsonPathRoot = "$"
+
+	// jsonPathSeparator separates keys in mapping paths
+	jsonPathSeparator = "."
+
+	// defaultTitlePath is used, when mapping has no path for the article title
+	defaultTitlePath = "title"
+
+	// defaultPubDatePath is used, when mapping has no path for the publication date
+	defaultPubDatePath = "publishedAt"
+
+	// defaultDescriptionPath is used, when mapping has no path for the article description
+	defaultDescriptionPath = "description"
+
+	// defaultLinkPath is used, when mapping has no path for the article link
+	defaultLinkPath = "url"
+
+	// defaultAuthorPath is used, when mapping has no path for the article author
+	defaultAuthorPath = "author"
+
+	// defaultCategoriesPath is used, when mapping has no path for the article categories
+	defaultCategoriesPath = "categories"
+
+	// defaultImagePath is used, when mapping has no path for the article image
+	defaultImagePath = "imageUrl"
+
+	// defaultContentPath is used, when mapping has no path for the article content
+	defaultContentPath = "content"
+
+	// defaultLanguagePath is used, when mapping has no path for the article language
+	defaultLanguagePath = "language"
+
+	// defaultGuidPath is used, when mapping has no path for the article identifier
+	defaultGuidPath = "guid"
+)
+
+// ErrInvalidMapping is returned when field mapping of the source contains malformed paths
+var ErrInvalidMapping = errors.New("invalid field mapping")
+
+// ValidateMapping checks that all paths of the field mapping are well-formed.
+// Nil mapping is valid, since it is optional.
+func ValidateMapping(mapping *types.FieldMapping) error {
+	if mapping == nil {
+		return nil
+	}
+
+	paths := map[string]string{
+		"items":       mapping.Items,
+		"title":       mapping.Title,
+		"publishedAt": mapping.PubDate,
+		"description": mapping.Description,
+		"url":         mapping.Link,
+		"author":      mapping.Author,
+		"categories":  mapping.Categories,
+		"imageUrl":    mapping.Image,
+		"content":     mapping.Content,
+		"language":    mapping.Language,
+		"guid":        mapping.Guid,
+	}
+
+	for field, path := range paths {
+		for _, key := range splitPath(path) {
+			if key == "" {
+				return fmt.Errorf("%w: malformed path for %s field: %q", ErrInvalidMapping, field, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseMappedJson decodes articles from JSON data, using mapping to locate the articles array
+// and fields of every article. Numbers and booleans are converted to strings, e.g. unix timestamps.
+func parseMappedJson(data []byte, mapping *types.FieldMapping) ([]types.Article, error) {
+	var root interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	items, found := resolvePath(root, mapping.Items)
+	if !found {
+		return nil, fmt.Errorf("items path %q is not found in the response", mapping.Items)
+	}
+
+	list, ok := items.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items path %q does not point to an array", mapping.Items)
+	}
+
+	articles := make([]types.Article, 0, len(list))
+	for _, item := range list {
+		articles = append(articles, types.Article{
+			Title:       stringAt(item, mapping.Title, defaultTitlePath),
+			PubDate:     stringAt(item, mapping.PubDate, defaultPubDatePath),
+			Description: stringAt(item, mapping.Description, defaultDescriptionPath),
+			Link:        stringAt(item, mapping.Link, defaultLinkPath),
+			Author:      stringAt(item, mapping.Author, defaultAuthorPath),
+			Categories:  stringsAt(item, mapping.Categories, defaultCategoriesPath),
+			ImageUrl:    stringAt(item, mapping.Image, defaultImagePath),
+			Content:     stringAt(item, mapping.Content, defaultContentPath),
+			Language:    stringAt(item, mapping.Language, defaultLanguagePath),
+			Guid:        stringAt(item, mapping.Guid, defaultGuidPath),
+		})
+	}
+
+	return articles, nil
+}
+
+// stringAt returns string representation of the value located by path in the item.
+// If path is empty, defaultPath is used instead.
+func stringAt(item interface{}, path, defaultPath string) string {
+	if path == "" {
+		path = defaultPath
+	}
+
+	value, found := resolvePath(item, path)
+	if !found {
+		return ""
+	}
+
+	return scalarString(value)
+}
+
+// stringsAt returns string representations of array elements, located by path in the item.
+// Single value is returned as an array of one element. If path is empty, defaultPath is used instead.
+func stringsAt(item interface{}, path, defaultPath string) []string {
+	if path == "" {
+		path = defaultPath
+	}
+
+	value, found := resolvePath(item, path)
+	if !found {
+		return nil
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return trimAll([]string{scalarString(value)})
+	}
+
+	values := make([]string, 0, len(list))
+	for _, element := range list {
+		values = append(values, scalarString(element))
+	}
+
+	return trimAll(values)
+}
+
+// scalarString returns string representation of decoded JSON string, number or boolean.
+// Empty string is returned for objects, arrays and null.
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	return ""
+}
+
+// resolvePath walks through decoded JSON value by keys of the path.
+// Numeric keys are used as indexes of arrays. Empty path points to the value itself.
+func resolvePath(value interface{}, path string) (interface{}, bool) {
+	for _, key := range splitPath(path) {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, exists := node[key]
+			if !exists {
+				return nil, false
+			}
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			value = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// splitPath removes optional root prefix from the path, and splits it into keys
+func splitPath(path string) []string {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, jsonPathRoot)
+	path = strings.TrimPrefix(path, jsonPathSeparator)
+
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, jsonPathSeparator)
+}
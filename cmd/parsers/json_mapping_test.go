@@ -0,0 +1,113 @@
+package parsers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestValidateMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		mapping     *types.FieldMapping
+		expectedErr bool
+	}{
+		{
+			name:        "Empty mapping",
+			mapping:     nil,
+			expectedErr: false,
+		},
+		{
+			name: "Valid mapping",
+			mapping: &types.FieldMapping{
+				Items: "$.data.results",
+				Title: "headline",
+				Link:  "links.0.href",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "Root items path",
+			mapping: &types.FieldMapping{
+				Items: "$",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "Malformed items path",
+			mapping: &types.FieldMapping{
+				Items: "data..results",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Malformed title path",
+			mapping: &types.FieldMapping{
+				Items: "articles",
+				Title: "headline.",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMapping(tt.mapping)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidMapping)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	document := map[string]interface{}{
+		"data": map[string]interface{}{
+			"results": []interface{}{"first", "second"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		expected      interface{}
+		expectedFound bool
+	}{
+		{
+			name:          "Root path",
+			path:          "$",
+			expected:      document,
+			expectedFound: true,
+		},
+		{
+			name:          "Array element",
+			path:          "$.data.results.1",
+			expected:      "second",
+			expectedFound: true,
+		},
+		{
+			name:          "Index out of range",
+			path:          "data.results.2",
+			expected:      nil,
+			expectedFound: false,
+		},
+		{
+			name:          "Missing key",
+			path:          "data.articles",
+			expected:      nil,
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := resolvePath(document, tt.path)
+
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
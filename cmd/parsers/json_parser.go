@@ -1,21 +1,41 @@
 package parsers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"gogator/cmd/types"
-	"io"
-	"net/http"
+	"strings"
 )
 
+const (
+	// jsonFeedVersionPrefix is a prefix of version field in JSON Feed documents
+	jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+
+	// jsonFeedVersionKey is the name of top-level field, which identifies JSON Feed documents
+	jsonFeedVersionKey = "version"
+
+	// jsonArticlesKey is the name of top-level field, which contains wrapped articles
+	jsonArticlesKey = "articles"
+)
+
+// ErrUnsupportedJSON is returned when JSON response has unknown structure, and source has no field mapping
+var ErrUnsupportedJSON = errors.New("unsupported JSON structure, field mapping should be configured for this source")
+
 // JsonParser struct is used to parse articles data from Json files.
 //
 // It implements Parser interface, which has a method Parse.
 // Firstly ot opens file with name from Source field, then decodes its content into
 // array of articles, and throws an error if something went wrong.
 //
+// Without Mapping, parser understands arrays of articles, JSON Feed documents, and objects
+// with articles wrapped into "articles" field. Mapping allows to parse responses of any other shape.
+//
 // Returns a successfully decoded array of news, and nil error.
 type JsonParser struct {
-	Source string
+	Source  string
+	Mapping *types.FieldMapping
 }
 
 // getFileData
@@ -30,26 +50,135 @@ var openFile getFileData = extractFileData
 
 // Parse function is required for JsonParser struct, in order to implement NewsParser interface, for data formatted in json
 func (jp JsonParser) Parse() ([]types.Article, error) {
-	var news []types.Article
+	return jp.ParseContext(context.Background())
+}
 
-	res, err := http.Get(sourceToEndpoint[jp.Source])
+// ParseContext works like Parse, but aborts fetching the source once ctx is cancelled
+func (jp JsonParser) ParseContext(ctx context.Context) ([]types.Article, error) {
+	data, err := fetcher.Load().FetchSource(ctx, jp.Source, sourceEndpoint(jp.Source))
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := io.ReadAll(res.Body)
+	news, err := parseJsonArticles(data, jp.Mapping)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(data, &news)
+	for i := 0; i <= len(news)-1; i++ {
+		news[i].Publisher = jp.Source
+	}
+
+	return news, nil
+}
+
+// parseJsonArticles decodes articles from JSON data.
+//
+// If mapping is provided, it is used to locate articles and their fields.
+// Otherwise, the structure is detected: array of articles, JSON Feed, or object with "articles" field.
+func parseJsonArticles(data []byte, mapping *types.FieldMapping) ([]types.Article, error) {
+	if mapping != nil {
+		return parseMappedJson(data, mapping)
+	}
+
+	var news []types.Article
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		err := json.Unmarshal(data, &news)
+		if err != nil {
+			return nil, err
+		}
+
+		return news, nil
+	}
+
+	var root map[string]json.RawMessage
+	err := json.Unmarshal(data, &root)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := 0; i <= len(news)-1; i++ {
-		news[i].Publisher = jp.Source
+	var version string
+	if rawVersion, exists := root[jsonFeedVersionKey]; exists {
+		_ = json.Unmarshal(rawVersion, &version)
 	}
 
-	return news, nil
+	switch {
+	case strings.HasPrefix(version, jsonFeedVersionPrefix):
+		var feed types.JsonFeed
+		err = json.Unmarshal(data, &feed)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromJsonFeedItems(feed), nil
+	case root[jsonArticlesKey] != nil:
+		var wrapped types.Json
+		err = json.Unmarshal(data, &wrapped)
+		if err != nil {
+			return nil, err
+		}
+
+		return wrapped.Articles, nil
+	}
+
+	return nil, ErrUnsupportedJSON
+}
+
+// fromJsonFeedItems converts JSON Feed items into articles.
+//
+// Description is taken from summary, plain text or HTML content (in that order),
+// and publication date falls back to the modification date. Tags of items are their categories,
+// and items without own language are in the language of the feed.
+func fromJsonFeedItems(feed types.JsonFeed) []types.Article {
+	articles := make([]types.Article, 0, len(feed.Items))
+
+	for _, item := range feed.Items {
+		authors := make([]string, 0, len(item.Authors))
+		for _, author := range item.Authors {
+			authors = append(authors, author.Name)
+		}
+
+		articles = append(articles, types.Article{
+			Title:       strings.TrimSpace(item.Title),
+			PubDate:     firstNonEmpty(item.DatePublished, item.DateModified),
+			Description: firstNonEmpty(item.Summary, item.ContentText, item.ContentHtml),
+			Link:        firstNonEmpty(item.Url, item.ExternalUrl),
+			Author:      strings.Join(trimAll(authors), ", "),
+			Categories:  trimAll(item.Tags),
+			ImageUrl:    firstNonEmpty(item.Image, item.BannerImage),
+			Content:     firstNonEmpty(item.ContentHtml, item.ContentText),
+			Language:    firstNonEmpty(item.Language, feed.Language),
+			Guid:        strings.TrimSpace(item.Id),
+		})
+	}
+
+	return articles
+}
+
+// firstNonEmpty returns the first of values, which is not blank, with trimmed spaces
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// trimAll returns values with trimmed spaces, skipping blank ones.
+// Nil is returned, if all values are blank.
+func trimAll(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+
+	return trimmed
 }
@@ -2,71 +2,65 @@ package parsers
 
 import (
 	"errors"
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestJsonParser_ParseWithArgs(t *testing.T) {
+	const endpoint = "https://example.com/news.json"
 	parser := JsonParser{
-		Source: "sources.json",
+		Source: "json-source",
 	}
 
+	sourceToEndpoint[parser.Source] = endpoint
+	defer delete(sourceToEndpoint, parser.Source)
+
 	testCases := []struct {
-		Name        string
-		setupMock   func()
-		expectError bool
+		Name           string
+		setupMock      func()
+		expectError    bool
+		expectedAmount int
 	}{
 		{
 			Name: "Default parse",
 			setupMock: func() {
-				mockFileContent := `[{"Title":"Test News","Description":"This is a test news.","PubDate":"2024-07-23","Publisher":"Test Source","Link":"http://example.com"}]`
-				mockExtractFileData := func(filename string) ([]byte, error) {
-					return []byte(mockFileContent), nil
-				}
-				openFile = mockExtractFileData
+				httpmock.RegisterResponder("GET", endpoint, httpmock.NewStringResponder(http.StatusOK,
+					`[{"Title":"Test News","Description":"This is a test news.","PubDate":"2024-07-23","Publisher":"Test Source","Link":"http://example.com"}]`))
 			},
-			expectError: false,
+			expectError:    false,
+			expectedAmount: 1,
 		},
 		{
-			Name: "File read failure",
+			Name: "Request failure",
 			setupMock: func() {
-				mockExtractFileData := func(filename string) ([]byte, error) {
-					return nil, errors.New("file read error")
-				}
-				openFile = mockExtractFileData
+				httpmock.RegisterResponder("GET", endpoint, httpmock.NewErrorResponder(errors.New("request failed")))
 			},
 			expectError: true,
 		},
 		{
 			Name: "Invalid JSON format",
 			setupMock: func() {
-				mockFileContent := `[{-----.....-------]`
-				mockExtractFileData := func(filename string) ([]byte, error) {
-					return []byte(mockFileContent), nil
-				}
-				openFile = mockExtractFileData
+				httpmock.RegisterResponder("GET", endpoint, httpmock.NewStringResponder(http.StatusOK, `[{-----.....-------]`))
 			},
 			expectError: true,
 		},
 		{
 			Name: "Empty JSON",
 			setupMock: func() {
-				mockFileContent := `[]`
-				mockExtractFileData := func(filename string) ([]byte, error) {
-					return []byte(mockFileContent), nil
-				}
-				openFile = mockExtractFileData
+				httpmock.RegisterResponder("GET", endpoint, httpmock.NewStringResponder(http.StatusOK, `[]`))
 			},
-			expectError: false,
+			expectError:    false,
+			expectedAmount: 0,
 		},
 		{
 			Name: "JSON with unexpected structure",
 			setupMock: func() {
-				mockFileContent := `[{"UnexpectedField":"Some value"}]`
-				mockExtractFileData := func(filename string) ([]byte, error) {
-					return []byte(mockFileContent), nil
-				}
-				openFile = mockExtractFileData
+				httpmock.RegisterResponder("GET", endpoint, httpmock.NewStringResponder(http.StatusOK,
+					`{"UnexpectedField":"Some value"}`))
 			},
 			expectError: true,
 		},
@@ -74,6 +68,9 @@ func TestJsonParser_ParseWithArgs(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
+			httpmock.ActivateNonDefault(fetcher.Load().Client)
+			defer httpmock.DeactivateAndReset()
+
 			testCase.setupMock()
 			news, err := parser.Parse()
 
@@ -81,7 +78,201 @@ func TestJsonParser_ParseWithArgs(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, news)
+				assert.Len(t, news, testCase.expectedAmount)
+			}
+		})
+	}
+}
+
+func TestParseJsonArticles(t *testing.T) {
+	testCases := []struct {
+		name         string
+		data         string
+		mapping      *types.FieldMapping
+		expectError  bool
+		expectedNews []types.Article
+	}{
+		{
+			name:        "Array of articles",
+			data:        `[{"title":"Test News","publishedAt":"2024-07-23","description":"Test description","url":"http://example.com"}]`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "Test News",
+					PublishedAt: time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC),
+					PubDate:     "2024-07-23",
+					Description: "Test description",
+					Link:        "http://example.com",
+				},
+			},
+		},
+		{
+			name:        "Wrapped articles",
+			data:        `{"status":"ok","articles":[{"title":"Test News","url":"http://example.com"}]}`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title: "Test News",
+					Link:  "http://example.com",
+				},
+			},
+		},
+		{
+			name: "JSON Feed",
+			data: `{
+				"version": "https://jsonfeed.org/version/1.1",
+				"title": "Test Feed",
+				"items": [
+					{"id": "1", "title": "First", "content_text": "First content", "date_published": "2024-07-23T10:00:00Z", "url": "http://example.com/1"},
+					{"id": "2", "title": "Second", "summary": "Second summary", "content_html": "<p>Second</p>", "date_modified": "2024-07-24T10:00:00Z", "external_url": "http://example.com/2"}
+				]
+			}`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "First",
+					PubDate:     "2024-07-23T10:00:00Z",
+					Description: "First content",
+					Link:        "http://example.com/1",
+					Content:     "First content",
+					Guid:        "1",
+				},
+				{
+					Title:       "Second",
+					PubDate:     "2024-07-24T10:00:00Z",
+					Description: "Second summary",
+					Link:        "http://example.com/2",
+					Content:     "<p>Second</p>",
+					Guid:        "2",
+				},
+			},
+		},
+		{
+			name: "JSON Feed with authors, tags and images",
+			data: `{
+				"version": "https://jsonfeed.org/version/1.1",
+				"language": "en-US",
+				"items": [
+					{
+						"id": "https://example.com/1",
+						"title": "First",
+						"content_html": "<p>Full story</p>",
+						"url": "http://example.com/1",
+						"image": "http://example.com/1.jpg",
+						"authors": [{"name": "John Doe"}, {"name": "Jane Roe"}],
+						"tags": ["World", " Europe "],
+						"language": "uk"
+					},
+					{"id": "2", "title": "Second", "url": "http://example.com/2", "banner_image": "http://example.com/2.jpg"}
+				]
+			}`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "First",
+					Description: "<p>Full story</p>",
+					Link:        "http://example.com/1",
+					Author:      "John Doe, Jane Roe",
+					Categories:  []string{"World", "Europe"},
+					ImageUrl:    "http://example.com/1.jpg",
+					Content:     "<p>Full story</p>",
+					Language:    "uk",
+					Guid:        "https://example.com/1",
+				},
+				{
+					Title:    "Second",
+					Link:     "http://example.com/2",
+					ImageUrl: "http://example.com/2.jpg",
+					Language: "en-US",
+					Guid:     "2",
+				},
+			},
+		},
+		{
+			name: "Mapped response",
+			data: `{"data":{"results":[{"headline":"Test News","published":1721728800,"links":[{"href":"http://example.com"}]}]}}`,
+			mapping: &types.FieldMapping{
+				Items:   "$.data.results",
+				Title:   "headline",
+				PubDate: "published",
+				Link:    "links.0.href",
+			},
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:   "Test News",
+					PubDate: "1721728800",
+					Link:    "http://example.com",
+				},
+			},
+		},
+		{
+			name: "Mapped response with optional fields",
+			data: `{"results":[{"headline":"Test News","byline":"John Doe","tags":["World",{"name":"Europe"}],"media":{"url":"http://example.com/1.jpg"},"section":"Politics"}]}`,
+			mapping: &types.FieldMapping{
+				Items:  "results",
+				Title:  "headline",
+				Author: "byline",
+				Image:  "media.url",
+			},
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:    "Test News",
+					Author:   "John Doe",
+					ImageUrl: "http://example.com/1.jpg",
+				},
+			},
+		},
+		{
+			name: "Mapped categories with a single value",
+			data: `{"results":[{"headline":"Test News","section":"Politics"}]}`,
+			mapping: &types.FieldMapping{
+				Items:      "results",
+				Title:      "headline",
+				Categories: "section",
+			},
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:      "Test News",
+					Categories: []string{"Politics"},
+				},
+			},
+		},
+		{
+			name:        "Object with unknown structure",
+			data:        `{"data":{"results":[]}}`,
+			expectError: true,
+		},
+		{
+			name:        "Mapping items path points to an object",
+			data:        `{"data":{"results":[]}}`,
+			mapping:     &types.FieldMapping{Items: "data"},
+			expectError: true,
+		},
+		{
+			name:        "Mapping items path is not found",
+			data:        `{"data":{"results":[]}}`,
+			mapping:     &types.FieldMapping{Items: "data.articles"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid JSON",
+			data:        `[{-----.....-------]`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			news, err := parseJsonArticles([]byte(tt.data), tt.mapping)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNews, news)
 			}
 		})
 	}
@@ -2,10 +2,27 @@ package parsers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"gogator/cmd/fsutil"
 	"gogator/cmd/types"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// sourcesFilePermissions are file permissions of sources file
+const sourcesFilePermissions = 0644
+
+var (
+	// ErrSourceNotFound is returned, when the source to update is not in available sources
+	ErrSourceNotFound = errors.New("source is not found")
+
+	// ErrSourceExists is returned, when the source to add is already in available sources
+	ErrSourceExists = errors.New("source is already registered")
 )
 
 var (
@@ -17,6 +34,13 @@ var (
 	// StoragePath is the path to folder with all data from application
 	StoragePath string
 
+	// sourcesMu guards sourceToEndpoint, sourceToParser and sourceToFeed, which are modified by admin handlers
+	// and read by parsers concurrently. Changes of sources hold it until they are written into sources file.
+	sourcesMu sync.RWMutex
+
+	// sourcesLoaded is set, when sources were loaded from sources file or written to it
+	sourcesLoaded atomic.Bool
+
 	// sourceToEndpoint maps source names (as strings) to their corresponding filenames
 	sourceToEndpoint = map[string]string{
 		WashingtonTimes: "https://www.washingtontimes.com/rss/headlines/news/world",
@@ -33,71 +57,229 @@ var (
 		BBC:             g.XmlParser(BBC),
 		WashingtonTimes: g.XmlParser(WashingtonTimes),
 	}
+
+	// sourceToFeed maps source names to their configuration: format, which was used to resolve
+	// their parser, and optional parser-specific settings
+	sourceToFeed = map[string]types.Feed{
+		UsaToday:        {Name: UsaToday, Format: HtmlFormat},
+		ABC:             {Name: ABC, Format: XmlFormat},
+		BBC:             {Name: BBC, Format: XmlFormat},
+		WashingtonTimes: {Name: WashingtonTimes, Format: XmlFormat},
+	}
 )
 
 // AddNewSource inserts new source to available sources list and determines the appropriate Parser for it
 //
-// Throws an error, if the source was already registered previously.
+// Throws ErrSourceExists, if the source is already registered, or an error, if there is no parser registered
+// for the given format.
 func AddNewSource(format, source, endpoint string) error {
-	sourceToEndpoint[source] = endpoint
-	sourceToParser[source] = determineParser(format, source)
+	return AddNewFeed(types.Feed{
+		Name:     source,
+		Format:   format,
+		Endpoint: endpoint,
+	})
+}
 
-	err := UpdateSourceFile()
-	if err != nil {
-		return err
+// AddNewFeed inserts new source together with its parser-specific configuration,
+// and determines the appropriate Parser for it.
+//
+// Throws ErrSourceExists, if the source is already registered, or an error, if there is no parser registered
+// for the feed format, or the configuration is invalid.
+func AddNewFeed(feed types.Feed) error {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if _, exists := sourceToEndpoint[feed.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrSourceExists, feed.Name)
 	}
 
-	return nil
+	return changeSources(func() error {
+		return setFeed(feed)
+	})
 }
 
-// GetAllSources returns all available sources
+// GetAllSources returns a copy of all available sources, mapped to their endpoints
 func GetAllSources() map[string]string {
-	return sourceToEndpoint
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	return maps.Clone(sourceToEndpoint)
 }
 
 // GetSourceDetailed returns detailed information about source
 func GetSourceDetailed(source string) types.Feed {
-	return types.Feed{
-		Name:     source,
-		Format:   determineFormat(sourceToParser[source], source),
-		Endpoint: sourceToEndpoint[source],
-	}
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	return sourceDetailed(source)
+}
+
+// sourceEndpoint returns the endpoint of the source
+func sourceEndpoint(source string) string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	return sourceToEndpoint[source]
+}
+
+// sourceDetailed returns detailed information about source. sourcesMu should be held by the caller.
+func sourceDetailed(source string) types.Feed {
+	feed := sourceToFeed[source]
+	feed.Name = source
+	feed.Endpoint = sourceToEndpoint[source]
+
+	return feed
+}
+
+// UpdateFeed applies non-empty fields of update to the source with the same name: format, endpoint,
+// field mapping, scraping profile and readability mode.
+// The complete feed is validated before it replaces the source, and sources file is written once,
+// so either all fields are updated, or none of them.
+//
+// Throws ErrSourceNotFound, if provided source not exists, or an error, if the updated feed is not valid
+func UpdateFeed(update types.Feed) error {
+	return updateFeed(update.Name, func(feed *types.Feed) {
+		if update.Format != "" {
+			feed.Format = update.Format
+		}
+		if update.Endpoint != "" {
+			feed.Endpoint = update.Endpoint
+		}
+		if update.Mapping != nil {
+			feed.Mapping = update.Mapping
+		}
+		if update.Scraping != nil {
+			feed.Scraping = update.Scraping
+		}
+		if update.Readability != nil {
+			feed.Readability = update.Readability
+		}
+	})
 }
 
 // UpdateSourceEndpoint updates endpoint for the given source
 //
-// Throws an error, if provided source not exists
+// Throws ErrSourceNotFound, if provided source not exists
 func UpdateSourceEndpoint(source, newEndpoint string) error {
-	sourceToEndpoint[source] = newEndpoint
-	err := UpdateSourceFile()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return updateFeed(source, func(feed *types.Feed) {
+		feed.Endpoint = newEndpoint
+	})
 }
 
 // UpdateSourceFormat updates format for the given source
 //
-// Throws an error, if provided source not exists
+// Throws ErrSourceNotFound, if provided source not exists, or an error, if there is no parser registered for the given format
 func UpdateSourceFormat(source, format string) error {
-	sourceToParser[source] = determineParser(format, source)
-	err := UpdateSourceFile()
-	if err != nil {
-		return err
+	return updateFeed(source, func(feed *types.Feed) {
+		feed.Format = format
+	})
+}
+
+// UpdateSourceMapping updates JSON field mapping for the given source
+//
+// Throws ErrSourceNotFound, if provided source not exists, or an error, if mapping is not valid
+func UpdateSourceMapping(source string, mapping *types.FieldMapping) error {
+	return updateFeed(source, func(feed *types.Feed) {
+		feed.Mapping = mapping
+	})
+}
+
+// UpdateSourceScraping updates scraping profile for the given source
+//
+// Throws ErrSourceNotFound, if provided source not exists, or an error, if profile is not valid
+func UpdateSourceScraping(source string, profile *types.ScrapingProfile) error {
+	return updateFeed(source, func(feed *types.Feed) {
+		feed.Scraping = profile
+	})
+}
+
+// UpdateSourceReadability enables or disables readability mode for the given source.
+// In readability mode links of articles are followed, and the main text of their pages is stored as their content.
+//
+// Throws ErrSourceNotFound, if provided source not exists
+func UpdateSourceReadability(source string, enabled bool) error {
+	return updateFeed(source, func(feed *types.Feed) {
+		feed.Readability = &enabled
+	})
+}
+
+// updateFeed changes the feed of the existing source, replaces the source with it, and writes sources file.
+// The source is not changed, if the changed feed is not valid, or sources file can not be written.
+func updateFeed(source string, change func(feed *types.Feed)) error {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if _, exists := sourceToEndpoint[source]; !exists {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, source)
 	}
 
-	return nil
+	feed := sourceDetailed(source)
+	change(&feed)
+
+	return changeSources(func() error {
+		return setFeed(feed)
+	})
 }
 
-// DeleteSource removes source from the map
+// DeleteSource removes source from the map.
+// The source is kept, if sources file can not be written.
 func DeleteSource(source string) error {
-	delete(sourceToEndpoint, source)
-	delete(sourceToParser, source)
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	return changeSources(func() error {
+		delete(sourceToEndpoint, source)
+		delete(sourceToParser, source)
+		delete(sourceToFeed, source)
+
+		return nil
+	})
+}
+
+// changeSources applies change to sources mappings and writes sources file. If either of them fails,
+// mappings are restored, so sources in memory always match sources file. sourcesMu should be locked by the caller.
+func changeSources(change func() error) error {
+	endpoints, parsers, feeds := maps.Clone(sourceToEndpoint), maps.Clone(sourceToParser), maps.Clone(sourceToFeed)
 
-	err := UpdateSourceFile()
+	err := change()
+	if err == nil {
+		err = writeSourcesFile()
+	}
 	if err != nil {
-		return err
+		sourceToEndpoint, sourceToParser, sourceToFeed = endpoints, parsers, feeds
+	}
+
+	return err
+}
+
+// SetDefaultSources replaces built-in sources with the given feeds. Sources of sources.json file,
+// loaded by LoadSourcesFile, are added to them.
+//
+// Throws an error, if any of feeds has a format without registered parser. Sources are not changed in that case.
+func SetDefaultSources(feeds []types.Feed) error {
+	for _, feed := range feeds {
+		if feed.Format == "" {
+			feed.Format = DefaultFormat
+		}
+
+		_, err := NewParser(feed)
+		if err != nil {
+			return fmt.Errorf("failed to set source %s: %w", feed.Name, err)
+		}
+	}
+
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	clear(sourceToEndpoint)
+	clear(sourceToParser)
+	clear(sourceToFeed)
+
+	for _, feed := range feeds {
+		err := setFeed(feed)
+		if err != nil {
+			return fmt.Errorf("failed to set source %s: %w", feed.Name, err)
+		}
 	}
 
 	return nil
@@ -105,6 +287,8 @@ func DeleteSource(source string) error {
 
 // LoadSourcesFile initializes sourceToParser and sourceToEndpoint with data stored in
 // sources.json file.
+//
+// Throws an error, if any of stored sources has a format without registered parser.
 func LoadSourcesFile() error {
 	sourcesFilepath := filepath.Join(StoragePath, sourcesFile)
 
@@ -127,41 +311,45 @@ func LoadSourcesFile() error {
 		return err
 	}
 
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
 	for _, s := range sources {
-		sourceToParser[s.Name] = determineParser(s.Format, s.Name)
-		sourceToEndpoint[s.Name] = s.Endpoint
+		err = setFeed(s)
+		if err != nil {
+			return fmt.Errorf("failed to load source %s: %w", s.Name, err)
+		}
 	}
 
+	sourcesLoaded.Store(true)
 	return nil
 }
 
+// SourcesLoaded reports whether sources were loaded from sources file, or the file was initialized
+func SourcesLoaded() bool {
+	return sourcesLoaded.Load()
+}
+
 // UpdateSourceFile initializes or updates a file with all information about sources.
-// It creates the file if it doesn't exist, and updates its content if it does.
+// It creates the file if it doesn't exist, and replaces its content if it does.
 //
-// Returns an error if the current working directory cannot be retrieved,
-// the file cannot be created or opened,
-// or if the file content cannot be written or closed properly.
+// The file is replaced atomically, so sources.json is never left truncated, even if the process is stopped
+// in the middle of writing. Writes are serialized with changes of sources, so the file always contains
+// sources after the latest completed change.
+//
+// Returns an error if the file cannot be created, or if its content cannot be written.
 func UpdateSourceFile() error {
-	var file *os.File
-	cwdPath, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
-	sourcesFilePath := filepath.Join(cwdPath, StoragePath, sourcesFile)
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
 
-	file, err = os.Create(sourcesFilePath)
-	if err != nil {
-		return err
-	}
+	return writeSourcesFile()
+}
 
+// writeSourcesFile writes all sources into sources file. sourcesMu should be locked by the caller.
+func writeSourcesFile() error {
 	var sources []types.Feed
-	for key, val := range sourceToEndpoint {
-		sources = append(sources, types.Feed{
-			Name:     key,
-			Format:   determineFormat(sourceToParser[key], key),
-			Endpoint: val,
-		})
+	for key := range sourceToEndpoint {
+		sources = append(sources, sourceDetailed(key))
 	}
 
 	sourcesFileData, err := json.Marshal(sources)
@@ -169,41 +357,36 @@ func UpdateSourceFile() error {
 		return err
 	}
 
-	_, err = file.Write(sourcesFileData)
-	if err != nil {
-		return err
-	}
-
-	err = file.Close()
+	err = fsutil.WriteFile(filepath.Join(StoragePath, sourcesFile), sourcesFileData, sourcesFilePermissions)
 	if err != nil {
 		return err
 	}
 
+	sourcesLoaded.Store(true)
 	return nil
 }
 
-// determineParser is used to determine which parser we will need for that source
-func determineParser(format, source string) Parser {
-	switch format {
-	case "json":
-		return JsonParser{Source: source}
-	case "xml":
-		return XMLParser{Source: source}
-	case "html":
-		return HtmlParser{Source: source}
+// setFeed resolves the Parser for the feed through the registry and stores the feed
+// in sources mappings. Empty format is replaced with DefaultFormat.
+// If readability mode is enabled for the feed, its parser extracts content of articles from their pages.
+// sourcesMu should be locked by the caller.
+func setFeed(feed types.Feed) error {
+	if feed.Format == "" {
+		feed.Format = DefaultFormat
 	}
-	return nil
-}
 
-// determineParser is used to determine which format we will use based on the Parser
-func determineFormat(p Parser, source string) string {
-	switch p {
-	case JsonParser{Source: source}:
-		return "json"
-	case XMLParser{Source: source}:
-		return "xml"
-	case HtmlParser{Source: source}:
-		return "html"
+	p, err := NewParser(feed)
+	if err != nil {
+		return err
 	}
-	return ""
+
+	if feed.ReadabilityEnabled() {
+		p = readabilityParser{Parser: p}
+	}
+
+	sourceToEndpoint[feed.Name] = feed.Endpoint
+	sourceToParser[feed.Name] = p
+	sourceToFeed[feed.Name] = feed
+
+	return nil
 }
@@ -1,13 +1,44 @@
 package parsers
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"log"
+	"maps"
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 )
 
+// TestMain runs tests against a copy of the sources file, because tests add, update and delete sources.
+// The copy is placed inside of the package, since extractFileData resolves StoragePath relatively to cwd
 func TestMain(m *testing.M) {
-	StoragePath = filepath.Join("..", "parsers", "data")
+	dir, err := copySourcesFile(filepath.Join("..", "parsers", "data"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	StoragePath = dir
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// copySourcesFile copies sources file from the src folder into a new temporary folder in cwd
+func copySourcesFile(src string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(src, sourcesFile))
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp(".", "storage-")
+	if err != nil {
+		return "", err
+	}
+
+	return dir, os.WriteFile(filepath.Join(dir, sourcesFile), data, sourcesFilePermissions)
 }
 
 func TestAddNewSource(t *testing.T) {
@@ -96,80 +127,131 @@ func TestGetAllSources(t *testing.T) {
 	}
 }
 
+func TestSources_ConcurrentAccess(t *testing.T) {
+	storagePath := StoragePath
+	defer func() {
+		StoragePath = storagePath
+	}()
+	StoragePath = t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		source := fmt.Sprintf("concurrent%d", i)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, AddNewSource(XmlFormat, source, "https://example.com/"+source))
+			assert.Nil(t, UpdateSourceEndpoint(source, "https://example.com/updated/"+source))
+			assert.Nil(t, DeleteSource(source))
+		}()
+		go func() {
+			defer wg.Done()
+			GetAllSources()[source] = "modified copy"
+			GetSourceDetailed(source)
+			sourceEndpoint(source)
+		}()
+	}
+	wg.Wait()
+
+	assert.NotContains(t, GetAllSources(), "concurrent0")
+}
+
+func TestAddNewSource_Exists(t *testing.T) {
+	storagePath := StoragePath
+	defer func() {
+		StoragePath = storagePath
+	}()
+	StoragePath = t.TempDir()
+
+	source := "registered-concurrently"
+	defer DeleteSource(source)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := AddNewSource(XmlFormat, source, fmt.Sprintf("https://example.com/%d", i))
+			if err != nil {
+				assert.ErrorIs(t, err, ErrSourceExists)
+				return
+			}
+
+			mu.Lock()
+			added++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, added, "only one of concurrent registrations of the same source succeeds")
+}
+
 func TestUpdateSourceEndpoint(t *testing.T) {
 	tests := []struct {
 		name             string
 		source           string
 		newEndpoint      string
 		expectedEndpoint string
-		expectedErr      bool
+		expectedErr      error
 	}{
 		{
 			name:             "Successful update",
-			source:           "WashingtonTimes",
+			source:           WashingtonTimes,
 			newEndpoint:      "https://newendpoint.com/rss",
 			expectedEndpoint: "https://newendpoint.com/rss",
-			expectedErr:      false,
-		},
-		{
-			name:             "Try to update not-existent source",
-			source:           "source-not-exists",
-			newEndpoint:      "https://api.com/rss",
-			expectedEndpoint: "https://api.com/rss",
-			expectedErr:      true,
 		},
 		{
 			name:             "Try to update not-existent source",
 			source:           "source-not-exists",
 			newEndpoint:      "https://api.com/rss",
-			expectedEndpoint: "https://api.com/rss",
-			expectedErr:      true,
+			expectedEndpoint: "",
+			expectedErr:      ErrSourceNotFound,
 		},
 	}
 
 	for _, tt := range tests {
-		err := UpdateSourceEndpoint(tt.source, tt.newEndpoint)
-
-		if tt.expectedErr {
-			assert.NotNil(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			err := UpdateSourceEndpoint(tt.source, tt.newEndpoint)
 
-			if sourceToEndpoint[tt.source] != tt.expectedEndpoint {
-				t.Errorf("for source %s, expected endpoint %s, got %s", tt.source, tt.expectedEndpoint, sourceToEndpoint[tt.source])
-			}
-		} else {
-			assert.Nil(t, err)
-		}
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedEndpoint, sourceEndpoint(tt.source))
+		})
 	}
 }
 
 func TestUpdateSourceFormat(t *testing.T) {
 	tests := []struct {
-		name               string
-		source             string
-		format             string
-		expectedParserType string
-		expectedErr        bool
+		name           string
+		source         string
+		format         string
+		expectedFormat string
+		expectedErr    error
 	}{
 		{
-			name:               "Successful update",
-			source:             "WashingtonTimes",
-			format:             "xml",
-			expectedParserType: "xml",
-			expectedErr:        false,
+			name:           "Successful update",
+			source:         WashingtonTimes,
+			format:         XmlFormat,
+			expectedFormat: XmlFormat,
 		},
 		{
-			name:               "Try to update not-existent source",
-			source:             "source-not-exists",
-			format:             "https://api.com/rss",
-			expectedParserType: "https://api.com/rss",
-			expectedErr:        true,
+			name:           "Unknown format",
+			source:         WashingtonTimes,
+			format:         "yaml",
+			expectedFormat: XmlFormat,
+			expectedErr:    ErrUnknownFormat,
 		},
 		{
-			name:               "Try to update not-existent source",
-			source:             "source-not-exists",
-			format:             "https://api.com/rss",
-			expectedParserType: "https://api.com/rss",
-			expectedErr:        true,
+			name:        "Try to update not-existent source",
+			source:      "source-not-exists",
+			format:      XmlFormat,
+			expectedErr: ErrSourceNotFound,
 		},
 	}
 
@@ -177,160 +259,198 @@ func TestUpdateSourceFormat(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			err := UpdateSourceFormat(tt.source, tt.format)
 
-			if tt.expectedErr {
-				assert.NotNil(t, err)
-
-				if sourceToEndpoint[tt.source] != tt.expectedParserType {
-					t.Errorf("for source %s, expected endpoint %s, got %s", tt.source, tt.expectedParserType, sourceToEndpoint[tt.source])
-				}
-			} else {
-				assert.Nil(t, err)
-			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedFormat, GetSourceDetailed(tt.source).Format)
 		})
 	}
 }
 
+func TestUpdateFeed(t *testing.T) {
+	storagePath := StoragePath
+	defer func() {
+		StoragePath = storagePath
+	}()
+	StoragePath = t.TempDir()
+
+	source := "update-feed"
+	assert.Nil(t, AddNewSource(XmlFormat, source, "https://example.com/rss"))
+	defer DeleteSource(source)
+
+	err := UpdateFeed(types.Feed{Name: source, Format: "yaml", Endpoint: "https://example.com/changed"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	assert.Equal(t, types.Feed{Name: source, Format: XmlFormat, Endpoint: "https://example.com/rss"},
+		GetSourceDetailed(source), "no field is applied, if any of them is not valid")
+
+	err = UpdateFeed(types.Feed{Name: source, Format: HtmlFormat, Endpoint: "https://example.com/changed"})
+	assert.Nil(t, err)
+	assert.Equal(t, types.Feed{Name: source, Format: HtmlFormat, Endpoint: "https://example.com/changed"},
+		GetSourceDetailed(source))
+
+	err = UpdateFeed(types.Feed{Name: "source-not-exists", Endpoint: "https://example.com/rss"})
+	assert.ErrorIs(t, err, ErrSourceNotFound)
+	assert.NotContains(t, GetAllSources(), "source-not-exists")
+}
+
+func TestSources_WriteFailure(t *testing.T) {
+	storagePath := StoragePath
+	defer func() {
+		StoragePath = storagePath
+	}()
+	dir := t.TempDir()
+	StoragePath = dir
+
+	source := "write-failure"
+	assert.Nil(t, AddNewSource(XmlFormat, source, "https://example.com/rss"))
+	defer DeleteSource(source)
+
+	StoragePath = filepath.Join(dir, "non", "existent", "path")
+	expected := GetSourceDetailed(source)
+
+	err := AddNewSource(XmlFormat, "not-written", "https://example.com/rss")
+	assert.NotNil(t, err)
+	assert.NotContains(t, GetAllSources(), "not-written")
+
+	err = UpdateSourceEndpoint(source, "https://example.com/changed")
+	assert.NotNil(t, err)
+	assert.Equal(t, expected, GetSourceDetailed(source))
+
+	err = DeleteSource(source)
+	assert.NotNil(t, err)
+	assert.Equal(t, expected, GetSourceDetailed(source))
+
+	StoragePath = dir
+}
+
 func TestDeleteSource(t *testing.T) {
 	tests := []struct {
-		name        string
-		source      string
-		expectedErr bool
+		name   string
+		source string
+		setup  func()
 	}{
 		{
-			name:        "Successful update",
-			source:      "WashingtonTimes",
-			expectedErr: false,
-		},
-		{
-			name:        "Try to delete not-existent source",
-			source:      "source-not-exists",
-			expectedErr: true,
+			name:   "Successful deletion",
+			source: "source-to-delete",
+			setup: func() {
+				assert.Nil(t, AddNewSource(XmlFormat, "source-to-delete", "https://example.com/rss"))
+			},
 		},
 		{
-			name:        "Try to delete not-existent source",
-			source:      "source-not-exists",
-			expectedErr: true,
+			name:   "Deletion of not-existent source changes nothing",
+			source: "source-not-exists",
+			setup:  func() {},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
 			err := DeleteSource(tt.source)
 
-			if tt.expectedErr {
-				assert.NotNil(t, err)
-			} else {
-				assert.Nil(t, err)
-			}
+			assert.Nil(t, err)
+			assert.NotContains(t, GetAllSources(), tt.source)
 		})
 	}
 }
 
-func TestDetermineParser(t *testing.T) {
+func TestUpdateSourcesFile(t *testing.T) {
 	tests := []struct {
-		format   string
-		source   string
-		expected Parser
+		name          string
+		storagePath   string
+		expectedError bool
 	}{
 		{
-			format: "json",
-			source: "source1",
-			expected: JsonParser{
-				Source: "source1",
-			},
-		},
-		{
-			format: "xml",
-			source: "source2",
-			expected: XMLParser{
-				Source: "source2",
-			},
-		},
-		{
-			format: "html",
-			source: "source3",
-			expected: HtmlParser{
-				Source: "source3",
-			},
+			name:          "Successful execution",
+			storagePath:   t.TempDir(),
+			expectedError: false,
 		},
 		{
-			format:   "invalid",
-			source:   "source4",
-			expected: nil,
+			name:          "Invalid (non-existent) path to the storage",
+			storagePath:   "non/existent/path",
+			expectedError: true,
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.format, func(t *testing.T) {
-			got := determineParser(tt.format, tt.source)
-			if got != tt.expected {
-				t.Errorf("determineParser(%s, %s) = %v, expected %v", tt.format, tt.source, got, tt.expected)
+		t.Run(tt.name, func(t *testing.T) {
+			storagePath := StoragePath
+			defer func() {
+				StoragePath = storagePath
+			}()
+			StoragePath = tt.storagePath
+
+			err := UpdateSourceFile()
+
+			if tt.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
 			}
 		})
 	}
 }
 
-func TestDetermineFormat(t *testing.T) {
-	tests := []struct {
-		parser   Parser
-		source   string
-		expected string
-	}{
-		{JsonParser{Source: "source1"}, "source1", "json"},
-		{XMLParser{Source: "source2"}, "source2", "xml"},
-		{HtmlParser{Source: "source3"}, "source3", "html"},
-		{nil, "source4", ""},
-	}
+func TestSourcesLoaded(t *testing.T) {
+	storagePath := StoragePath
+	defer func() {
+		StoragePath = storagePath
+	}()
+	sourcesLoaded.Store(false)
 
-	for _, tt := range tests {
-		t.Run(tt.expected, func(t *testing.T) {
-			got := determineFormat(tt.parser, tt.source)
-			if got != tt.expected {
-				t.Errorf("determineFormat(%v, %s) = %v, expected %v", tt.parser, tt.source, got, tt.expected)
-			}
-		})
-	}
+	StoragePath = t.TempDir()
+	err := LoadSourcesFile()
+	assert.NotNil(t, err)
+	assert.False(t, SourcesLoaded())
+
+	err = UpdateSourceFile()
+	assert.Nil(t, err)
+	assert.True(t, SourcesLoaded())
 }
 
-func TestUpdateSourcesFile(t *testing.T) {
+func TestSetDefaultSources(t *testing.T) {
 	tests := []struct {
-		name          string
-		setup         func()
-		expectedError bool
+		name            string
+		feeds           []types.Feed
+		expectedSources map[string]string
+		expectedErr     bool
 	}{
 		{
-			name: "Successful execution",
-			setup: func() {
+			name: "Replaces built-in sources",
+			feeds: []types.Feed{
+				{Name: "guardian", Format: XmlFormat, Endpoint: "https://www.theguardian.com/world/rss"},
+				{Name: "npr", Endpoint: "https://feeds.npr.org/1001/rss.xml"},
 			},
-			expectedError: false,
-		},
-		{
-			name: "Invalid (non-existent) path to the storage",
-			setup: func() {
-				StoragePath = "non/existent/path"
+			expectedSources: map[string]string{
+				"guardian": "https://www.theguardian.com/world/rss",
+				"npr":      "https://feeds.npr.org/1001/rss.xml",
 			},
-			expectedError: true,
 		},
 		{
-			name: "Remove data from sourceToParser, to cause error",
-			setup: func() {
-				sourceToParser = nil
+			name: "Unknown format keeps sources unchanged",
+			feeds: []types.Feed{
+				{Name: "guardian", Format: "csv", Endpoint: "https://www.theguardian.com/world/rss"},
 			},
-			expectedError: true,
+			expectedErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup()
+			before, parsers, feeds := maps.Clone(sourceToEndpoint), maps.Clone(sourceToParser), maps.Clone(sourceToFeed)
+			defer func() {
+				sourceToEndpoint, sourceToParser, sourceToFeed = before, parsers, feeds
+			}()
 
-			err := UpdateSourceFile()
-
-			if tt.expectedError {
+			err := SetDefaultSources(tt.feeds)
+			if tt.expectedErr {
 				assert.NotNil(t, err)
-			} else {
-				assert.Nil(t, err)
+				assert.Equal(t, before, sourceToEndpoint)
+				return
 			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedSources, sourceToEndpoint)
+			assert.Equal(t, XmlFormat, sourceToFeed["npr"].Format)
 		})
 	}
 }
@@ -1,11 +1,7 @@
 package parsers
 
 import (
-	"errors"
 	"fmt"
-	types "gogator/cmd/types"
-	"os"
-	"sync"
 	"time"
 )
 
@@ -36,43 +32,3 @@ func GenerateDateRange(dateFrom, dateEnd string) ([]string, error) {
 
 	return dates, nil
 }
-
-// FromFiles retrieves news articles from JSON files within the specified date range.
-// The date range is inclusive and should be provided in the format "YYYY-MM-DD".
-// The function concurrently parses JSON files for each date in the range.
-// If an error occurs during the parsing of any file, the process is aborted and the error is returned.
-// The returned slice contains all successfully parsed news articles.
-func FromFiles(dateFrom, dateEnd string) ([]types.Article, error) {
-	var (
-		news       []types.Article
-		wg         sync.WaitGroup
-		mu         sync.Mutex
-		errChannel = make(chan error)
-	)
-
-	articlesFilenames, err := GenerateDateRange(dateFrom, dateEnd)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, date := range articlesFilenames {
-		jp := g.JsonParser(date + JsonExtension)
-		wg.Add(1)
-
-		go fetchNews(jp, &news, &wg, &mu, errChannel)
-	}
-
-	wg.Wait()
-	close(errChannel)
-
-	for err := range errChannel {
-		if errors.Is(err, os.ErrNotExist) {
-			continue
-		}
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return news, nil
-}
@@ -1,12 +1,20 @@
 package parsers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"gogator/cmd/dedup"
+	"gogator/cmd/language"
 	"gogator/cmd/types"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Parser interface will be used to implement parsers
@@ -18,6 +26,15 @@ type Parser interface {
 	Parse() ([]types.Article, error)
 }
 
+// ContextParser is implemented by parsers, which fetch data over the network.
+//
+// ParseContext works like Parse, but aborts in-flight requests once ctx is cancelled.
+// Built-in parsers implement it, and parsers registered without it are called with Parse.
+type ContextParser interface {
+	Parser
+	ParseContext(ctx context.Context) ([]types.Article, error)
+}
+
 const (
 	// UsaToday represents the identifier for USA Today source
 	UsaToday = "usatoday"
@@ -50,65 +67,135 @@ const (
 	JsonExtension = ".json"
 )
 
+// ErrAllSourcesFailed is returned in tolerant mode, when none of the requested sources was fetched successfully
+var ErrAllSourcesFailed = errors.New("failed to fetch all sources")
+
 // ParseBySource retrieves all news from a particular source.
 //
 // If the source parameter is equal to "all", news will be retrieved from all sources specified in sourceToParser.
 //
 // The function returns a slice of news items and an error if any occurred during the parsing process.
+// It works in strict mode, use FetchBySource to tolerate failures of particular sources.
 func ParseBySource(source string) ([]types.Article, error) {
+	news, _, err := FetchBySource(context.Background(), source, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+// FetchBySource retrieves news from particular sources (separated by ','), or from all sources if
+// source is empty, and reports the outcome of fetching every source.
+//
+// By default, failing sources do not affect the healthy ones: articles from successfully fetched
+// sources are returned together with per-source results, and an error is returned only if all sources failed.
+// In strict mode no articles are returned, if any of the sources failed.
+//
+// Cancelling ctx aborts requests, which are still in progress.
+// Every article gets a stable ID, and articles repeated in several sources are returned once.
+// Results are sorted by source name.
+func FetchBySource(ctx context.Context, source string, strict bool) ([]types.Article, []types.FetchResult, error) {
 	var (
-		news       []types.Article
-		wg         sync.WaitGroup
-		mu         sync.Mutex
-		errChannel = make(chan error, 1)
+		news    []types.Article
+		results []types.FetchResult
+		wg      sync.WaitGroup
+		mu      sync.Mutex
 	)
 
+	sourcesMu.RLock()
+	selected := make(map[string]Parser)
 	if source == "" {
-		for _, p := range sourceToParser {
-			wg.Add(1)
-			go fetchNews(p, &news, &wg, &mu, errChannel)
-		}
+		maps.Copy(selected, sourceToParser)
 	} else {
-		sources := strings.Split(source, ",")
-		for _, sourceName := range sources {
+		for _, sourceName := range strings.Split(source, ",") {
 			if p, exists := sourceToParser[sourceName]; exists {
-				wg.Add(1)
-				go fetchNews(p, &news, &wg, &mu, errChannel)
+				selected[sourceName] = p
 			}
 		}
 	}
+	sourcesMu.RUnlock()
+
+	for name, p := range selected {
+		wg.Add(1)
+		go fetchNews(ctx, name, p, &news, &results, &wg, &mu)
+	}
 
 	wg.Wait()
-	close(errChannel)
 
-	if err, ok := <-errChannel; ok {
-		return nil, err
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Source < results[j].Source
+	})
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", result.Source, result.Err)
+			}
+		}
 	}
 
-	return news, nil
+	if strict && firstErr != nil {
+		return nil, results, firstErr
+	}
+	if failed > 0 && failed == len(results) {
+		return nil, results, fmt.Errorf("%w: %w", ErrAllSourcesFailed, firstErr)
+	}
+
+	return dedup.Deduplicate(news), results, nil
 }
 
 // fetchNews is a helper function to parse news from a given parser.
 //
-// # It updates the news slice in a concurrency-safe manner and sends any errors to errChannel
+// # It updates the news slice and the results slice in a concurrency-safe manner
 //
-// We use pointers to all variables from function ParseBySource and FromFiles.
+// We use pointers to all variables from function FetchBySource.
 // It will cause a panic if we will call wg.Done() without passing a pointer:
 // / each goroutine would receive its own copy of the WaitGroup, which leads to incorrect synchronization:
 // / because the Add, Done, and Wait calls would affect separate WaitGroup instances,
 // / and most likely causing the Wait() function to never return or behave unpredictably.
-func fetchNews(p Parser, news *[]types.Article, wg *sync.WaitGroup, mu *sync.Mutex, errChannel chan<- error) {
+func fetchNews(ctx context.Context, source string, p Parser, news *[]types.Article, results *[]types.FetchResult, wg *sync.WaitGroup, mu *sync.Mutex) {
 	defer wg.Done()
 
-	parsedNews, err := p.Parse()
-	if err != nil {
-		errChannel <- err
-		return
+	start := time.Now()
+	parsedNews, err := parseWithContext(ctx, p)
+
+	// source without new articles is not a failure. After a real failure validators are forgotten,
+	// so the next fetch retrieves the whole feed, even if it has not changed since then
+	notModified := errors.Is(err, ErrNotModified)
+	if notModified {
+		err = nil
+	} else if validators := fetcher.Load().validators; err != nil && validators != nil {
+		validators.forget(source)
 	}
 
+	result := types.NewFetchResult(source, len(parsedNews), time.Since(start), err)
+	result.NotModified = notModified
+
 	mu.Lock()
-	*news = append(*news, parsedNews...)
-	mu.Unlock()
+	defer mu.Unlock()
+
+	*results = append(*results, result)
+	if err == nil {
+		types.NormalizePubDates(parsedNews, start)
+		language.Assign(parsedNews)
+		dedup.AssignIDs(parsedNews)
+		*news = append(*news, parsedNews...)
+	}
+}
+
+// parseWithContext calls ParseContext, if parser supports it, and Parse otherwise
+func parseWithContext(ctx context.Context, p Parser) ([]types.Article, error) {
+	if cp, ok := p.(ContextParser); ok {
+		return cp.ParseContext(ctx)
+	}
+
+	return p.Parse()
 }
 
 // extractFileData reads data from file $filename and returns its content
@@ -1,6 +1,8 @@
 package parsers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"gogator/cmd/filters"
@@ -42,46 +44,113 @@ func TestParseWithParams(t *testing.T) {
 	}
 }
 
+// stubParser is a Parser which returns predefined articles or error, without making any requests
+type stubParser struct {
+	articles []types.Article
+	err      error
+}
+
+func (s stubParser) Parse() ([]types.Article, error) {
+	return s.articles, s.err
+}
+
 func Test_fetchNews(t *testing.T) {
+	tests := []struct {
+		name             string
+		p                Parser
+		expectedArticles int
+		expectedErr      bool
+	}{
+		{
+			name:             "Successful execution",
+			p:                stubParser{articles: []types.Article{{Title: "First"}, {Title: "Second"}}},
+			expectedArticles: 2,
+			expectedErr:      false,
+		},
+		{
+			name:             "Incorrect endpoint (bad parser)",
+			p:                stubParser{err: errors.New("unsupported protocol scheme")},
+			expectedArticles: 0,
+			expectedErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				news    []types.Article
+				results []types.FetchResult
+				wg      sync.WaitGroup
+				mu      sync.Mutex
+			)
+
+			wg.Add(1)
+			fetchNews(context.Background(), "source", tt.p, &news, &results, &wg, &mu)
+			wg.Wait()
+
+			assert.Len(t, news, tt.expectedArticles)
+			assert.Len(t, results, 1)
+			assert.Equal(t, "source", results[0].Source)
+			assert.Equal(t, tt.expectedArticles, results[0].Articles)
+			assert.Equal(t, tt.expectedErr, results[0].Failed())
+		})
+	}
+}
+
+func TestFetchBySource(t *testing.T) {
+	healthy := stubParser{articles: []types.Article{{Title: "Healthy"}}}
+	broken := stubParser{err: errors.New("connection refused")}
 
 	tests := []struct {
-		name        string
-		p           Parser
-		news        *[]types.Article
-		wg          *sync.WaitGroup
-		mu          *sync.Mutex
-		errChannel  chan error
-		expectedErr bool
+		name             string
+		parsers          map[string]Parser
+		strict           bool
+		expectedArticles int
+		expectedResults  int
+		expectedErr      bool
 	}{
 		{
-			name:        "Successful execution",
-			p:           g.XmlParser(sourceToEndpoint[ABC]),
-			news:        new([]types.Article),
-			wg:          &sync.WaitGroup{},
-			mu:          &sync.Mutex{},
-			errChannel:  make(chan error, 1),
-			expectedErr: false,
+			name:             "Broken source does not affect healthy ones",
+			parsers:          map[string]Parser{"healthy": healthy, "broken": broken},
+			strict:           false,
+			expectedArticles: 1,
+			expectedResults:  2,
+			expectedErr:      false,
+		},
+		{
+			name:             "Broken source fails fetching in strict mode",
+			parsers:          map[string]Parser{"healthy": healthy, "broken": broken},
+			strict:           true,
+			expectedArticles: 0,
+			expectedResults:  2,
+			expectedErr:      true,
 		},
 		{
-			name:        "Incorrect endpoint (bad parser)",
-			p:           g.XmlParser(""),
-			news:        new([]types.Article),
-			wg:          &sync.WaitGroup{},
-			mu:          &sync.Mutex{},
-			errChannel:  make(chan error, 1),
-			expectedErr: true,
+			name:             "All sources are broken",
+			parsers:          map[string]Parser{"broken": broken},
+			strict:           false,
+			expectedArticles: 0,
+			expectedResults:  1,
+			expectedErr:      true,
 		},
 	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fetchNews(tt.p, tt.news, tt.wg, tt.mu, tt.errChannel)
+			original := sourceToParser
+			sourceToParser = tt.parsers
+			defer func() {
+				sourceToParser = original
+			}()
+
+			news, results, err := FetchBySource(context.Background(), AllSources, tt.strict)
 
-			err := <-tt.errChannel
 			if tt.expectedErr {
 				assert.NotNil(t, err)
 			} else {
 				assert.Nil(t, err)
 			}
+			assert.Len(t, news, tt.expectedArticles)
+			assert.Len(t, results, tt.expectedResults)
 		})
 	}
 }
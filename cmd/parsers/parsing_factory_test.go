@@ -10,7 +10,7 @@ func TestGoGatorParsingFactory_CreateHtmlParser(t *testing.T) {
 
 	htmlParser := g.HtmlParser("source")
 
-	assert.Equal(t, htmlParser, HtmlParser{"source"})
+	assert.Equal(t, htmlParser, HtmlParser{Source: "source"})
 }
 
 func TestGoGatorParsingFactory_CreateJsonParser(t *testing.T) {
@@ -18,7 +18,7 @@ func TestGoGatorParsingFactory_CreateJsonParser(t *testing.T) {
 
 	jsonParser := g.JsonParser("source")
 
-	assert.Equal(t, jsonParser, JsonParser{"source"})
+	assert.Equal(t, jsonParser, JsonParser{Source: "source"})
 }
 
 func TestGoGatorParsingFactory_CreateXmlParser(t *testing.T) {
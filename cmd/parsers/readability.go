@@ -0,0 +1,348 @@
+package parsers
+
+import (
+	"bytes"
+	"context"
+	"github.com/PuerkitoBio/goquery"
+	"gogator/cmd/types"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultReadabilityInterval is the minimal delay between requests of article pages to the same host
+	defaultReadabilityInterval = time.Second
+
+	// defaultReadabilityCacheSize is amount of article links, which content is remembered
+	defaultReadabilityCacheSize = 10000
+
+	// minParagraphLength is the length of text, starting from which paragraph is considered to be the content
+	minParagraphLength = 25
+
+	// maxLinkDensity is the share of the text in links, starting from which paragraph is considered to be navigation
+	maxLinkDensity = 0.5
+
+	// classWeight is added to the score of blocks, which class or id looks like content, and subtracted otherwise
+	classWeight = 25
+)
+
+var (
+	// extractor is shared by all sources in readability mode
+	extractor = NewContentExtractor(ReadabilityConfig{})
+
+	// boilerplateSelector matches elements, which are never a part of the article body
+	boilerplateSelector = "script, style, noscript, template, iframe, svg, canvas, form, button, input, select, " +
+		"nav, header, footer, aside, figure, [role=navigation], [role=banner], [role=contentinfo], [aria-hidden=true]"
+
+	// paragraphSelector matches elements, which contain text of the article
+	paragraphSelector = "p, pre, blockquote, h2, h3, li"
+
+	// negativePattern matches classes and ids of blocks, which usually surround the article
+	negativePattern = regexp.MustCompile(`(?i)comment|sidebar|footer|share|social|related|promo|advert|banner|` +
+		`sponsor|cookie|newsletter|subscribe|popup|modal|menu|breadcrumb|masthead|widget|recommend`)
+
+	// positivePattern matches classes and ids of blocks, which usually contain the article
+	positivePattern = regexp.MustCompile(`(?i)article|body|content|entry|main|post|story|text|blog`)
+
+	// whitespace matches runs of spaces, which are collapsed in the extracted text
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// ReadabilityConfig contains settings of ContentExtractor. Zero values are replaced with defaults.
+type ReadabilityConfig struct {
+	// Interval is the minimal delay between requests to the same host, so sources are not flooded
+	// with requests of every article of the feed
+	Interval time.Duration
+
+	// CacheSize is amount of article links, which content is remembered. The oldest links are forgotten first.
+	CacheSize int
+}
+
+// ContentExtractor follows links of articles and extracts the main text of their pages.
+//
+// Requests to the same host are made not more often than once per Interval, and extracted content
+// is cached by article link, so every page is requested once. Pages, which failed to be fetched or
+// have no recognizable content, are cached as well, and are not requested again until they are forgotten.
+//
+// ContentExtractor is safe for concurrent use.
+type ContentExtractor struct {
+	config ReadabilityConfig
+
+	mu sync.Mutex
+
+	// hostToNext maps hosts to the moment, when the next request to them is allowed
+	hostToNext map[string]time.Time
+
+	// linkToContent maps article links to their extracted content
+	linkToContent map[string]string
+
+	// links are cached links in order of their extraction, so the oldest one is forgotten first
+	links []string
+}
+
+// NewContentExtractor creates an instance of ContentExtractor, replacing zero values of config with defaults
+func NewContentExtractor(config ReadabilityConfig) *ContentExtractor {
+	if config.Interval == 0 {
+		config.Interval = defaultReadabilityInterval
+	}
+	if config.CacheSize <= 0 {
+		config.CacheSize = defaultReadabilityCacheSize
+	}
+
+	return &ContentExtractor{
+		config:        config,
+		hostToNext:    make(map[string]time.Time),
+		linkToContent: make(map[string]string),
+	}
+}
+
+// ConfigureReadability replaces the extractor shared by sources in readability mode with a new one,
+// created from config. Cached content is forgotten.
+func ConfigureReadability(config ReadabilityConfig) {
+	extractor = NewContentExtractor(config)
+}
+
+// Enrich sets content of articles to the main text of their pages.
+//
+// Articles, which already have content (e.g. from <content:encoded> of the feed), or have no link, are skipped.
+// Failures of particular pages are not errors: their articles are left without content.
+// Once ctx is cancelled, remaining articles are left as they are.
+func (e *ContentExtractor) Enrich(ctx context.Context, articles []types.Article) {
+	for i := range articles {
+		if articles[i].Content != "" || articles[i].Link == "" {
+			continue
+		}
+
+		content, err := e.content(ctx, articles[i].Link)
+		if err != nil {
+			return
+		}
+
+		articles[i].Content = content
+	}
+}
+
+// content returns cached content of the article page, or fetches the page and extracts its content.
+// Returns an error only if ctx was cancelled.
+func (e *ContentExtractor) content(ctx context.Context, link string) (string, error) {
+	e.mu.Lock()
+	content, exists := e.linkToContent[link]
+	e.mu.Unlock()
+
+	if exists {
+		return content, nil
+	}
+
+	err := e.wait(ctx, link)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := fetcher.Load().Fetch(ctx, link)
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if err == nil {
+		content = ExtractContent(data)
+	}
+
+	e.remember(link, content)
+	return content, nil
+}
+
+// wait blocks until the request to the host of the link is allowed, and reserves the next slot for it
+func (e *ContentExtractor) wait(ctx context.Context, link string) error {
+	host := link
+	if u, err := url.Parse(link); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	now := time.Now()
+
+	e.mu.Lock()
+	next := e.hostToNext[host]
+	if next.Before(now) {
+		next = now
+	}
+	e.hostToNext[host] = next.Add(e.config.Interval)
+	e.mu.Unlock()
+
+	if !next.After(now) {
+		return nil
+	}
+
+	return sleep(ctx, next.Sub(now))
+}
+
+// remember caches content of the link, forgetting the oldest links, when cache is full
+func (e *ContentExtractor) remember(link, content string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.linkToContent[link]; exists {
+		return
+	}
+
+	for len(e.links) >= e.config.CacheSize {
+		delete(e.linkToContent, e.links[0])
+		e.links = e.links[1:]
+	}
+
+	e.links = append(e.links, link)
+	e.linkToContent[link] = content
+}
+
+// ExtractContent returns the main text of the HTML page, removing navigation, comments,
+// advertising and other boilerplate, in the manner of readability tools.
+//
+// Blocks of the page are scored by paragraphs of text they contain: longer paragraphs with more commas
+// score higher, and classes or ids like "article" or "sidebar" increase or decrease the score.
+// Paragraphs of the best block, which are not mostly links, are joined with empty lines.
+// Empty string is returned, if page has no recognizable content.
+func ExtractContent(page []byte) string {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return ""
+	}
+
+	doc.Find(boilerplateSelector).Remove()
+
+	// candidates are blocks, which contain paragraphs, indexed by their nodes
+	var candidates []*candidate
+	nodeToCandidate := make(map[any]*candidate)
+
+	addScore := func(block *goquery.Selection, score float64) {
+		if block.Length() == 0 {
+			return
+		}
+
+		c, exists := nodeToCandidate[block.Get(0)]
+		if !exists {
+			c = &candidate{block: block, score: blockWeight(block)}
+			nodeToCandidate[block.Get(0)] = c
+			candidates = append(candidates, c)
+		}
+		c.score += score
+	}
+
+	doc.Find("p, pre, td").Each(func(i int, paragraph *goquery.Selection) {
+		text := normalizeText(paragraph.Text())
+		if len(text) < minParagraphLength {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+
+		addScore(paragraph.Parent(), score)
+		addScore(paragraph.Parent().Parent(), score/2)
+	})
+
+	var (
+		best      *goquery.Selection
+		bestScore float64
+	)
+	for _, c := range candidates {
+		score := c.score * (1 - linkDensity(c.block))
+		if best == nil || score > bestScore {
+			best, bestScore = c.block, score
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+
+	var paragraphs []string
+	best.Find(paragraphSelector).Each(func(i int, paragraph *goquery.Selection) {
+		// nested paragraphs, e.g. <p> inside of <li> or <blockquote>, are taken with their parent
+		if paragraph.ParentsFiltered(paragraphSelector).Length() > 0 {
+			return
+		}
+
+		text := normalizeText(paragraph.Text())
+		if text == "" || linkDensity(paragraph) > maxLinkDensity {
+			return
+		}
+
+		paragraphs = append(paragraphs, text)
+	})
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// candidate is a block of the page, which may contain the article, with its score
+type candidate struct {
+	block *goquery.Selection
+	score float64
+}
+
+// blockWeight returns the initial score of the block by its class and id
+func blockWeight(block *goquery.Selection) float64 {
+	weight := 0.0
+	for _, attribute := range []string{"class", "id"} {
+		value, exists := block.Attr(attribute)
+		if !exists || value == "" {
+			continue
+		}
+
+		if negativePattern.MatchString(value) {
+			weight -= classWeight
+		}
+		if positivePattern.MatchString(value) {
+			weight += classWeight
+		}
+	}
+
+	switch goquery.NodeName(block) {
+	case "article", "main":
+		weight += classWeight
+	}
+
+	return weight
+}
+
+// linkDensity returns the share of the block text, which is a text of links
+func linkDensity(block *goquery.Selection) float64 {
+	length := len(normalizeText(block.Text()))
+	if length == 0 {
+		return 0
+	}
+
+	linksLength := 0
+	block.Find("a").Each(func(i int, link *goquery.Selection) {
+		linksLength += len(normalizeText(link.Text()))
+	})
+
+	return float64(linksLength) / float64(length)
+}
+
+// normalizeText collapses whitespaces of the text and trims it
+func normalizeText(text string) string {
+	return strings.TrimSpace(whitespace.ReplaceAllString(text, " "))
+}
+
+// readabilityParser decorates the parser of a source in readability mode: after the feed is parsed,
+// links of its articles are followed, and the main text of their pages is stored as their content
+type readabilityParser struct {
+	Parser
+}
+
+// Parse parses the feed and extracts content of its articles
+func (rp readabilityParser) Parse() ([]types.Article, error) {
+	return rp.ParseContext(context.Background())
+}
+
+// ParseContext works like Parse, but aborts fetching the feed and pages of articles once ctx is cancelled
+func (rp readabilityParser) ParseContext(ctx context.Context) ([]types.Article, error) {
+	articles, err := parseWithContext(ctx, rp.Parser)
+	if err != nil {
+		return nil, err
+	}
+
+	extractor.Enrich(ctx, articles)
+	return articles, nil
+}
@@ -0,0 +1,150 @@
+package parsers
+
+import (
+	"context"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const readabilityPage = `<!DOCTYPE html><html><head><title>Story</title><script>var tracking = 1;</script></head>
+<body>
+	<header><nav><a href="/">Home</a> <a href="/world">World</a> <a href="/sport">Sport</a></nav></header>
+	<div class="sidebar">
+		<p>Most read: <a href="/1">Something happened somewhere, and it is popular</a></p>
+		<p>Subscribe to our newsletter, to receive the news every morning</p>
+	</div>
+	<div id="story-body" class="article-content">
+		<h1>Ukraine election results</h1>
+		<p>Voters went to the polls on Sunday, in the first election since the war began, officials said.</p>
+		<p>The prime minister won, with turnout being the highest in a decade, according to the commission.</p>
+		<p><a href="/related">Read more: the history of elections in the region</a></p>
+		<ul><li>Results will be certified next week</li></ul>
+	</div>
+	<footer><p>Copyright 2024, All rights reserved, Example News Corporation</p></footer>
+</body></html>`
+
+func TestExtractContent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		page     string
+		expected string
+	}{
+		{
+			name: "Article with boilerplate",
+			page: readabilityPage,
+			expected: "Voters went to the polls on Sunday, in the first election since the war began, officials said.\n\n" +
+				"The prime minister won, with turnout being the highest in a decade, according to the commission.\n\n" +
+				"Results will be certified next week",
+		},
+		{
+			name:     "Page without paragraphs",
+			page:     `<html><body><nav><a href="/">Home</a></nav><div>Short</div></body></html>`,
+			expected: "",
+		},
+		{
+			name:     "Empty page",
+			page:     ``,
+			expected: "",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractContent([]byte(tt.page)))
+		})
+	}
+}
+
+func TestContentExtractor_Enrich(t *testing.T) {
+	httpmock.ActivateNonDefault(fetcher.Load().Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://example.com/story",
+		httpmock.NewStringResponder(http.StatusOK, readabilityPage))
+	httpmock.RegisterResponder("GET", "https://example.com/missing",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	e := NewContentExtractor(ReadabilityConfig{Interval: time.Millisecond})
+
+	articles := []types.Article{
+		{Title: "Story", Link: "https://example.com/story"},
+		{Title: "Missing", Link: "https://example.com/missing"},
+		{Title: "With content", Link: "https://example.com/other", Content: "Content of the feed"},
+		{Title: "Without link"},
+	}
+	e.Enrich(context.Background(), articles)
+
+	assert.Contains(t, articles[0].Content, "Voters went to the polls on Sunday")
+	assert.NotContains(t, articles[0].Content, "newsletter")
+	assert.Empty(t, articles[1].Content)
+	assert.Equal(t, "Content of the feed", articles[2].Content)
+	assert.Empty(t, articles[3].Content)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["GET https://example.com/story"])
+	assert.Equal(t, 1, info["GET https://example.com/missing"])
+	assert.Equal(t, 0, info["GET https://example.com/other"])
+
+	// content is cached by link, so pages are requested once, including the failed ones
+	again := []types.Article{
+		{Title: "Story", Link: "https://example.com/story"},
+		{Title: "Missing", Link: "https://example.com/missing"},
+	}
+	e.Enrich(context.Background(), again)
+
+	assert.Equal(t, articles[0].Content, again[0].Content)
+	info = httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["GET https://example.com/story"])
+	assert.Equal(t, 1, info["GET https://example.com/missing"])
+}
+
+func TestContentExtractor_RateLimit(t *testing.T) {
+	e := NewContentExtractor(ReadabilityConfig{Interval: 50 * time.Millisecond})
+
+	start := time.Now()
+	for _, link := range []string{"https://example.com/1", "https://other.com/1", "https://example.com/2", "https://example.com/3"} {
+		err := e.wait(context.Background(), link)
+		assert.NoError(t, err)
+	}
+
+	// the first request to every host is not delayed, and the next ones wait for the interval
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, e.wait(ctx, "https://example.com/4"), context.Canceled)
+}
+
+func TestContentExtractor_CacheSize(t *testing.T) {
+	e := NewContentExtractor(ReadabilityConfig{CacheSize: 2})
+
+	e.remember("https://example.com/1", "first")
+	e.remember("https://example.com/2", "second")
+	e.remember("https://example.com/3", "third")
+
+	assert.Equal(t, []string{"https://example.com/2", "https://example.com/3"}, e.links)
+	assert.NotContains(t, e.linkToContent, "https://example.com/1")
+	assert.Equal(t, "third", e.linkToContent["https://example.com/3"])
+}
+
+func TestSetFeed_Readability(t *testing.T) {
+	const source = "readability-source"
+	defer func() {
+		delete(sourceToEndpoint, source)
+		delete(sourceToParser, source)
+		delete(sourceToFeed, source)
+	}()
+
+	enabled := true
+	err := setFeed(types.Feed{Name: source, Endpoint: "https://example.com/rss", Readability: &enabled})
+	assert.NoError(t, err)
+	assert.Equal(t, readabilityParser{Parser: g.XmlParser(source)}, sourceToParser[source])
+
+	err = setFeed(types.Feed{Name: source, Endpoint: "https://example.com/rss"})
+	assert.NoError(t, err)
+	assert.Equal(t, g.XmlParser(source), sourceToParser[source])
+}
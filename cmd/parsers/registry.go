@@ -0,0 +1,129 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/types"
+	"sort"
+	"sync"
+)
+
+// ParserConstructor creates a Parser for the given feed.
+//
+// It receives the whole types.Feed, so the parser is able to use per-source configuration
+// stored in sources.json. Returns an error if the feed configuration is not suitable for this parser.
+type ParserConstructor func(feed types.Feed) (Parser, error)
+
+const (
+	// XmlFormat identifies feeds which are parsed by XMLParser
+	XmlFormat = "xml"
+
+	// AtomFormat identifies Atom feeds. They are parsed by XMLParser, which detects the feed flavour by itself
+	AtomFormat = "atom"
+
+	// JsonFormat identifies feeds which are parsed by JsonParser
+	JsonFormat = "json"
+
+	// HtmlFormat identifies feeds which are parsed by HtmlParser
+	HtmlFormat = "html"
+
+	// DefaultFormat is used when source was registered without format
+	DefaultFormat = XmlFormat
+)
+
+var (
+	// ErrUnknownFormat is returned when there is no parser registered for the requested format
+	ErrUnknownFormat = errors.New("unknown source format")
+
+	// ErrFormatRegistered is returned when the format already has a registered parser
+	ErrFormatRegistered = errors.New("format is already registered")
+
+	// registryMu guards formatToConstructor
+	registryMu sync.RWMutex
+
+	// formatToConstructor maps format names to constructors of their parsers
+	formatToConstructor = map[string]ParserConstructor{
+		XmlFormat: func(feed types.Feed) (Parser, error) {
+			return g.XmlParser(feed.Name), nil
+		},
+		AtomFormat: func(feed types.Feed) (Parser, error) {
+			return g.XmlParser(feed.Name), nil
+		},
+		JsonFormat: func(feed types.Feed) (Parser, error) {
+			err := ValidateMapping(feed.Mapping)
+			if err != nil {
+				return nil, err
+			}
+
+			return JsonParser{
+				Source:  feed.Name,
+				Mapping: feed.Mapping,
+			}, nil
+		},
+		HtmlFormat: func(feed types.Feed) (Parser, error) {
+			err := ValidateScrapingProfile(feed.Scraping)
+			if err != nil {
+				return nil, err
+			}
+
+			return HtmlParser{
+				Source:  feed.Name,
+				Profile: feed.Scraping,
+			}, nil
+		},
+	}
+)
+
+// Register makes a parser available for sources of the given format.
+//
+// It is meant to be called from init functions of packages which provide new parsers.
+// Throws an error if format is empty, constructor is nil, or format was already registered.
+func Register(format string, constructor ParserConstructor) error {
+	if format == "" || constructor == nil {
+		return errors.New("format name and parser constructor are required")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := formatToConstructor[format]; exists {
+		return fmt.Errorf("%w: %s", ErrFormatRegistered, format)
+	}
+	formatToConstructor[format] = constructor
+
+	return nil
+}
+
+// NewParser resolves the feed format through the registry and creates its Parser.
+//
+// Empty format is treated as DefaultFormat.
+// Throws ErrUnknownFormat, if no parser was registered for the feed format.
+func NewParser(feed types.Feed) (Parser, error) {
+	if feed.Format == "" {
+		feed.Format = DefaultFormat
+	}
+
+	registryMu.RLock()
+	constructor, exists := formatToConstructor[feed.Format]
+	registryMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s. Supported formats are: %v", ErrUnknownFormat, feed.Format, SupportedFormats())
+	}
+
+	return constructor(feed)
+}
+
+// SupportedFormats returns sorted names of all registered formats
+func SupportedFormats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	formats := make([]string, 0, len(formatToConstructor))
+	for format := range formatToConstructor {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	return formats
+}
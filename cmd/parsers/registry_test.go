@@ -0,0 +1,110 @@
+package parsers
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestNewParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		feed        types.Feed
+		expected    Parser
+		expectedErr error
+	}{
+		{
+			name:     "Json parser",
+			feed:     types.Feed{Name: "source1", Format: JsonFormat},
+			expected: JsonParser{Source: "source1"},
+		},
+		{
+			name:     "Xml parser",
+			feed:     types.Feed{Name: "source2", Format: XmlFormat},
+			expected: XMLParser{Source: "source2"},
+		},
+		{
+			name:     "Html parser",
+			feed:     types.Feed{Name: "source3", Format: HtmlFormat},
+			expected: HtmlParser{Source: "source3"},
+		},
+		{
+			name:     "Empty format is resolved to default one",
+			feed:     types.Feed{Name: "source4"},
+			expected: XMLParser{Source: "source4"},
+		},
+		{
+			name:        "Unknown format",
+			feed:        types.Feed{Name: "source5", Format: "invalid"},
+			expected:    nil,
+			expectedErr: ErrUnknownFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(tt.feed)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	constructor := func(feed types.Feed) (Parser, error) {
+		return nil, errors.New("not implemented")
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		constructor ParserConstructor
+		finish      func()
+		expectedErr bool
+	}{
+		{
+			name:        "Register new format",
+			format:      "yaml",
+			constructor: constructor,
+			finish: func() {
+				delete(formatToConstructor, "yaml")
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "Register already existing format",
+			format:      XmlFormat,
+			constructor: constructor,
+			finish:      func() {},
+			expectedErr: true,
+		},
+		{
+			name:        "Register format without constructor",
+			format:      "csv",
+			constructor: nil,
+			finish:      func() {},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.format, tt.constructor)
+
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Contains(t, SupportedFormats(), tt.format)
+			}
+
+			tt.finish()
+		})
+	}
+}
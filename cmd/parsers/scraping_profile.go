@@ -0,0 +1,85 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andybalholm/cascadia"
+	"gogator/cmd/types"
+	"net/url"
+)
+
+var (
+	// ErrInvalidScrapingProfile is returned when scraping profile of the HTML source is not valid
+	ErrInvalidScrapingProfile = errors.New("invalid scraping profile")
+
+	// GenericScrapingProfile is used for HTML sources without configured profile.
+	// It relies on semantic HTML elements, which are used by most of news websites.
+	GenericScrapingProfile = types.ScrapingProfile{
+		ItemSelector:        "article",
+		TitleSelector:       "h1, h2, h3",
+		LinkSelector:        "a[href]",
+		DateSelector:        "time",
+		DateAttribute:       "datetime",
+		DescriptionSelector: "p",
+		AuthorSelector:      "[rel=author]",
+		ImageSelector:       "img[src]",
+	}
+
+	// usaTodayScrapingProfile extracts articles from the USA Today main page
+	usaTodayScrapingProfile = types.ScrapingProfile{
+		ItemSelector:  UsaTodayKeySelector,
+		TitleSelector: TitleSelector,
+		DateSelector:  TimestampSelector,
+		DateAttribute: TimestampAttribute,
+	}
+
+	// defaultScrapingProfiles maps built-in HTML sources to their profiles.
+	// They are used, when sources.json was created before scraping profiles were introduced.
+	defaultScrapingProfiles = map[string]types.ScrapingProfile{
+		UsaToday: usaTodayScrapingProfile,
+	}
+)
+
+// ValidateScrapingProfile checks that item selector is provided, all selectors are valid CSS selectors,
+// and base URL is absolute. Nil profile is valid, since it is optional.
+func ValidateScrapingProfile(profile *types.ScrapingProfile) error {
+	if profile == nil {
+		return nil
+	}
+
+	if profile.ItemSelector == "" {
+		return fmt.Errorf("%w: item selector is required", ErrInvalidScrapingProfile)
+	}
+
+	selectors := map[string]string{
+		"item":        profile.ItemSelector,
+		"title":       profile.TitleSelector,
+		"link":        profile.LinkSelector,
+		"date":        profile.DateSelector,
+		"description": profile.DescriptionSelector,
+		"author":      profile.AuthorSelector,
+		"category":    profile.CategorySelector,
+		"image":       profile.ImageSelector,
+		"content":     profile.ContentSelector,
+	}
+
+	for field, selector := range selectors {
+		if selector == "" {
+			continue
+		}
+
+		_, err := cascadia.Compile(selector)
+		if err != nil {
+			return fmt.Errorf("%w: invalid %s selector %q: %v", ErrInvalidScrapingProfile, field, selector, err)
+		}
+	}
+
+	if profile.BaseUrl != "" {
+		base, err := url.Parse(profile.BaseUrl)
+		if err != nil || !base.IsAbs() {
+			return fmt.Errorf("%w: base URL should be absolute, got %q", ErrInvalidScrapingProfile, profile.BaseUrl)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package parsers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+func TestValidateScrapingProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     *types.ScrapingProfile
+		expectedErr bool
+	}{
+		{
+			name:        "Empty profile",
+			profile:     nil,
+			expectedErr: false,
+		},
+		{
+			name:        "Generic profile",
+			profile:     &GenericScrapingProfile,
+			expectedErr: false,
+		},
+		{
+			name: "Profile without item selector",
+			profile: &types.ScrapingProfile{
+				TitleSelector: "h2",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Invalid title selector",
+			profile: &types.ScrapingProfile{
+				ItemSelector:  "article",
+				TitleSelector: "h2[",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Relative base URL",
+			profile: &types.ScrapingProfile{
+				ItemSelector: "article",
+				BaseUrl:      "/news",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScrapingProfile(tt.profile)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidScrapingProfile)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
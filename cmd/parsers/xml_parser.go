@@ -1,17 +1,48 @@
 package parsers
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"gogator/cmd/types"
-	"io"
-	"net/http"
+	"strings"
 )
 
+const (
+	// rssRootElement is the name of root element in RSS 2.0 feeds
+	rssRootElement = "rss"
+
+	// rdfRootElement is the name of root element in RSS 1.0 feeds
+	rdfRootElement = "RDF"
+
+	// atomRootElement is the name of root element in Atom 1.0 feeds
+	atomRootElement = "feed"
+
+	// atomAlternateLink is the relation of Atom link which points to the article itself
+	atomAlternateLink = "alternate"
+
+	// atomEnclosureLink is the relation of Atom link which points to a media file of the article
+	atomEnclosureLink = "enclosure"
+
+	// imageMedium is the medium of images in Media RSS elements
+	imageMedium = "image"
+
+	// imageTypePrefix is a prefix of MIME types of images
+	imageTypePrefix = "image/"
+)
+
+// ErrUnsupportedXMLFeed is returned when XML document is neither RSS nor Atom feed
+var ErrUnsupportedXMLFeed = errors.New("unsupported XML feed")
+
 // XMLParser is a struct used to parse article data from XML feeds.
 //
 // It implements the NewsParser interface, which requires a Parse method.
 //
 // This struct is used to dynamically parse feeds containing article data.
+// Feed flavour is detected by the root element of the document, so RSS 1.0 (RDF),
+// RSS 2.0 and Atom 1.0 feeds are supported.
 // The Parse method returns a successfully decoded array of news articles, or an error if parsing fails
 type XMLParser struct {
 	Source string
@@ -24,28 +55,231 @@ type XMLParser struct {
 //
 // Returns a slice of parsed news articles and an error, if any
 func (xp XMLParser) Parse() ([]types.Article, error) {
-	res, err := http.Get(sourceToEndpoint[xp.Source])
+	return xp.ParseContext(context.Background())
+}
+
+// ParseContext works like Parse, but aborts fetching the feed once ctx is cancelled
+func (xp XMLParser) ParseContext(ctx context.Context) ([]types.Article, error) {
+	body, err := fetcher.Load().FetchSource(ctx, xp.Source, sourceEndpoint(xp.Source))
 	if err != nil {
 		return nil, err
 	}
 
-	var news []types.RSS
-
-	body, err := io.ReadAll(res.Body)
+	articles, err := parseXMLFeed(body)
 	if err != nil {
 		return nil, err
 	}
 
-	err = xml.Unmarshal(body, &news)
+	for i := 0; i <= len(articles)-1; i++ {
+		articles[i].Publisher = xp.Source
+	}
+
+	return articles, nil
+}
+
+// parseXMLFeed detects the flavour of the feed by its root element and normalizes
+// its items into an array of articles
+func parseXMLFeed(data []byte) ([]types.Article, error) {
+	root, err := xmlRootElement(data)
 	if err != nil {
 		return nil, err
 	}
 
-	articles := news[0].Channel.Items
+	switch root {
+	case rssRootElement:
+		var rss types.RSS
+		err = xml.Unmarshal(data, &rss)
+		if err != nil {
+			return nil, err
+		}
 
-	for i := 0; i <= len(articles)-1; i++ {
-		articles[i].Publisher = xp.Source
+		return fromRSSItems(rss.Channel), nil
+	case rdfRootElement:
+		var rdf types.RDF
+		err = xml.Unmarshal(data, &rdf)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromRDFItems(rdf.Items), nil
+	case atomRootElement:
+		var atom types.Atom
+		err = xml.Unmarshal(data, &atom)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromAtomEntries(atom), nil
 	}
 
-	return articles, nil
+	return nil, fmt.Errorf("%w: root element <%s>", ErrUnsupportedXMLFeed, root)
+}
+
+// xmlRootElement returns local name of the first element in XML document
+func xmlRootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		if element, ok := token.(xml.StartElement); ok {
+			return element.Name.Local, nil
+		}
+	}
+}
+
+// fromRSSItems converts items of RSS 2.0 channel into articles.
+//
+// Author falls back to the Dublin Core <dc:creator> element, and items without own language
+// are in the language of the channel.
+func fromRSSItems(channel types.Channel) []types.Article {
+	articles := make([]types.Article, 0, len(channel.Items))
+
+	for _, item := range channel.Items {
+		articles = append(articles, types.Article{
+			Title:       strings.TrimSpace(item.Title),
+			PubDate:     strings.TrimSpace(item.PubDate),
+			Description: strings.TrimSpace(item.Description),
+			Link:        strings.TrimSpace(item.Link),
+			Author:      firstNonEmpty(item.Author, item.Creator),
+			Categories:  trimAll(item.Categories),
+			ImageUrl:    rssItemImage(item),
+			Content:     strings.TrimSpace(item.ContentEncoded),
+			Language:    strings.TrimSpace(channel.Language),
+			Guid:        strings.TrimSpace(item.Guid),
+		})
+	}
+
+	return articles
+}
+
+// rssItemImage returns link to the image of RSS item from its enclosures, Media RSS contents
+// (also grouped ones) or thumbnails, in that order. Empty string is returned, if item has no image.
+func rssItemImage(item types.RSSItem) string {
+	media := append([]types.RSSMedia{}, item.Enclosures...)
+	media = append(media, item.MediaContents...)
+	for _, group := range item.MediaGroups {
+		media = append(media, group.Contents...)
+	}
+
+	for _, m := range media {
+		if m.Medium == imageMedium || strings.HasPrefix(m.Type, imageTypePrefix) {
+			return strings.TrimSpace(m.Url)
+		}
+	}
+
+	// thumbnails are always images
+	for _, thumbnail := range item.Thumbnails {
+		if thumbnail.Url != "" {
+			return strings.TrimSpace(thumbnail.Url)
+		}
+	}
+
+	return ""
+}
+
+// fromRDFItems converts RSS 1.0 items into articles
+func fromRDFItems(items []types.RDFItem) []types.Article {
+	articles := make([]types.Article, 0, len(items))
+
+	for _, item := range items {
+		articles = append(articles, types.Article{
+			Title:       strings.TrimSpace(item.Title),
+			PubDate:     strings.TrimSpace(item.Date),
+			Description: strings.TrimSpace(item.Description),
+			Link:        strings.TrimSpace(item.Link),
+			Author:      strings.TrimSpace(item.Creator),
+			Categories:  trimAll(item.Subjects),
+			Content:     strings.TrimSpace(item.ContentEncoded),
+			Language:    strings.TrimSpace(item.Language),
+		})
+	}
+
+	return articles
+}
+
+// fromAtomEntries converts Atom entries into articles.
+//
+// Publication date falls back to the <updated> element, since <published> is optional in Atom,
+// and description falls back to the <content> element if entry has no <summary>.
+// Entries without own language are in the language of the feed.
+func fromAtomEntries(feed types.Atom) []types.Article {
+	articles := make([]types.Article, 0, len(feed.Entries))
+
+	for _, entry := range feed.Entries {
+		pubDate := entry.Published
+		if pubDate == "" {
+			pubDate = entry.Updated
+		}
+
+		description := entry.Summary
+		if strings.TrimSpace(description) == "" {
+			description = entry.Content
+		}
+
+		articles = append(articles, types.Article{
+			Title:       strings.TrimSpace(entry.Title),
+			PubDate:     strings.TrimSpace(pubDate),
+			Description: strings.TrimSpace(description),
+			Link:        atomEntryLink(entry.Links),
+			Author:      atomEntryAuthor(entry.Authors),
+			Categories:  atomEntryCategories(entry.Categories),
+			ImageUrl:    atomEntryImage(entry.Links),
+			Content:     strings.TrimSpace(entry.Content),
+			Language:    firstNonEmpty(entry.Language, feed.Language),
+			Guid:        strings.TrimSpace(entry.Id),
+		})
+	}
+
+	return articles
+}
+
+// atomEntryLink returns link to the article from the list of Atom entry links.
+// If there is no alternate link, the first one is returned.
+func atomEntryLink(links []types.AtomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == atomAlternateLink {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+
+	return ""
+}
+
+// atomEntryImage returns link to the image of Atom entry, which is an enclosure link with image type
+func atomEntryImage(links []types.AtomLink) string {
+	for _, link := range links {
+		if link.Rel == atomEnclosureLink && strings.HasPrefix(link.Type, imageTypePrefix) {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+
+	return ""
+}
+
+// atomEntryAuthor returns names of Atom entry authors, separated by comma
+func atomEntryAuthor(authors []types.AtomAuthor) string {
+	names := make([]string, 0, len(authors))
+	for _, author := range authors {
+		names = append(names, author.Name)
+	}
+
+	return strings.Join(trimAll(names), ", ")
+}
+
+// atomEntryCategories returns labels of Atom entry categories, or their terms if label is omitted
+func atomEntryCategories(categories []types.AtomCategory) []string {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, firstNonEmpty(category.Label, category.Term))
+	}
+
+	return trimAll(names)
 }
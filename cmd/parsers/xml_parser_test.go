@@ -23,9 +23,6 @@ func TestXmlParser_Parse(t *testing.T) {
 		{
 			name: "Default parse",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				mockXML := `<?xml version="1.0" encoding="UTF-8"?>
 				<rss version="2.0">
 					<channel>
@@ -56,9 +53,6 @@ func TestXmlParser_Parse(t *testing.T) {
 		{
 			name: "HTTP request failure",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewErrorResponder(errors.New("http request failed")))
 			},
@@ -67,47 +61,41 @@ func TestXmlParser_Parse(t *testing.T) {
 		{
 			name: "Empty response body",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(http.StatusOK, ""))
 			},
 			expectError: true,
 		},
 		{
-			name: "Invalid XML format",
+			name: "RSS without XML declaration",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(
 						http.StatusOK,
 						`<rss><channel><item><title>Test</title></item></channel></rss>`))
 			},
-			expectError: true,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:     "Test",
+					Publisher: "abc",
+				},
+			},
 		},
 		{
-			name: "Unexpected XML structure",
+			name: "Channel without items",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(
 						http.StatusOK,
 						`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel><title>Test Channel</title></channel></rss>`))
 			},
-			expectError:  true,
+			expectError:  false,
 			expectedNews: []types.Article{},
 		},
 		{
 			name: "Empty XML document",
 			setupMock: func() {
-				httpmock.Activate()
-				defer httpmock.DeactivateAndReset()
-
 				httpmock.RegisterResponder("GET", sourceToEndpoint[parser.Source],
 					httpmock.NewStringResponder(
 						http.StatusOK,
@@ -120,6 +108,9 @@ func TestXmlParser_Parse(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
+			httpmock.ActivateNonDefault(fetcher.Load().Client)
+			defer httpmock.DeactivateAndReset()
+
 			tt.setupMock()
 			news, err := parser.Parse()
 
@@ -132,3 +123,201 @@ func TestXmlParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseXMLFeed(t *testing.T) {
+	testCases := []struct {
+		name         string
+		data         string
+		expectError  bool
+		expectedNews []types.Article
+	}{
+		{
+			name: "RSS 2.0 feed",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+				<rss version="2.0">
+					<channel>
+						<item>
+							<title>Test Article</title>
+							<description>This is a test news.</description>
+							<pubDate>Tue, 23 Jul 2024 10:00:00 GMT</pubDate>
+							<link>http://example.com/rss</link>
+						</item>
+					</channel>
+				</rss>`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "Test Article",
+					Description: "This is a test news.",
+					PubDate:     "Tue, 23 Jul 2024 10:00:00 GMT",
+					Link:        "http://example.com/rss",
+				},
+			},
+		},
+		{
+			name: "RSS 2.0 feed with optional elements",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+				<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/"
+					xmlns:content="http://purl.org/rss/1.0/modules/content/"
+					xmlns:dc="http://purl.org/dc/elements/1.1/">
+					<channel>
+						<language>en-gb</language>
+						<item>
+							<title>BBC Article</title>
+							<link>http://example.com/bbc</link>
+							<guid isPermaLink="false">bbc-1</guid>
+							<author>news@example.com (John Doe)</author>
+							<category>World</category>
+							<category> Europe </category>
+							<media:thumbnail width="240" height="135" url="http://example.com/bbc.jpg"/>
+						</item>
+						<item>
+							<title>ABC Article</title>
+							<link>http://example.com/abc</link>
+							<dc:creator>Jane Roe</dc:creator>
+							<enclosure url="http://example.com/abc.mp3" type="audio/mpeg"/>
+							<media:group>
+								<media:content url="http://example.com/abc.jpg" medium="image"/>
+							</media:group>
+							<content:encoded><![CDATA[<p>Full story</p>]]></content:encoded>
+						</item>
+					</channel>
+				</rss>`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:      "BBC Article",
+					Link:       "http://example.com/bbc",
+					Author:     "news@example.com (John Doe)",
+					Categories: []string{"World", "Europe"},
+					ImageUrl:   "http://example.com/bbc.jpg",
+					Language:   "en-gb",
+					Guid:       "bbc-1",
+				},
+				{
+					Title:    "ABC Article",
+					Link:     "http://example.com/abc",
+					Author:   "Jane Roe",
+					ImageUrl: "http://example.com/abc.jpg",
+					Content:  "<p>Full story</p>",
+					Language: "en-gb",
+				},
+			},
+		},
+		{
+			name: "RSS 1.0 feed",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+					xmlns:dc="http://purl.org/dc/elements/1.1/"
+					xmlns="http://purl.org/rss/1.0/">
+					<channel><title>Test Channel</title></channel>
+					<item>
+						<title>Test Article</title>
+						<link>http://example.com/rdf</link>
+						<description>This is a test news.</description>
+						<dc:date>2024-07-23T10:00:00Z</dc:date>
+					</item>
+				</rdf:RDF>`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "Test Article",
+					Description: "This is a test news.",
+					PubDate:     "2024-07-23T10:00:00Z",
+					Link:        "http://example.com/rdf",
+				},
+			},
+		},
+		{
+			name: "Atom feed",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+				<feed xmlns="http://www.w3.org/2005/Atom">
+					<title>Test Feed</title>
+					<entry>
+						<title>First Article</title>
+						<link rel="self" href="http://example.com/self"/>
+						<link rel="alternate" href="http://example.com/first"/>
+						<published>2024-07-22T10:00:00Z</published>
+						<updated>2024-07-23T10:00:00Z</updated>
+						<summary>First summary.</summary>
+					</entry>
+					<entry>
+						<title>Second Article</title>
+						<link href="http://example.com/second"/>
+						<updated>2024-07-23T12:00:00Z</updated>
+						<content type="html">Second content.</content>
+					</entry>
+				</feed>`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "First Article",
+					Description: "First summary.",
+					PubDate:     "2024-07-22T10:00:00Z",
+					Link:        "http://example.com/first",
+				},
+				{
+					Title:       "Second Article",
+					Description: "Second content.",
+					PubDate:     "2024-07-23T12:00:00Z",
+					Link:        "http://example.com/second",
+					Content:     "Second content.",
+				},
+			},
+		},
+		{
+			name: "Atom feed with optional elements",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+				<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en">
+					<entry>
+						<id>urn:uuid:1</id>
+						<title>Article</title>
+						<link href="http://example.com/article"/>
+						<link rel="enclosure" type="image/png" href="http://example.com/article.png"/>
+						<author><name>John Doe</name></author>
+						<author><name>Jane Roe</name></author>
+						<category term="world" label="World"/>
+						<category term="europe"/>
+						<content type="html">Full story</content>
+					</entry>
+				</feed>`,
+			expectError: false,
+			expectedNews: []types.Article{
+				{
+					Title:       "Article",
+					Description: "Full story",
+					Link:        "http://example.com/article",
+					Author:      "John Doe, Jane Roe",
+					Categories:  []string{"World", "europe"},
+					ImageUrl:    "http://example.com/article.png",
+					Content:     "Full story",
+					Language:    "en",
+					Guid:        "urn:uuid:1",
+				},
+			},
+		},
+		{
+			name:        "Unsupported root element",
+			data:        `<?xml version="1.0" encoding="UTF-8"?><html><body></body></html>`,
+			expectError: true,
+		},
+		{
+			name:        "Empty document",
+			data:        ``,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			news, err := parseXMLFeed([]byte(tt.data))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNews, news)
+			}
+		})
+	}
+}
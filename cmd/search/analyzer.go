@@ -0,0 +1,154 @@
+package search
+
+import (
+	"gogator/cmd/language"
+	"strings"
+	"unicode"
+)
+
+// minStemLength is the length of the shortest word, which is reduced to its stem.
+// Shorter words are kept as they are, since their suffixes are usually a part of the root.
+const minStemLength = 4
+
+// stopWords are frequent English words, which do not help to find relevant articles.
+// "s" and "t" are left from possessives and contractions, e.g. "Ukraine's" and "don't".
+var stopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "an": true, "and": true, "are": true, "as": true,
+	"at": true, "be": true, "been": true, "but": true, "by": true, "can": true, "for": true, "from": true,
+	"has": true, "have": true, "he": true, "her": true, "his": true, "how": true, "i": true, "if": true,
+	"in": true, "into": true, "is": true, "it": true, "its": true, "more": true, "new": true, "not": true,
+	"of": true, "on": true, "or": true, "out": true, "over": true, "s": true, "says": true, "she": true, "so": true, "t": true,
+	"than": true, "that": true, "the": true, "their": true, "them": true, "they": true, "this": true,
+	"to": true, "up": true, "was": true, "we": true, "were": true, "what": true, "when": true, "which": true,
+	"who": true, "will": true, "with": true, "you": true,
+}
+
+// analyzer holds stop-words and the stemmer of a language
+type analyzer struct {
+	stopWords map[string]bool
+	stem      func(word string) string
+}
+
+// analyzers map ISO 639-1 codes of languages to their analyzers
+var analyzers = map[string]analyzer{
+	language.English:   {stopWords: stopWords, stem: stem},
+	language.Ukrainian: {stopWords: ukrainianStopWords, stem: stemUkrainian},
+}
+
+// Analyze splits text of unknown language into terms: words are lower-cased, stop-words are removed,
+// and the rest of the words are reduced to their stems. Words in Cyrillic are analyzed as Ukrainian ones,
+// and the rest of the words as English ones, so queries match articles of both languages.
+func Analyze(text string) []string {
+	return AnalyzeLanguage(text, "")
+}
+
+// AnalyzeLanguage splits text into terms with stop-words and the stemmer of the language, e.g. "en" or "uk".
+// If the language is not supported, text is analyzed the same way as by Analyze.
+func AnalyzeLanguage(text, lang string) []string {
+	tokens := Tokenize(text, lang)
+
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		terms = append(terms, token.Term)
+	}
+
+	return terms
+}
+
+// Token is a lower-cased word of the text and the term it is reduced to
+type Token struct {
+	Word string
+	Term string
+}
+
+// Tokenize splits text into words, which are not stop-words, like AnalyzeLanguage does,
+// keeping every word together with its term
+func Tokenize(text, lang string) []Token {
+	a, supported := analyzers[language.Base(lang)]
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	tokens := make([]Token, 0, len(words))
+	for _, word := range words {
+		wordAnalyzer := a
+		if !supported {
+			wordAnalyzer = analyzerOfScript(word)
+		}
+
+		if wordAnalyzer.stopWords[word] {
+			continue
+		}
+
+		tokens = append(tokens, Token{Word: word, Term: wordAnalyzer.stem(word)})
+	}
+
+	return tokens
+}
+
+// analyzerOfScript returns Ukrainian analyzer for words in Cyrillic, and English analyzer for the rest of the words
+func analyzerOfScript(word string) analyzer {
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return analyzers[language.Ukrainian]
+		}
+	}
+
+	return analyzers[language.English]
+}
+
+// stem removes common English inflectional suffixes from the word:
+// plurals ("elections" -> "election", "policies" -> "policy"), past tense ("voted" -> "vot"),
+// gerunds ("running" -> "run") and adverbs ("quickly" -> "quick").
+//
+// It is a light stemmer: the stem is not always a dictionary word, but different forms of the word
+// are reduced to the same stem, which is enough for matching.
+func stem(word string) string {
+	if len([]rune(word)) < minStemLength {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		word = strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "zes"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	for _, suffix := range []string{"ing", "ed", "ly"} {
+		root := strings.TrimSuffix(word, suffix)
+		if root == word || len([]rune(root)) < minStemLength-1 || !hasVowel(root) {
+			continue
+		}
+
+		return undouble(root)
+	}
+
+	return word
+}
+
+// hasVowel reports whether the word contains a vowel, so it is not just a suffix-like ending
+func hasVowel(word string) bool {
+	return strings.ContainsAny(word, "aeiouy")
+}
+
+// undouble removes the last letter of doubled consonant endings, left after removing suffixes:
+// "runn" -> "run", "stopp" -> "stop". Endings "ll", "ss" and "zz" are kept ("fall", "pass").
+func undouble(word string) string {
+	runes := []rune(word)
+	n := len(runes)
+	if n < 2 || runes[n-1] != runes[n-2] {
+		return word
+	}
+
+	if strings.ContainsRune("aeiouylsz", runes[n-1]) {
+		return word
+	}
+
+	return string(runes[:n-1])
+}
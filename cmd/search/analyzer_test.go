@@ -0,0 +1,88 @@
+package search
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Lower-cases and splits on punctuation",
+			text:     "Ukraine's cities, Russia-backed",
+			expected: []string{"ukraine", "city", "russia", "back"},
+		},
+		{
+			name:     "Removes stop-words",
+			text:     "The election of the year",
+			expected: []string{"election", "year"},
+		},
+		{
+			name:     "Keeps numbers",
+			text:     "Euro 2024 results",
+			expected: []string{"euro", "2024", "result"},
+		},
+		{
+			name:     "Empty text",
+			text:     "",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Analyze(tt.text))
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	testCases := []struct {
+		words []string
+		stem  string
+	}{
+		{words: []string{"election", "elections"}, stem: "election"},
+		{words: []string{"policy", "policies"}, stem: "policy"},
+		{words: []string{"run", "running"}, stem: "run"},
+		{words: []string{"vote", "votes"}, stem: "vote"},
+		{words: []string{"voted", "voting"}, stem: "vot"},
+		{words: []string{"crash", "crashes"}, stem: "crash"},
+		{words: []string{"quick", "quickly"}, stem: "quick"},
+		{words: []string{"bus"}, stem: "bus"},
+		{words: []string{"class"}, stem: "class"},
+	}
+
+	for _, tt := range testCases {
+		for _, word := range tt.words {
+			assert.Equal(t, tt.stem, stem(word), word)
+		}
+	}
+}
+
+func TestAnalyzeLanguage(t *testing.T) {
+	assert.Equal(t, []string{"вибор", "президент"}, AnalyzeLanguage("Вибори президента", "uk"))
+	assert.Equal(t, []string{"election", "president"}, AnalyzeLanguage("Elections of the president", "en-GB"))
+	assert.Equal(t, []string{"вибор", "election"}, AnalyzeLanguage("Виборів і elections", ""))
+}
+
+func TestStemUkrainian(t *testing.T) {
+	testCases := []struct {
+		words []string
+		stem  string
+	}{
+		{words: []string{"вибори", "виборів", "виборах", "виборами"}, stem: "вибор"},
+		{words: []string{"бюджет", "бюджету", "бюджетом"}, stem: "бюджет"},
+		{words: []string{"зібралися", "зібрали"}, stem: "зібра"},
+		{words: []string{"мир"}, stem: "мир"},
+	}
+
+	for _, tt := range testCases {
+		for _, word := range tt.words {
+			assert.Equal(t, tt.stem, stemUkrainian(word), word)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package search provides full-text search over articles.
+//
+// Titles, descriptions and contents of articles are split into terms by the analyzer: text is tokenized,
+// lower-cased, stop-words are removed, and words are reduced to their stems, so "Elections" matches "election".
+// English and Ukrainian have their own stop-words and stemmers, which are chosen by the language of the article.
+// Terms are kept in an inverted index, which ranks articles by the query with Okapi BM25.
+package search
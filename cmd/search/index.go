@@ -0,0 +1,177 @@
+package search
+
+import (
+	"gogator/cmd/types"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// k1 controls saturation of term frequency: repeating the term increases the score less and less
+	k1 = 1.2
+
+	// b controls normalization by document length: terms in short articles weigh more
+	b = 0.75
+
+	// titleBoost is how many times terms of the title are counted, since the title describes the article best
+	titleBoost = 2
+)
+
+// Index is an inverted index of articles, which ranks them with BM25.
+//
+// Index is safe for concurrent use.
+type Index struct {
+	mu sync.RWMutex
+
+	// termToDocs maps terms to IDs of articles, which contain them, and amount of occurrences
+	termToDocs map[string]map[string]int
+
+	// docToTerms maps IDs of articles to their terms, so the article can be removed from termToDocs
+	docToTerms map[string]map[string]int
+
+	// docLengths maps IDs of articles to amount of their terms
+	docLengths map[string]int
+
+	// totalLength is the sum of all docLengths
+	totalLength int
+}
+
+// NewIndex creates an empty Index
+func NewIndex() *Index {
+	return &Index{
+		termToDocs: make(map[string]map[string]int),
+		docToTerms: make(map[string]map[string]int),
+		docLengths: make(map[string]int),
+	}
+}
+
+// Add indexes title, description and content of articles with the analyzer of their language.
+// Articles should have IDs.
+// If article with the same ID is already indexed, it is replaced.
+func (idx *Index) Add(articles ...types.Article) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, article := range articles {
+		idx.remove(article.ID)
+
+		terms := make(map[string]int)
+		length := 0
+		for _, term := range AnalyzeLanguage(article.Title, article.Language) {
+			terms[term] += titleBoost
+			length += titleBoost
+		}
+		for _, term := range AnalyzeLanguage(article.Description, article.Language) {
+			terms[term]++
+			length++
+		}
+		for _, term := range AnalyzeLanguage(article.Content, article.Language) {
+			terms[term]++
+			length++
+		}
+
+		for term, frequency := range terms {
+			if idx.termToDocs[term] == nil {
+				idx.termToDocs[term] = make(map[string]int)
+			}
+			idx.termToDocs[term][article.ID] = frequency
+		}
+
+		idx.docToTerms[article.ID] = terms
+		idx.docLengths[article.ID] = length
+		idx.totalLength += length
+	}
+}
+
+// Remove removes articles with the given IDs from the index
+func (idx *Index) Remove(ids ...string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, id := range ids {
+		idx.remove(id)
+	}
+}
+
+// Len returns amount of indexed articles
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docLengths)
+}
+
+// Score returns BM25 scores of articles, which contain at least one term of the query, by their IDs.
+// Articles without query terms are not included.
+func (idx *Index) Score(query string) map[string]float64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	scores := make(map[string]float64)
+
+	total := float64(len(idx.docLengths))
+	if total == 0 {
+		return scores
+	}
+	avgLength := float64(idx.totalLength) / total
+
+	seen := make(map[string]bool)
+	for _, term := range Analyze(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		docs := idx.termToDocs[term]
+		if len(docs) == 0 {
+			continue
+		}
+
+		matched := float64(len(docs))
+		idf := math.Log(1 + (total-matched+0.5)/(matched+0.5))
+
+		for id, frequency := range docs {
+			tf := float64(frequency)
+			norm := 1 - b + b*float64(idx.docLengths[id])/avgLength
+			scores[id] += idf * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+
+	return scores
+}
+
+// remove removes the article from the index. Caller should hold the write lock.
+func (idx *Index) remove(id string) {
+	terms, exists := idx.docToTerms[id]
+	if !exists {
+		return
+	}
+
+	for term := range terms {
+		delete(idx.termToDocs[term], id)
+		if len(idx.termToDocs[term]) == 0 {
+			delete(idx.termToDocs, term)
+		}
+	}
+
+	idx.totalLength -= idx.docLengths[id]
+	delete(idx.docToTerms, id)
+	delete(idx.docLengths, id)
+}
+
+// Rank orders articles from the most relevant one, and sets score of every article.
+// Articles without scores get zero score. Articles with equal scores keep their order.
+func Rank(articles []types.Article, scores map[string]float64) []types.Article {
+	ranked := make([]types.Article, len(articles))
+	for i, article := range articles {
+		article.Score = scores[article.ID]
+		ranked[i] = article
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	return ranked
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+)
+
+var indexedArticles = []types.Article{
+	{
+		ID:          "1",
+		Title:       "Elections in the UK",
+		Description: "Voters go to the polls",
+	},
+	{
+		ID:          "2",
+		Title:       "Weather forecast",
+		Description: "Rain expected on the day of the election",
+	},
+	{
+		ID:          "3",
+		Title:       "Football results",
+		Description: "Scores of the weekend",
+	},
+}
+
+func TestIndex_Score(t *testing.T) {
+	index := NewIndex()
+	index.Add(indexedArticles...)
+	assert.Equal(t, 3, index.Len())
+
+	scores := index.Score("election")
+	assert.Len(t, scores, 2)
+	assert.Greater(t, scores["1"], scores["2"], "match in the title should weigh more")
+
+	assert.Empty(t, index.Score("the"))
+	assert.Empty(t, index.Score("basketball"))
+}
+
+func TestIndex_ScoreUkrainian(t *testing.T) {
+	index := NewIndex()
+	index.Add(types.Article{ID: "1", Title: "Рада призначила дату виборів", Language: "uk"})
+
+	assert.Len(t, index.Score("вибори"), 1)
+	assert.Empty(t, index.Score("про"))
+}
+
+func TestIndex_AddReplaces(t *testing.T) {
+	index := NewIndex()
+	index.Add(indexedArticles...)
+	index.Add(types.Article{ID: "1", Title: "Budget plans"})
+
+	assert.Equal(t, 3, index.Len())
+	assert.NotContains(t, index.Score("elections"), "1")
+	assert.Contains(t, index.Score("budget"), "1")
+}
+
+func TestIndex_Remove(t *testing.T) {
+	index := NewIndex()
+	index.Add(indexedArticles...)
+	index.Remove("1", "unknown")
+
+	assert.Equal(t, 2, index.Len())
+	assert.Equal(t, []string{"2"}, keys(index.Score("elections")))
+}
+
+func TestRank(t *testing.T) {
+	index := NewIndex()
+	index.Add(indexedArticles...)
+
+	ranked := Rank(indexedArticles, index.Score("football"))
+	assert.Len(t, ranked, 3)
+	assert.Equal(t, "3", ranked[0].ID)
+	assert.Greater(t, ranked[0].Score, 0.0)
+
+	// articles without scores keep their order
+	assert.Equal(t, "1", ranked[1].ID)
+	assert.Equal(t, "2", ranked[2].ID)
+	assert.Zero(t, ranked[2].Score)
+}
+
+func keys(scores map[string]float64) []string {
+	var ids []string
+	for id := range scores {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
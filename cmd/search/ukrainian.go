@@ -0,0 +1,50 @@
+package search
+
+import (
+	"strings"
+)
+
+// minUkrainianStemLength is the length of the shortest stem, left after removing the ending of Ukrainian word
+const minUkrainianStemLength = 3
+
+// ukrainianStopWords are frequent Ukrainian words, which do not help to find relevant articles
+var ukrainianStopWords = map[string]bool{
+	"а": true, "але": true, "би": true, "був": true, "була": true, "були": true, "було": true, "в": true,
+	"вже": true, "від": true, "він": true, "вона": true, "вони": true, "де": true, "для": true, "до": true,
+	"з": true, "за": true, "і": true, "із": true, "й": true, "її": true, "їх": true, "коли": true,
+	"на": true, "не": true, "ні": true, "по": true, "про": true, "та": true, "так": true, "також": true,
+	"те": true, "ти": true, "то": true, "у": true, "це": true, "цей": true, "що": true, "щоб": true,
+	"як": true, "який": true, "яка": true, "які": true, "я": true,
+}
+
+// ukrainianEndings are inflectional endings of Ukrainian nouns, adjectives and verbs.
+// Longer endings go first, so "виборами" loses "ами" rather than "и".
+var ukrainianEndings = []string{
+	"ими", "ого", "ому", "ями", "ами", "ові", "еві", "ють", "ать", "ять",
+	"ів", "їв", "ій", "ий", "ої", "их", "ім", "ям", "ах", "ях", "ом", "ем", "ою", "ею", "ти", "ть",
+	"ла", "ли", "ло",
+	"а", "я", "о", "е", "и", "і", "ї", "у", "ю", "ь", "й",
+}
+
+// stemUkrainian removes the reflexive suffix and the inflectional ending of Ukrainian word:
+// "вибори", "виборів" and "виборах" -> "вибор", "зібралися" -> "зібра".
+//
+// Like the English stemmer, it is a light one: stems are not dictionary words,
+// but different forms of the word are mostly reduced to the same stem.
+func stemUkrainian(word string) string {
+	for _, suffix := range []string{"ся", "сь"} {
+		if root := strings.TrimSuffix(word, suffix); root != word && len([]rune(root)) > minUkrainianStemLength {
+			word = root
+			break
+		}
+	}
+
+	for _, ending := range ukrainianEndings {
+		root := strings.TrimSuffix(word, ending)
+		if root != word && len([]rune(root)) >= minUkrainianStemLength {
+			return root
+		}
+	}
+
+	return word
+}
@@ -2,16 +2,34 @@ package server
 
 import (
 	"github.com/gin-gonic/gin"
+	"gogator/cmd/auth"
+	"gogator/cmd/metrics"
 	"gogator/cmd/server/handlers"
 )
 
-// setupRoutes attaches routes to *gin.Engine
-func setupRoutes(r *gin.Engine) {
-	r.GET("/news", handlers.GetNews)
+// setupRoutes attaches routes to *gin.Engine.
+// Latency of every request is measured. Probes and metrics are public, news and trends require reader role, and admin routes require admin role.
+// Only if authentication is disabled explicitly, every route is public. Nil authenticator rejects every request.
+// adminMiddleware are applied to admin routes after the role is checked, e.g. verification of client certificates.
+func setupRoutes(r *gin.Engine, authenticator *auth.Authenticator, adminMiddleware ...gin.HandlerFunc) {
+	r.Use(metrics.Middleware())
 
-	r.GET("/admin/sources", handlers.GetSources)
-	r.GET("/admin/sources/:source", handlers.GetSourceDetailed)
-	r.POST("/admin/sources", handlers.RegisterSource)
-	r.PUT("/admin/sources", handlers.UpdateSource)
-	r.DELETE("/admin/sources", handlers.DeleteSource)
+	r.GET("/healthz", handlers.GetHealth)
+	r.GET("/readyz", handlers.GetReadiness)
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	reader := r.Group("/", authenticator.Require(auth.ReaderRole))
+	reader.GET("/news", handlers.GetNews)
+	reader.GET("/trends", handlers.GetTrends)
+
+	admin := r.Group("/admin", authenticator.Require(auth.AdminRole))
+	admin.Use(adminMiddleware...)
+	admin.GET("/sources", handlers.GetSources)
+	admin.GET("/sources/:source", handlers.GetSourceDetailed)
+	admin.POST("/sources", handlers.RegisterSource)
+	admin.PUT("/sources", handlers.UpdateSource)
+	admin.DELETE("/sources", handlers.DeleteSource)
+
+	admin.GET("/fetch-report", handlers.GetFetchReport)
+	admin.GET("/stats", handlers.GetStats)
 }
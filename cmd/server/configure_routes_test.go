@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/auth"
+	"gogator/cmd/server/handlers"
+	"gogator/cmd/storage"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -18,21 +21,84 @@ func TestSetupRoutes(t *testing.T) {
 	}{
 		{"GET /news", "GET", "/non-existent", http.StatusNotFound},
 		{"GET /admin/sources", "GET", "/admin/sources", http.StatusOK},
-		{"PUT /admin/sources", "PUT", "/admin/sources", http.StatusOK},
-		{"POST /admin/sources", "POST", "/admin/sources", http.StatusOK},
-		{"DELETE /admin/sources", "DELETE", "/admin/sources", http.StatusOK},
+		{"PUT /admin/sources", "PUT", "/admin/sources", http.StatusBadRequest},
+		{"POST /admin/sources", "POST", "/admin/sources", http.StatusInternalServerError},
+		{"DELETE /admin/sources", "DELETE", "/admin/sources", http.StatusInternalServerError},
 		{"GET /news", "GET", "/news", http.StatusOK},
-		{"POST /news", "GET", "/news", http.StatusNotFound},
-		{"DELETE /news", "GET", "/news", http.StatusNotFound},
+		{"GET /trends", "GET", "/trends", http.StatusOK},
+		{"POST /news", "POST", "/news", http.StatusNotFound},
+		{"DELETE /news", "DELETE", "/news", http.StatusNotFound},
 	}
 
+	store := handlers.Store
+	defer func() {
+		handlers.Store = store
+	}()
+	handlers.Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	authenticator, err := auth.New(auth.Config{Disabled: true})
+	assert.Nil(t, err)
+
 	gin.SetMode(gin.TestMode)
 	server := gin.Default()
-	setupRoutes(server)
+	setupRoutes(server, authenticator)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBuffer([]byte{}))
+
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.statusCode, resp.Code)
+		})
+	}
+}
+
+func TestSetupRoutes_Auth(t *testing.T) {
+	authenticator, err := auth.New(auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "operator", Role: auth.AdminRole, Key: "admin-key"},
+			{Name: "dashboard", Role: auth.ReaderRole, Key: "reader-key"},
+		},
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		key        string
+		statusCode int
+	}{
+		{"GET /news without key", "GET", "/news", "", http.StatusUnauthorized},
+		{"GET /news with reader key", "GET", "/news", "reader-key", http.StatusOK},
+		{"GET /trends with reader key", "GET", "/trends", "reader-key", http.StatusOK},
+		{"DELETE /admin/sources without key", "DELETE", "/admin/sources", "", http.StatusUnauthorized},
+		{"DELETE /admin/sources with reader key", "DELETE", "/admin/sources", "reader-key", http.StatusForbidden},
+		{"POST /admin/sources with reader key", "POST", "/admin/sources", "reader-key", http.StatusForbidden},
+		{"GET /admin/stats with reader key", "GET", "/admin/stats", "reader-key", http.StatusForbidden},
+		{"GET /admin/sources with admin key", "GET", "/admin/sources", "admin-key", http.StatusOK},
+		{"GET /healthz without key", "GET", "/healthz", "", http.StatusOK},
+		{"GET /metrics without key", "GET", "/metrics", "", http.StatusOK},
+	}
+
+	store := handlers.Store
+	defer func() {
+		handlers.Store = store
+	}()
+	handlers.Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	setupRoutes(server, authenticator)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBuffer([]byte{}))
+			if tt.key != "" {
+				req.Header.Set(auth.APIKeyHeader, tt.key)
+			}
 
 			resp := httptest.NewRecorder()
 			server.ServeHTTP(resp, req)
@@ -1,4 +1,5 @@
-// Package server is used for initialization, configuration, and execution of HTTPs server for the application.
+// Package server is used for initialization, configuration, and execution of the server for the application.
+// The server listens over HTTPS (with reloadable or self-signed certificates), plain HTTP or h2c.
 //
 // This package could be used just by running RunAndConf function, which initializes server,
 // attaches paths and handlers to it, and runs a server on the user-defined or default port.
@@ -8,6 +9,7 @@
 // The server is using the following paths:
 // - /admin/sources - to manage sources
 // - /news - to retrieve latest news
+// - /trends - to retrieve terms, which are trending in the latest news
 //
 // DEPRECATED:
 // FetchNewsJob was used to fetch and parse articles feeds, and then writes the parsed data to a
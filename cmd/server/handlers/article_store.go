@@ -0,0 +1,7 @@
+package handlers
+
+import "gogator/cmd/storage"
+
+// Store is used by handlers to retrieve, search and remove articles.
+// It is initialized by the server on start, using the backend selected with flags.
+var Store storage.SearchableStore
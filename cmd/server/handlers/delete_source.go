@@ -16,8 +16,8 @@ const (
 	MsgSourceDeleted = "Feed was successfully removed."
 )
 
-// DeleteSource handler deletes existing source from registered sources.
-// If non-existent source is going to be deleted - throws an error.
+// DeleteSource handler deletes existing source from registered sources, and removes its stored articles.
+// If non-existent source is going to be deleted - responds with 404 Not Found.
 func DeleteSource(c *gin.Context) {
 	var reqBody types.Feed
 
@@ -30,10 +30,10 @@ func DeleteSource(c *gin.Context) {
 	}
 
 	if !sourceInArray(reqBody.Name) {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(http.StatusNotFound, gin.H{
 			"error": ErrSourceNotFound,
 		})
-		log.Println(ErrDeleteSource + err.Error())
+		log.Println(ErrDeleteSource + ErrSourceNotFound)
 		return
 	}
 
@@ -46,18 +46,9 @@ func DeleteSource(c *gin.Context) {
 		return
 	}
 
-	dates, err := parsers.GenerateDateRange(FirstFetchedFileDate, LastFetchedFileDate)
+	_, err = Store.DeleteBySource(reqBody.Name)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": ErrDeleteSource + err.Error(),
-		})
-		log.Println(ErrDeleteSource + err.Error())
-		return
-	}
-
-	err = parsers.DestroySource(reqBody.Name, dates)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": ErrDeleteSource + err.Error(),
 		})
 		log.Println(ErrDeleteSource + err.Error())
@@ -6,14 +6,37 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 )
 
+// TestMain runs tests against a copy of the sources file, because tests add, update and delete sources
 func TestMain(m *testing.M) {
-	parsers.StoragePath = filepath.Join("..", "..", "parsers", "data")
+	data, err := os.ReadFile(filepath.Join("..", "..", "parsers", "data", "sources.json"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	dir, err := os.MkdirTemp(".", "storage-")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "sources.json"), data, 0644)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	parsers.StoragePath = dir
+	Store = storage.NewIndexedStore(storage.NewJsonStore(parsers.StoragePath))
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
 }
 
 func TestDeleteSource(t *testing.T) {
@@ -59,7 +82,7 @@ func TestDeleteSource(t *testing.T) {
 				}
 			},
 			finish:     func() {},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusNotFound,
 			response: gin.H{
 				"error": ErrSourceNotFound,
 			},
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gogator/cmd/parsers"
+	"gogator/cmd/types"
+	"log"
+	"net/http"
+	"os"
+)
+
+const (
+	// ErrFetchReport is thrown when server fails to read results of the last news fetching
+	ErrFetchReport = "Failed to read fetch report: "
+)
+
+// GetFetchReport returns per-source results of the last news fetching: amount of articles,
+// duration and error of every source.
+// If news were not fetched yet, an empty list is returned.
+func GetFetchReport(c *gin.Context) {
+	results, err := parsers.ReadFetchReport()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": ErrFetchReport + err.Error(),
+		})
+		log.Println(ErrFetchReport + err.Error())
+		return
+	}
+
+	if results == nil {
+		results = []types.FetchResult{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+	})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/parsers"
+	"gogator/cmd/types"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetFetchReport(t *testing.T) {
+	server := gin.Default()
+	server.GET("/admin/fetch-report", GetFetchReport)
+
+	storagePath := parsers.StoragePath
+	tempDir, err := os.MkdirTemp(".", "fetch_report")
+	assert.Nil(t, err)
+	defer func() {
+		parsers.StoragePath = storagePath
+		assert.Nil(t, os.RemoveAll(tempDir))
+	}()
+
+	tests := []struct {
+		name            string
+		setup           func()
+		statusCode      int
+		expectedResults int
+	}{
+		{
+			name:            "News were not fetched yet",
+			setup:           func() {},
+			statusCode:      http.StatusOK,
+			expectedResults: 0,
+		},
+		{
+			name: "Report of the last fetching",
+			setup: func() {
+				err := parsers.WriteFetchReport(tempDir, []types.FetchResult{
+					types.NewFetchResult("abc", 10, time.Second, nil),
+					types.NewFetchResult("bbc", 0, time.Second, errors.New("connection refused")),
+				})
+				assert.Nil(t, err)
+			},
+			statusCode:      http.StatusOK,
+			expectedResults: 2,
+		},
+	}
+
+	parsers.StoragePath = tempDir
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			req, _ := http.NewRequest(http.MethodGet, "/admin/fetch-report", nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			var response struct {
+				Results []types.FetchResult `json:"results"`
+			}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Results, tt.expectedResults)
+		})
+	}
+}
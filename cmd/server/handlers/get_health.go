@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetHealth is the liveness probe. It responds with 200 OK, while the server is able to handle requests,
+// regardless of its dependencies, so the process is not restarted because of temporary failures of storage.
+func GetHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": OkStatus})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetHealth(t *testing.T) {
+	server := gin.Default()
+	server.GET("/healthz", GetHealth)
+	defer SetShuttingDown(false)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/healthz", nil)
+
+	for _, shuttingDown := range []bool{false, true} {
+		SetShuttingDown(shuttingDown)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+	}
+}
@@ -2,27 +2,20 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"gogator/cmd/cluster"
+	"gogator/cmd/dedup"
 	"gogator/cmd/filters"
-	"gogator/cmd/parsers"
+	"gogator/cmd/metrics"
+	"gogator/cmd/paging"
+	"gogator/cmd/sorting"
 	"gogator/cmd/types"
 	"gogator/cmd/validator"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 )
 
-var (
-	// LastFetchedFileDate will be used for iterating over files with news
-	//
-	// It is assigned date of launching the server and will be often updated
-	LastFetchedFileDate = time.Now().Format(time.DateOnly)
-
-	// FirstFetchedFileDate identifies first file which contains news
-	//
-	// It is assigned date of launching the server, and won't be updated.
-	FirstFetchedFileDate = time.Now().Format(time.DateOnly)
-)
-
 const (
 	// KeywordFlag will be used to get the keywords (or empty string) from URL parameter
 	KeywordFlag = "keywords"
@@ -36,22 +29,123 @@ const (
 	// SourcesFlag will be used to get the sources (or empty string) from URL parameter
 	SourcesFlag = "sources"
 
-	// ErrFailedParsing is thrown when program fails to parse sources
-	ErrFailedParsing = "error while parsing sources: "
+	// CollapseFlag will be used to get the collapse option (or empty string) from URL parameter
+	CollapseFlag = "collapse"
+
+	// TimezoneFlag will be used to get the time zone of dates (or empty string) from URL parameter
+	TimezoneFlag = "tz"
+
+	// MatchFlag will be used to get the match mode of keywords (or empty string) from URL parameter
+	MatchFlag = "match"
+
+	// SortFlag will be used to get the order of articles (or empty string) from URL parameter
+	SortFlag = "sort"
+
+	// LimitFlag will be used to get the amount of articles on a page (or empty string) from URL parameter
+	LimitFlag = "limit"
+
+	// OffsetFlag will be used to get the position of the first article on a page (or empty string) from URL parameter
+	OffsetFlag = "offset"
+
+	// CursorFlag will be used to get the cursor of the page (or empty string) from URL parameter
+	CursorFlag = "cursor"
+
+	// CategoryFlag will be used to get the categories of articles (or empty string) from URL parameter
+	CategoryFlag = "category"
+
+	// AuthorFlag will be used to get the authors of articles (or empty string) from URL parameter
+	AuthorFlag = "author"
+
+	// LanguageFlag will be used to get the languages of articles (or empty string) from URL parameter
+	LanguageFlag = "lang"
+
+	// GroupFlag will be used to get the grouping of articles (or empty string) from URL parameter
+	GroupFlag = "group"
+
+	// StoryGroup groups articles of different publishers about the same story
+	StoryGroup = "story"
+
+	// ErrFailedParsing is thrown when program fails to retrieve stored news
+	ErrFailedParsing = "error while retrieving news: "
 
 	//
 	ErrValidatingParams = "Error validating parameters: "
+
+	// ErrInvalidCollapse is thrown when collapse parameter is not a boolean
+	ErrInvalidCollapse = "collapse should be true or false"
+
+	// ErrInvalidGroup is thrown when group parameter is not a supported grouping
+	ErrInvalidGroup = "group should be story or empty"
+
+	// ErrCursorWithGroup is thrown when grouped articles are requested with a cursor
+	ErrCursorWithGroup = "cursor can not be used with group, use offset instead"
 )
 
-// GetNews handler will be used in our server to retrieve news from prepared files
+// GetNews handler will be used in our server to retrieve stored news, filtered by parameters.
+// If date range is not specified, news of all stored days are returned.
+// Dates can be days, RFC 3339 timestamps or relative dates like -6h, today or 7d.
+// Days and times without offset are interpreted in the time zone of tz parameter, or in UTC.
+//
+// Articles can be filtered by comma separated categories (category parameter) and authors (author parameter),
+// both ignoring case, and by comma separated ISO 639-1 codes of their languages (lang parameter), e.g. en,uk.
+//
+// Keywords are matched according to the match parameter: case-insensitive (default), exact, whole-word or regex.
+// Articles are ordered by sort parameter: date_desc (default), date_asc, source or relevance.
+// If sort parameter is relevance, only articles matching the keywords are returned, ordered by their score,
+// which is returned for every article.
+//
+// Articles are returned by pages of limit articles, starting at offset, or after the cursor of the previous page.
+// If there are more articles, the response contains the cursor and the link of the next page,
+// which is also returned in Link header. Total amount of matching articles is returned as totalAmount.
+//
+// Every article is returned once. If collapse parameter is true, near-identical articles of different
+// publishers are returned as a single article with a list of alternate sources.
+//
+// If group parameter is story, articles about the same story are clustered, and stories are returned
+// instead of articles: every story has a representative headline, its articles and publishers.
+// Stories are paginated with limit and offset parameters, and totalAmount is the amount of stories.
 func GetNews(c *gin.Context) {
 	keywords := c.Query(KeywordFlag)
 	sources := c.Query(SourcesFlag)
 	dateFrom := c.Query(DateFromFlag)
 	dateEnd := c.Query(DateEndFlag)
+	sort := c.Query(SortFlag)
+	matchMode := c.Query(MatchFlag)
+	timezone := c.Query(TimezoneFlag)
+	lang := c.Query(LanguageFlag)
+
+	collapse := false
+	if value := c.Query(CollapseFlag); value != "" {
+		var err error
+		collapse, err = strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": ErrValidatingParams + ErrInvalidCollapse,
+			})
+			log.Println(ErrValidatingParams + ErrInvalidCollapse)
+			return
+		}
+	}
+
+	group := c.Query(GroupFlag)
+	if group != "" && group != StoryGroup {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + ErrInvalidGroup,
+		})
+		log.Println(ErrValidatingParams + ErrInvalidGroup)
+		return
+	}
+
+	if group != "" && c.Query(CursorFlag) != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + ErrCursorWithGroup,
+		})
+		log.Println(ErrValidatingParams + ErrCursorWithGroup)
+		return
+	}
 
 	v := &validator.ArgValidator{}
-	err := v.Validate(sources, dateFrom, dateEnd)
+	err := v.Validate(keywords, matchMode, sources, dateFrom, dateEnd, timezone)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": ErrValidatingParams + err.Error(),
@@ -60,17 +154,57 @@ func GetNews(c *gin.Context) {
 		return
 	}
 
-	params := types.NewFilteringParams(keywords, dateFrom, dateEnd, sources)
+	err = validator.BySort(sort, keywords)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
+	}
 
-	var news []types.Article
-	if dateFrom == "" {
-		dateFrom = FirstFetchedFileDate
+	err = validator.ByLanguage(lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
+	}
+
+	pageRequest, err := paging.ParseRequest(c.Query(LimitFlag), c.Query(OffsetFlag), c.Query(CursorFlag), sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
 	}
-	if dateEnd == "" {
-		dateEnd = LastFetchedFileDate
+
+	params := types.NewFilteringParams(keywords, dateFrom, dateEnd, sources)
+	params.Sort = sort
+	params.MatchMode = matchMode
+	params.Timezone = timezone
+	params.Categories = c.Query(CategoryFlag)
+	params.Authors = c.Query(AuthorFlag)
+	params.Language = lang
+
+	// relative dates are resolved once, so all articles are filtered by the same range
+	err = filters.NormalizeDateRange(params, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
 	}
 
-	news, err = parsers.FromFiles(dateFrom, dateEnd)
+	var news []types.Article
+	if params.Sort == types.RelevanceSort {
+		news, err = Store.Search(params)
+	} else {
+		news, err = Store.Query(params)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": ErrFailedParsing + err.Error(),
@@ -79,10 +213,72 @@ func GetNews(c *gin.Context) {
 		return
 	}
 
-	news = filters.Apply(news, params)
+	sorting.Sort(news, params.Sort)
+	if collapse {
+		news = dedup.Collapse(news)
+	}
+
+	if group == StoryGroup {
+		c.JSON(http.StatusOK, storiesPage(c, cluster.Group(news), len(news), pageRequest))
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	page := paging.Paginate(news, pageRequest, params.Sort)
+	metrics.ObserveArticles(c.FullPath(), len(page.Articles))
+	response := gin.H{
 		"totalAmount": len(news),
-		"news":        news,
-	})
+		"offset":      page.Offset,
+		"limit":       pageRequest.Limit,
+		"news":        page.Articles,
+	}
+
+	if page.Next != nil {
+		next := nextPageLink(c, page.Next)
+		c.Header("Link", "<"+next+">; rel=\"next\"")
+		response["nextCursor"] = page.Next.Encode()
+		response["next"] = next
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// storiesPage returns the requested page of stories. If there are more stories,
+// the response contains the link of the next page, which is also returned in Link header.
+func storiesPage(c *gin.Context, stories []cluster.Story, articles int, request paging.Request) gin.H {
+	start := min(request.Offset, len(stories))
+	end := min(start+request.Limit, len(stories))
+
+	returned := 0
+	for _, story := range stories[start:end] {
+		returned += story.Size
+	}
+	metrics.ObserveArticles(c.FullPath(), returned)
+
+	response := gin.H{
+		"totalAmount":   len(stories),
+		"totalArticles": articles,
+		"offset":        start,
+		"limit":         request.Limit,
+		"stories":       stories[start:end],
+	}
+
+	if end < len(stories) {
+		query := c.Request.URL.Query()
+		query.Set(OffsetFlag, strconv.Itoa(end))
+		next := c.Request.URL.Path + "?" + query.Encode()
+
+		c.Header("Link", "<"+next+">; rel=\"next\"")
+		response["next"] = next
+	}
+
+	return response
+}
+
+// nextPageLink returns the link of the next page: the same request with the cursor instead of the offset
+func nextPageLink(c *gin.Context, cursor *paging.Cursor) string {
+	query := c.Request.URL.Query()
+	query.Del(OffsetFlag)
+	query.Set(CursorFlag, cursor.Encode())
+
+	return c.Request.URL.Path + "?" + query.Encode()
 }
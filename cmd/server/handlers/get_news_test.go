@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/cluster"
+	"gogator/cmd/paging"
 	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
 	"gogator/cmd/types"
+	"gogator/cmd/validator"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -38,7 +43,7 @@ func TestGetNews(t *testing.T) {
 			input: &types.FilteringParams{
 				Sources: "source-7",
 			},
-			statusCode: http.StatusOK,
+			statusCode: http.StatusBadRequest,
 		},
 		{
 			name: "Failed request with wrong date range",
@@ -78,7 +83,7 @@ func TestGetNews(t *testing.T) {
 			}
 
 			w := httptest.NewRecorder()
-			go server.ServeHTTP(w, req)
+			server.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.statusCode, w.Code)
 
@@ -108,3 +113,406 @@ func TestSourceInArray(t *testing.T) {
 		assert.Equal(t, test.expected, result, "Expected %v for source %s, got %v", test.expected, test.source, result)
 	}
 }
+
+func TestGetNews_InvalidCollapse(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news?collapse=maybe", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidCollapse)
+}
+
+func TestGetNews_InvalidGroup(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	testCases := []struct {
+		query string
+		error string
+	}{
+		{query: "?group=publisher", error: ErrInvalidGroup},
+		{query: "?group=story&cursor=abc", error: ErrCursorWithGroup},
+	}
+
+	for _, tc := range testCases {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news"+tc.query, nil)
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), tc.error)
+	}
+}
+
+func TestGetNews_GroupByStory(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	err := Store.Upsert([]types.Article{
+		{Title: "Earthquake hits southern Japan", Description: "Tsunami warning issued",
+			PubDate: "2024-07-20T10:00:00Z", Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "Japan earthquake triggers tsunami warning", Description: "Southern Japan was hit by a strong earthquake",
+			PubDate: "2024-07-20T09:00:00Z", Publisher: "abc", Link: "https://abc.com/1"},
+		{Title: "Central bank keeps rates unchanged", Description: "Inflation is slowing",
+			PubDate: "2024-07-20T08:00:00Z", Publisher: "abc", Link: "https://abc.com/2"},
+	})
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news?group=story&limit=1", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		TotalAmount   int             `json:"totalAmount"`
+		TotalArticles int             `json:"totalArticles"`
+		Next          string          `json:"next"`
+		Stories       []cluster.Story `json:"stories"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &body)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, body.TotalAmount)
+	assert.Equal(t, 3, body.TotalArticles)
+	assert.Equal(t, "/news?group=story&limit=1&offset=1", body.Next)
+	if assert.Len(t, body.Stories, 1) {
+		assert.Equal(t, []string{"abc", "bbc"}, body.Stories[0].Publishers)
+		assert.Len(t, body.Stories[0].Articles, 2)
+	}
+}
+
+func TestGetNews_InvalidSort(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	testCases := []struct {
+		name  string
+		query string
+		error string
+	}{
+		{
+			name:  "Unsupported sort",
+			query: "?keywords=ukraine&sort=popularity",
+			error: validator.ErrUnsupportedSort,
+		},
+		{
+			name:  "Relevance without keywords",
+			query: "?sort=relevance",
+			error: validator.ErrRelevanceWithoutKeywords,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.error)
+		})
+	}
+}
+
+func TestGetNews_SortByRelevance(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	err := Store.Upsert([]types.Article{
+		{Title: "Weather", Description: "Rain during the election", PubDate: "2024-07-20", Publisher: "abc", Link: "https://abc.com/1"},
+		{Title: "UK election called", PubDate: "2024-07-19", Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "Football", PubDate: "2024-07-20", Publisher: "bbc", Link: "https://bbc.com/2"},
+	})
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news?keywords=election&sort=relevance", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		TotalAmount int             `json:"totalAmount"`
+		News        []types.Article `json:"news"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, response.TotalAmount)
+	assert.Equal(t, "UK election called", response.News[0].Title)
+	assert.Greater(t, response.News[0].Score, response.News[1].Score)
+}
+
+func TestGetNews_InvalidKeywords(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news?keywords="+url.QueryEscape("(Ukraine OR Poland"), nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), validator.ErrFailedKeywordsValidation)
+}
+
+func TestGetNews_InvalidMatch(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	testCases := []struct {
+		name  string
+		query string
+		error string
+	}{
+		{
+			name:  "Unsupported match mode",
+			query: "?keywords=covid&match=fuzzy",
+			error: validator.ErrFailedMatchModeValidation,
+		},
+		{
+			name:  "Invalid regular expression",
+			query: "?match=regex&keywords=" + url.QueryEscape(`"COVID-(19"`),
+			error: validator.ErrFailedKeywordsValidation,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.error)
+		})
+	}
+}
+
+func TestGetNews_InvalidDates(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	testCases := []struct {
+		name  string
+		query string
+		error string
+	}{
+		{
+			name:  "Unknown time zone",
+			query: "?date-from=today&tz=Mars/Olympus",
+			error: validator.ErrFailedTimezoneValidation,
+		},
+		{
+			name:  "Unsupported relative unit",
+			query: "?date-from=-6y",
+			error: validator.ErrFailedDateValidation,
+		},
+		{
+			name:  "Date from after date end",
+			query: "?date-from=today&date-end=yesterday",
+			error: validator.ErrDateFromAfter,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.error)
+		})
+	}
+}
+
+func TestGetNews_Pagination(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	type response struct {
+		TotalAmount int             `json:"totalAmount"`
+		Offset      int             `json:"offset"`
+		Limit       int             `json:"limit"`
+		Next        string          `json:"next"`
+		NextCursor  string          `json:"nextCursor"`
+		News        []types.Article `json:"news"`
+	}
+
+	get := func(link string) (*httptest.ResponseRecorder, response) {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080"+link, nil)
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		var body response
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w, body
+	}
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	err := Store.Upsert([]types.Article{
+		{Title: "First", PubDate: "2024-07-18T10:00:00Z", Publisher: "abc", Link: "https://abc.com/1"},
+		{Title: "Second", PubDate: "2024-07-19T10:00:00Z", Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "Third", PubDate: "2024-07-20T10:00:00Z", Publisher: "abc", Link: "https://abc.com/2"},
+		{Title: "Fourth", PubDate: "2024-07-20T12:00:00Z", Publisher: "bbc", Link: "https://bbc.com/2"},
+		{Title: "Fifth", PubDate: "2024-07-21T10:00:00Z", Publisher: "abc", Link: "https://abc.com/3"},
+	})
+	assert.Nil(t, err)
+
+	w, all := get("/news?sort=date_asc")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, all.TotalAmount)
+	assert.Equal(t, "First", all.News[0].Title)
+	assert.Equal(t, "Fifth", all.News[4].Title)
+	assert.Empty(t, all.Next)
+	assert.Empty(t, w.Header().Get("Link"))
+
+	w, first := get("/news?sort=date_asc&limit=2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, all.TotalAmount, first.TotalAmount)
+	assert.Equal(t, all.News[:2], first.News)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Equal(t, "<"+first.Next+">; rel=\"next\"", w.Header().Get("Link"))
+
+	w, second := get(first.Next)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, second.Offset)
+	assert.Equal(t, all.News[2:4], second.News)
+
+	w, byOffset := get("/news?sort=date_asc&limit=2&offset=2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, second.News, byOffset.News)
+
+	// articles stored after the first page was returned do not shift the next page
+	err = Store.Upsert([]types.Article{
+		{Title: "Oldest", PubDate: "2024-07-17T10:00:00Z", Publisher: "bbc", Link: "https://bbc.com/3"},
+	})
+	assert.Nil(t, err)
+
+	w, shifted := get(first.Next)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 6, shifted.TotalAmount)
+	assert.Equal(t, second.News, shifted.News)
+
+	w, newest := get("/news?limit=1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Fifth", newest.News[0].Title)
+}
+
+func TestGetNews_FilterByCategoryAndAuthor(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	err := Store.Upsert([]types.Article{
+		{Title: "World", PubDate: "2024-07-18T10:00:00Z", Publisher: "bbc", Link: "https://bbc.com/1",
+			Author: "John Doe", Categories: []string{"World"}, ImageUrl: "https://bbc.com/1.jpg"},
+		{Title: "Sport", PubDate: "2024-07-19T10:00:00Z", Publisher: "bbc", Link: "https://bbc.com/2",
+			Author: "Jane Roe", Categories: []string{"Sport"}},
+		{Title: "Europe", PubDate: "2024-07-20T10:00:00Z", Publisher: "abc", Link: "https://abc.com/1",
+			Author: "Jane Roe", Categories: []string{"World", "Europe"}},
+	})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "By category", query: "category=world", expected: []string{"Europe", "World"}},
+		{name: "By author", query: "author=jane%20roe", expected: []string{"Europe", "Sport"}},
+		{name: "By category and author", query: "category=World&author=Roe", expected: []string{"Europe"}},
+		{name: "By unknown category", query: "category=weather", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var body struct {
+				News []types.Article `json:"news"`
+			}
+			err := json.Unmarshal(w.Body.Bytes(), &body)
+			assert.Nil(t, err)
+
+			var titles []string
+			for _, article := range body.News {
+				titles = append(titles, article.Title)
+			}
+			assert.Equal(t, tc.expected, titles)
+		})
+	}
+}
+
+func TestGetNews_InvalidPagination(t *testing.T) {
+	server := gin.Default()
+	server.GET("/news", GetNews)
+
+	cursor := paging.NewCursor(types.Article{ID: "a"}, types.DateAscSort).Encode()
+
+	testCases := []struct {
+		name  string
+		query string
+		error string
+	}{
+		{
+			name:  "Invalid limit",
+			query: "?limit=0",
+			error: paging.ErrInvalidLimit.Error(),
+		},
+		{
+			name:  "Invalid offset",
+			query: "?offset=first",
+			error: paging.ErrInvalidOffset.Error(),
+		},
+		{
+			name:  "Cursor of another sort",
+			query: "?sort=date_desc&cursor=" + cursor,
+			error: paging.ErrInvalidCursor.Error(),
+		},
+		{
+			name:  "Unsupported sort",
+			query: "?sort=popularity",
+			error: validator.ErrUnsupportedSort,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/news"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.error)
+		})
+	}
+}
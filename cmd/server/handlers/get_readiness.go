@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gogator/cmd/parsers"
+	"gogator/cmd/types"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ReadyStatus is returned, when the server accepts new requests
+	ReadyStatus = "ready"
+
+	// NotReadyStatus is returned, when any of readiness checks fails
+	NotReadyStatus = "not ready"
+
+	// ShuttingDownStatus is returned, when the server is draining requests before shutdown
+	ShuttingDownStatus = "shutting down"
+
+	// OkStatus is returned by the liveness probe, and by readiness checks, which passed
+	OkStatus = "ok"
+
+	// errSourcesNotLoaded is returned by the sources check, until sources file is loaded
+	errSourcesNotLoaded = "sources file is not loaded"
+
+	// errStoreNotInitialized is returned by the storage check, until Store is set
+	errStoreNotInitialized = "storage is not initialized"
+)
+
+// shuttingDown is set, when the server received the shutdown signal
+var shuttingDown atomic.Bool
+
+// SetShuttingDown makes the readiness probe fail, so load balancers stop sending new requests
+// to the server, which is going to shut down
+func SetShuttingDown(value bool) {
+	shuttingDown.Store(value)
+}
+
+// GetReadiness responds with 200 OK, when the server is able to serve news:
+// stored articles can be read and sources were loaded from sources.json file.
+// It responds with 503 Service Unavailable, if any of the checks fails, or after the server
+// received the shutdown signal.
+//
+// Response contains the overall status and the result of every check, e.g.
+// {"status": "not ready", "checks": {"storage": "ok", "sources": "sources file is not loaded"}}
+func GetReadiness(c *gin.Context) {
+	if shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": ShuttingDownStatus})
+		return
+	}
+
+	status, code := ReadyStatus, http.StatusOK
+	checks := gin.H{
+		"storage": OkStatus,
+		"sources": OkStatus,
+	}
+
+	if err := checkStorage(); err != nil {
+		status, code = NotReadyStatus, http.StatusServiceUnavailable
+		checks["storage"] = err.Error()
+	}
+
+	if !parsers.SourcesLoaded() {
+		status, code = NotReadyStatus, http.StatusServiceUnavailable
+		checks["sources"] = errSourcesNotLoaded
+	}
+
+	c.JSON(code, gin.H{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+// checkStorage verifies, that stored articles can be read. Only articles of the current day are queried,
+// so the check stays cheap regardless of amount of stored articles.
+func checkStorage() error {
+	if Store == nil {
+		return errors.New(errStoreNotInitialized)
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	_, err := Store.Query(types.NewFilteringParams("", today, today, ""))
+	return err
+}
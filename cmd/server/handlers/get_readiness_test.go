@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetReadiness(t *testing.T) {
+	server := gin.Default()
+	server.GET("/readyz", GetReadiness)
+
+	store, storagePath := Store, parsers.StoragePath
+	defer func() {
+		Store, parsers.StoragePath = store, storagePath
+		SetShuttingDown(false)
+	}()
+
+	parsers.StoragePath = t.TempDir()
+	assert.Nil(t, parsers.UpdateSourceFile())
+
+	unreadableDir := t.TempDir()
+	today := time.Now().UTC().Format(time.DateOnly)
+	err := os.WriteFile(filepath.Join(unreadableDir, today+".json"), []byte("not json"), 0644)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name           string
+		store          storage.SearchableStore
+		shuttingDown   bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Ready",
+			store:          storage.NewIndexedStore(storage.NewJsonStore(t.TempDir())),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status": "ready", "checks": {"storage": "ok", "sources": "ok"}}`,
+		},
+		{
+			name:           "Storage is not initialized",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"status": "not ready", "checks": {"storage": "storage is not initialized",` +
+				` "sources": "ok"}}`,
+		},
+		{
+			name:           "Stored articles can not be read",
+			store:          storage.NewIndexedStore(storage.NewJsonStore(unreadableDir)),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"status": "not ready", "checks": {"storage": "invalid character 'o' in literal null (expecting 'u')",` +
+				` "sources": "ok"}}`,
+		},
+		{
+			name:           "Shutting down",
+			store:          storage.NewIndexedStore(storage.NewJsonStore(t.TempDir())),
+			shuttingDown:   true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status": "shutting down"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Store = tt.store
+			SetShuttingDown(tt.shuttingDown)
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/readyz", nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+const (
+	// ErrStats is thrown when server fails to count stored articles
+	ErrStats = "Failed to retrieve storage stats: "
+)
+
+// GetStats returns amount of stored articles: total, per source, and the range of stored days
+func GetStats(c *gin.Context) {
+	stats, err := Store.Stats()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": ErrStats + err.Error(),
+		})
+		log.Println(ErrStats + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
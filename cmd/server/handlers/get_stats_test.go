@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/storage"
+	"gogator/cmd/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetStats(t *testing.T) {
+	server := gin.Default()
+	server.GET("/admin/stats", GetStats)
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	err := Store.Upsert([]types.Article{
+		{Title: "First", PubDate: "2024-07-19", Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "Second", PubDate: "2024-07-20", Publisher: "abc", Link: "https://abc.com/1"},
+	})
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/admin/stats", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var stats storage.Stats
+	err = json.Unmarshal(w.Body.Bytes(), &stats)
+	assert.Nil(t, err)
+	assert.Equal(t, storage.Stats{
+		TotalArticles:    2,
+		SourceToArticles: map[string]int{"bbc": 1, "abc": 1},
+		DayToArticles:    map[string]int{"2024-07-19": 1, "2024-07-20": 1},
+		FirstDay:         "2024-07-19",
+		LastDay:          "2024-07-20",
+	}, stats)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gogator/cmd/trends"
+	"gogator/cmd/types"
+	"gogator/cmd/validator"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// WindowFlag will be used to get the period of trends (or empty string) from URL parameter
+	WindowFlag = "window"
+
+	// BaselineFlag will be used to get the period before the window (or empty string) from URL parameter
+	BaselineFlag = "baseline"
+
+	// ErrTrends is thrown when server fails to retrieve articles, which trends are computed from
+	ErrTrends = "error while computing trends: "
+)
+
+// GetTrends returns terms, which are trending in stored articles, from the most trending one.
+//
+// Amount of articles mentioning a term in the last window (window parameter, 6h by default) is compared
+// with the amount predicted by the baseline period before it (baseline parameter, 7d by default).
+// Every trend has its counts, score and the newest articles of the window, which mention it.
+//
+// Articles can be filtered by comma separated sources (sources parameter) and languages (lang parameter).
+// Amount of trends is set by limit parameter, 20 by default.
+func GetTrends(c *gin.Context) {
+	sources := c.Query(SourcesFlag)
+	lang := c.Query(LanguageFlag)
+	now := time.Now()
+
+	params, err := trends.NewParams(c.Query(WindowFlag), c.Query(BaselineFlag), c.Query(LimitFlag), now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
+	}
+
+	err = validator.BySources(sources)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
+	}
+
+	err = validator.ByLanguage(lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrValidatingParams + err.Error(),
+		})
+		log.Println(ErrValidatingParams + err.Error())
+		return
+	}
+
+	filteringParams := types.NewFilteringParams("", params.Since().Format(time.RFC3339Nano),
+		now.UTC().Format(time.RFC3339Nano), sources)
+	filteringParams.Language = lang
+
+	news, err := Store.Query(filteringParams)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": ErrTrends + err.Error(),
+		})
+		log.Println(ErrTrends + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window":   trends.FormatDuration(params.Window),
+		"baseline": trends.FormatDuration(params.Baseline),
+		"articles": len(news),
+		"trends":   trends.Compute(news, params),
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/storage"
+	"gogator/cmd/trends"
+	"gogator/cmd/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetTrends(t *testing.T) {
+	server := gin.Default()
+	server.GET("/trends", GetTrends)
+
+	store := Store
+	defer func() {
+		Store = store
+	}()
+	Store = storage.NewIndexedStore(storage.NewJsonStore(t.TempDir()))
+
+	now := time.Now().UTC()
+	err := Store.Upsert([]types.Article{
+		{Title: "Storm hits the coast", PublishedAt: now.Add(-time.Hour), Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "Storm is moving north", PublishedAt: now.Add(-2 * time.Hour), Publisher: "abc", Link: "https://abc.com/1"},
+		{Title: "Markets rise", PublishedAt: now.Add(-3 * time.Hour), Publisher: "bbc", Link: "https://bbc.com/2"},
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		trends     int
+	}{
+		{name: "Default window", query: "", statusCode: http.StatusOK, trends: 1},
+		{name: "Window and sources", query: "?window=3h&sources=bbc", statusCode: http.StatusOK, trends: 0},
+		{name: "Invalid window", query: "?window=3y", statusCode: http.StatusBadRequest},
+		{name: "Invalid limit", query: "?limit=0", statusCode: http.StatusBadRequest},
+		{name: "Invalid source", query: "?sources=source-7", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/trends"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Trends []trends.Trend `json:"trends"`
+			}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Nil(t, err)
+			assert.Len(t, response.Trends, tt.trends)
+			if tt.trends > 0 {
+				assert.Equal(t, "storm", response.Trends[0].Term)
+				assert.Equal(t, 2, response.Trends[0].Count)
+			}
+		})
+	}
+}
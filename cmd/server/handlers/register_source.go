@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"gogator/cmd/parsers"
 	"gogator/cmd/types"
@@ -23,7 +24,7 @@ const (
 )
 
 // RegisterSource handler will be used in order to create new source from where
-// we can parse news
+// we can parse news. If the source is already registered, it responds with 409 Conflict.
 func RegisterSource(c *gin.Context) {
 	var reqBody types.Feed
 
@@ -36,14 +37,19 @@ func RegisterSource(c *gin.Context) {
 		return
 	}
 
-	if sourceInArray(reqBody.Name) {
-		c.JSON(http.StatusBadRequest, gin.H{
+	err = parsers.AddNewFeed(reqBody)
+	if errors.Is(err, parsers.ErrSourceExists) {
+		c.JSON(http.StatusConflict, gin.H{
 			"error": ErrSourceExists,
 		})
 		return
 	}
-
-	err = parsers.AddNewSource(reqBody.Format, reqBody.Name, reqBody.Endpoint)
+	if isInvalidFeedErr(err) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrAddSource + err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": ErrAddSource + err.Error(),
@@ -57,6 +63,14 @@ func RegisterSource(c *gin.Context) {
 	})
 }
 
+// isInvalidFeedErr checks if error was caused by invalid feed configuration provided by user,
+// e.g. unknown format, malformed field mapping or scraping profile
+func isInvalidFeedErr(err error) bool {
+	return errors.Is(err, parsers.ErrUnknownFormat) ||
+		errors.Is(err, parsers.ErrInvalidMapping) ||
+		errors.Is(err, parsers.ErrInvalidScrapingProfile)
+}
+
 // sourceInArray checks if sources is already in array
 func sourceInArray(source string) bool {
 	if _, exists := parsers.GetAllSources()[source]; exists {
@@ -50,7 +50,7 @@ func TestRegisterSource(t *testing.T) {
 				err := parsers.DeleteSource("source3")
 				assert.Nil(t, err)
 			},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusConflict,
 			response: gin.H{
 				"error": ErrSourceExists,
 			},
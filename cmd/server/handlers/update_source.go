@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"gogator/cmd/parsers"
 	"gogator/cmd/types"
@@ -23,7 +24,8 @@ const (
 )
 
 // UpdateSource updates existent source with given parameters.
-// If not-existent source is going to be updated - throws an error.
+// All fields are applied at once: if any of them is not valid, the source is not changed.
+// If not-existent source is going to be updated - responds with 404 Not Found.
 func UpdateSource(c *gin.Context) {
 	var reqBody types.Feed
 	var err error
@@ -43,22 +45,36 @@ func UpdateSource(c *gin.Context) {
 		return
 	}
 
-	if !sourceInArray(reqBody.Name) {
+	err = parsers.ValidateMapping(reqBody.Mapping)
+	if err == nil {
+		err = parsers.ValidateScrapingProfile(reqBody.Scraping)
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": ErrSourceNotFound,
+			"error": ErrUpdateSource + err.Error(),
 		})
 		return
 	}
 
-	if reqBody.Endpoint != "" {
-		err = parsers.UpdateSourceEndpoint(reqBody.Name, reqBody.Endpoint)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": ErrUpdateSource + err.Error(),
-			})
-			log.Println(ErrUpdateSource + err.Error())
-			return
-		}
+	err = parsers.UpdateFeed(reqBody)
+	if errors.Is(err, parsers.ErrSourceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": ErrSourceNotFound,
+		})
+		return
+	}
+	if isInvalidFeedErr(err) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": ErrUpdateSource + err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": ErrUpdateSource + err.Error(),
+		})
+		log.Println(ErrUpdateSource + err.Error())
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/parsers"
 	"gogator/cmd/types"
 	"net/http"
 	"net/http/httptest"
@@ -32,7 +33,7 @@ func TestUpdateSource(t *testing.T) {
 				Endpoint: "https://source5.com",
 			},
 			setup:      func() {},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusNotFound,
 			response: gin.H{
 				"error": ErrSourceNotFound,
 			},
@@ -74,6 +75,49 @@ func TestUpdateSource(t *testing.T) {
 				"status": MsgSourceUpdated,
 			},
 		},
+		{
+			name:   "Update existent source with unknown format",
+			source: "source5",
+			body: &types.Feed{
+				Name:   "bbc",
+				Format: "yaml",
+			},
+			setup:      func() {},
+			statusCode: http.StatusBadRequest,
+			response: gin.H{
+				"error": ErrUpdateSource + "unknown source format: yaml. Supported formats are: [atom html json xml]",
+			},
+		},
+		{
+			name:   "Update existent source with malformed field mapping",
+			source: "source5",
+			body: &types.Feed{
+				Name:   "bbc",
+				Format: "json",
+				Mapping: &types.FieldMapping{
+					Items: "data..results",
+				},
+			},
+			setup:      func() {},
+			statusCode: http.StatusBadRequest,
+			response: gin.H{
+				"error": ErrUpdateSource + "invalid field mapping: malformed path for items field: \"data..results\"",
+			},
+		},
+		{
+			name:   "Invalid field does not apply other fields",
+			source: "source5",
+			body: &types.Feed{
+				Name:     "bbc",
+				Format:   "yaml",
+				Endpoint: "https://bbc.com/changed",
+			},
+			setup:      func() {},
+			statusCode: http.StatusBadRequest,
+			response: gin.H{
+				"error": ErrUpdateSource + "unknown source format: yaml. Supported formats are: [atom html json xml]",
+			},
+		},
 		{
 			name:   "Update endpoint in existent source",
 			source: "source5",
@@ -113,6 +157,7 @@ func TestUpdateSource(t *testing.T) {
 			err := json.Unmarshal(w.Body.Bytes(), &response)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.response, response)
+			assert.NotEqual(t, "https://bbc.com/changed", parsers.GetSourceDetailed("bbc").Endpoint)
 		})
 	}
 }
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"gogator/cmd/server/handlers"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// infoShuttingDown is logged when the shutdown signal is received
+	infoShuttingDown = "Shutting down, draining requests for "
+
+	// infoShutdownComplete is logged when all requests are completed and the server is stopped
+	infoShutdownComplete = "Server is stopped"
+
+	// errDrainingRequests is thrown when requests in flight do not complete in the shutdown timeout
+	errDrainingRequests = "Error draining requests, the remaining ones are cancelled: "
+
+	// infoBackgroundStopped is logged when all background jobs are stopped
+	infoBackgroundStopped = "Background jobs are stopped"
+)
+
+// backgroundJob is work of the server, which runs next to serving requests, until ctx is cancelled
+type backgroundJob func(ctx context.Context)
+
+// serve runs the server with listen and background jobs, until the server fails or ctx is cancelled.
+//
+// Jobs run with a root context, which is cancelled together with ctx, or when the server fails,
+// and serve waits for all jobs to return before it returns itself.
+// After ctx is cancelled, the server is marked as shutting down, so its readiness probe fails,
+// but requests are still accepted during drainDelay, until load balancers stop sending them.
+// Then the listener is closed, and requests in flight have shutdownTimeout to complete. Contexts of requests,
+// which are still running after it, are cancelled, and their connections are closed.
+func serve(ctx context.Context, server *http.Server, listen func() error, drainDelay, shutdownTimeout time.Duration,
+	jobs ...backgroundJob) error {
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	server.BaseContext = func(net.Listener) context.Context {
+		return requestsCtx
+	}
+
+	jobsCtx, cancelJobs := context.WithCancel(ctx)
+	var jobsWg sync.WaitGroup
+	defer func() {
+		cancelJobs()
+		jobsWg.Wait()
+		if len(jobs) != 0 {
+			log.Println(infoBackgroundStopped)
+		}
+	}()
+
+	for _, job := range jobs {
+		jobsWg.Add(1)
+		go func() {
+			defer jobsWg.Done()
+			job(jobsCtx)
+		}()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- listen()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println(infoShuttingDown + drainDelay.String())
+	handlers.SetShuttingDown(true)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(drainDelay):
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	shutdownErr := server.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		cancelRequests()
+		server.Close()
+		shutdownErr = errors.New(errDrainingRequests + shutdownErr.Error())
+	}
+
+	err := <-errCh
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if shutdownErr != nil {
+		return shutdownErr
+	}
+
+	log.Println(infoShutdownComplete)
+	return nil
+}
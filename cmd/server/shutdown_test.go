@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/server/handlers"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name            string
+		handlerDuration time.Duration
+		shutdownTimeout time.Duration
+		expectedStatus  int
+		expectCancelled bool
+		expectedErr     bool
+	}{
+		{
+			name:            "Request in flight completes",
+			handlerDuration: 200 * time.Millisecond,
+			shutdownTimeout: 5 * time.Second,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "Request in flight is cancelled after timeout",
+			handlerDuration: 5 * time.Second,
+			shutdownTimeout: 100 * time.Millisecond,
+			expectCancelled: true,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer handlers.SetShuttingDown(false)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.Nil(t, err)
+
+			started := make(chan struct{})
+			cancelled := make(chan bool, 1)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				select {
+				case <-time.After(tt.handlerDuration):
+					cancelled <- false
+					w.WriteHeader(http.StatusOK)
+				case <-r.Context().Done():
+					cancelled <- true
+				}
+			})
+			mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.StatusServiceUnavailable, readinessStatus())
+				w.WriteHeader(http.StatusOK)
+			})
+			server := &http.Server{Handler: mux}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- serve(ctx, server, func() error {
+					return server.Serve(ln)
+				}, 100*time.Millisecond, tt.shutdownTimeout)
+			}()
+
+			url := "http://" + ln.Addr().String()
+			response := make(chan *http.Response, 1)
+			go func() {
+				resp, err := http.Get(url + "/slow")
+				if err != nil {
+					response <- nil
+					return
+				}
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				response <- resp
+			}()
+
+			<-started
+			cancel()
+
+			// requests are accepted during the drain delay, while readiness probe fails
+			time.Sleep(20 * time.Millisecond)
+			resp, err := http.Get(url + "/readyz")
+			assert.Nil(t, err)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			err = <-serveErr
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, tt.expectCancelled, <-cancelled)
+			resp = <-response
+			if tt.expectedStatus != 0 {
+				assert.NotNil(t, resp)
+				assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			}
+
+			_, err = http.Get(url + "/readyz")
+			assert.NotNil(t, err, "listener is closed")
+		})
+	}
+}
+
+func TestServe_BackgroundJobs(t *testing.T) {
+	defer handlers.SetShuttingDown(false)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := &http.Server{Handler: http.NewServeMux()}
+
+	started := make(chan struct{})
+	stopped := false
+	job := func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+
+		// serve should wait for the job to finish its cleanup
+		time.Sleep(50 * time.Millisecond)
+		stopped = true
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve(ctx, server, func() error {
+			return server.Serve(ln)
+		}, 0, time.Second, job)
+	}()
+
+	<-started
+	cancel()
+
+	assert.Nil(t, <-serveErr)
+	assert.True(t, stopped, "serve returns after background jobs")
+}
+
+func TestServe_ListenError(t *testing.T) {
+	server := &http.Server{Addr: "invalid address"}
+
+	err := serve(context.Background(), server, server.ListenAndServe, time.Second, time.Second)
+	assert.NotNil(t, err)
+}
+
+// readinessStatus returns status of the readiness probe
+func readinessStatus() int {
+	r := gin.New()
+	r.GET("/readyz", handlers.GetReadiness)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	return w.Code
+}
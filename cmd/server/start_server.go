@@ -1,58 +1,105 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"gogator/cmd/auth"
+	"gogator/cmd/config"
+	"gogator/cmd/metrics"
 	parsers "gogator/cmd/parsers"
+	"gogator/cmd/server/handlers"
+	"gogator/cmd/storage"
+	"io"
+	"log"
+	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"strings"
-)
-
-var (
-	// defaultCertsPath is default path to server
-	defaultCertsPath = filepath.Join("cmd", "server", "certs")
-
-	// defaultDataDirPath is a default path to the directory where all data will be stored
-	defaultDataDirPath = filepath.Join("cmd", "parsers", "data")
+	"syscall"
 )
 
 const (
-	// defaultServerPort is a default port on which this server will be running
-	defaultServerPort = 443
-
-	// defaultPrivateKey identifies the default name of server's private key
-	defaultPrivateKey = "tls.key"
-
-	// defaultCertName represents default name of server's certificate file
-	defaultCertName = "tls.crt"
-
 	// errNotSpecified helps us to check if error was related to initializing sources file
 	errNotSpecified = "no such file or directory"
 
 	// errInitializingSources is thrown when func responsible for initialization of sources fails
 	errInitializingSources = "Error initializing sources file: "
+
+	// errInitializingStorage is thrown when storage of articles can not be created
+	errInitializingStorage = "Error initializing articles storage: "
+
+	// errInitializingAuth is thrown when credentials of clients can not be loaded
+	errInitializingAuth = "Error initializing authentication: "
+
+	// errLoadingConfig is thrown when configuration can not be loaded or is not valid
+	errLoadingConfig = "Error loading configuration: "
+
+	// errInitializingLogs is thrown when output of logs can not be opened
+	errInitializingLogs = "Error initializing logs: "
+
+	// errInitializingMetrics is thrown when collectors of stored articles can not be registered
+	errInitializingMetrics = "Error initializing metrics: "
+
+	// errInitializingTLS is thrown when certificates of the server or authorities of clients can not be loaded
+	errInitializingTLS = "Error initializing TLS: "
+
+	// errWarmingUpIndex is logged when the search index can not be built at start
+	errWarmingUpIndex = "Error building search index, it is built on the first search: "
+
+	// warnAuthDisabled is logged when authentication is disabled explicitly, so every route is public
+	warnAuthDisabled = "WARNING: authentication is disabled. " +
+		"Anyone who can reach the server is able to manage sources."
 )
 
-// ConfAndRun initializes and runs an HTTPS server using the Gin framework.
-// This function sets up server routes and handlers, and starts the server
-// on a user-specified port or defaults to port 443.
+// ConfAndRun initializes and runs a server using the Gin framework.
+// This function loads configuration, sets up server routes and handlers, and starts the server
+// on the configured address, which is :443 by default.
+//
+// The server listens in one of the modes: https (default), plain http, or h2c (HTTP/2 without TLS),
+// which is useful behind a TLS-terminating ingress. In https mode the certificate is reloaded when its files
+// change, or a self-signed one is generated for development. If authorities of clients are configured,
+// admin routes also require a verified client certificate.
 //
-// Optional parameters (specified via flags):
-// / -p (serverPort): Specifies the port on which the server will run. Defaults to 443 if not specified.
-// / -c (certFile): Specifies the absolute path to the certificate file for the HTTPS server. Defaults to a predefined path if not specified.
-// / -k (keyFile): Specifies the absolute path to the private key file for the HTTPS server. Defaults to a predefined path if not specified.
-// / -fs (storagePath): Specifies the path to the directory where all data will be stored. Defaults to a predefined path if not specified.
+// Liveness (/healthz) and readiness (/readyz) probes, and metrics in Prometheus format (/metrics) are served
+// on every mode without authentication.
+//
+// On SIGTERM or SIGINT the server shuts down gracefully: readiness probe starts failing, requests are
+// accepted for the drain delay, and then requests in flight have the shutdown timeout to complete.
+//
+// Configuration is loaded from the YAML file and GOGATOR_* environment variables (see package config).
+// Flags override both of them, if they are set explicitly:
+// / -config (configFile): Specifies the path to the configuration file. GOGATOR_CONFIG is used, if not specified.
+// / -print-config: Prints the effective configuration with secrets redacted, and exits without running the server.
+// / -p (serverPort): Specifies the port on which the server will run.
+// / -mode (serverMode): Specifies the protocol of the server: https, http or h2c.
+// / -self-signed: Generates a self-signed certificate for https mode, instead of reading certFile and keyFile.
+// / -c (certFile): Specifies the path to the certificate file for the HTTPS server.
+// / -k (keyFile): Specifies the path to the private key file for the HTTPS server.
+// / -fs (storagePath): Specifies the path to the directory where all data will be stored.
+// / -storage (storageBackend): Specifies how articles are stored inside of storagePath: json files or bolt database.
+// / -auth (authFile): Specifies the path to JSON file with API keys and the secret of bearer tokens (see package auth).
+// / -insecure-no-auth (insecureNoAuth): Runs the server without credentials, so every route is public.
+// / The server does not start without credentials in any mode, unless authentication is disabled explicitly.
 func ConfAndRun() error {
 	var (
-		server = gin.Default()
-		err    error
+		// configFile is a path to the configuration file
+		configFile string
+
+		// printConfig tells to print the effective configuration instead of running the server
+		printConfig bool
 
 		// serverPort identifies port on which Server will be running
 		serverPort int
 
+		// serverMode is the protocol, which the server listens with
+		serverMode string
+
+		// selfSigned tells to generate the certificate of the server
+		selfSigned bool
+
 		// certFile is the name of certificate file
 		certFile string
 
@@ -61,22 +108,112 @@ func ConfAndRun() error {
 
 		// storagePath is a path where all data from application will be stored (sources and files with articles)
 		storagePath string
+
+		// storageBackend is the name of the storage, which keeps articles
+		storageBackend string
+
+		// authFile is a path to the file with credentials of clients
+		authFile string
+
+		// insecureNoAuth allows the server to run without credentials of clients
+		insecureNoAuth bool
 	)
-	cwdPath, err := os.Getwd()
-	if err != nil {
-		return err
-	}
+	defaults := config.Default()
 
-	flag.IntVar(&serverPort, "p", defaultServerPort,
-		"On which port server will be running")
-	flag.StringVar(&certFile, "c", filepath.Join(cwdPath, defaultCertsPath, defaultCertName),
-		"Absolute path to the certificate for the HTTPs server")
-	flag.StringVar(&keyFile, "k", filepath.Join(cwdPath, defaultCertsPath, defaultPrivateKey),
-		"Absolute path to the private key for the HTTPs server")
-	flag.StringVar(&storagePath, "fs", defaultDataDirPath,
+	flag.StringVar(&configFile, "config", "",
+		"Path to YAML configuration file. Environment variable "+config.EnvConfigFile+" is used, if empty")
+	flag.BoolVar(&printConfig, "print-config", false,
+		"Print the effective configuration and exit")
+	flag.IntVar(&serverPort, "p", 0,
+		"On which port server will be running. Overrides server.address of the configuration")
+	flag.StringVar(&serverMode, "mode", defaults.Server.Mode,
+		"Protocol of the server: https, http (plain HTTP/1.1) or h2c (HTTP/2 without TLS)")
+	flag.BoolVar(&selfSigned, "self-signed", false,
+		"Serve a generated self-signed certificate in https mode. For development only")
+	flag.StringVar(&certFile, "c", defaults.TLS.CertFile,
+		"Path to the certificate for the HTTPs server")
+	flag.StringVar(&keyFile, "k", defaults.TLS.KeyFile,
+		"Path to the private key for the HTTPs server")
+	flag.StringVar(&storagePath, "fs", defaults.Storage.Path,
 		"Path to directory where all data will be stored")
+	flag.StringVar(&storageBackend, "storage", defaults.Storage.Backend,
+		"Storage of articles inside of the data directory: json (file per day) or bolt (single database file)")
+	flag.StringVar(&authFile, "auth", "",
+		"Path to JSON file with API keys and token secret. Environment variable "+auth.EnvConfigFile+" is used, if empty")
+	flag.BoolVar(&insecureNoAuth, "insecure-no-auth", false,
+		"Run without API keys and token secret, so every route is public. Overrides auth.disabled of the configuration")
 	flag.Parse()
 
+	conf, err := config.Load(configFile)
+	if err != nil {
+		return errors.New(errLoadingConfig + err.Error())
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "p":
+			conf.Server.Address = fmt.Sprintf(":%d", serverPort)
+		case "mode":
+			conf.Server.Mode = serverMode
+		case "self-signed":
+			conf.TLS.SelfSigned = selfSigned
+		case "c":
+			conf.TLS.CertFile = certFile
+		case "k":
+			conf.TLS.KeyFile = keyFile
+		case "fs":
+			conf.Storage.Path = storagePath
+		case "storage":
+			conf.Storage.Backend = storageBackend
+		case "auth":
+			conf.Auth.File = authFile
+		case "insecure-no-auth":
+			conf.Auth.Disabled = insecureNoAuth
+		}
+	})
+
+	err = conf.LoadAuth()
+	if err != nil {
+		return errors.New(errInitializingAuth + err.Error())
+	}
+
+	if printConfig {
+		return conf.Print(os.Stdout)
+	}
+
+	err = conf.Validate()
+	if err != nil {
+		return errors.New(errLoadingConfig + err.Error())
+	}
+
+	server, err := newEngine(conf.Log)
+	if err != nil {
+		return errors.New(errInitializingLogs + err.Error())
+	}
+
+	parsers.StoragePath = conf.Storage.Path
+	parsers.ConfigureFetcher(conf.FetcherConfig())
+
+	if len(conf.Sources) != 0 {
+		err = parsers.SetDefaultSources(conf.Feeds())
+		if err != nil {
+			return errors.New(errInitializingSources + err.Error())
+		}
+	}
+
+	store, err := storage.New(conf.Storage.Backend, conf.Storage.Path)
+	if err != nil {
+		return errors.New(errInitializingStorage + err.Error())
+	}
+	defer store.Close()
+	indexedStore := storage.NewIndexedStore(store)
+	handlers.Store = indexedStore
+
+	err = metrics.RegisterStorage(handlers.Store, parsers.ReadFetchReport)
+	if err != nil {
+		return errors.New(errInitializingMetrics + err.Error())
+	}
+
 	err = parsers.LoadSourcesFile()
 	if err != nil {
 		if strings.Contains(err.Error(), errNotSpecified) {
@@ -89,14 +226,92 @@ func ConfAndRun() error {
 		}
 	}
 
-	setupRoutes(server)
-
-	err = server.RunTLS(fmt.Sprintf(":%d", serverPort),
-		certFile,
-		keyFile)
+	authenticator, err := auth.New(conf.AuthConfig())
 	if err != nil {
-		return err
+		return errors.New(errInitializingAuth + err.Error())
+	}
+	if authenticator.Disabled() {
+		log.Println(warnAuthDisabled)
+	}
+
+	var adminMiddleware []gin.HandlerFunc
+	if conf.TLS.ClientCAFile != "" {
+		adminMiddleware = append(adminMiddleware, requireClientCert())
+	}
+
+	setupRoutes(server, authenticator, adminMiddleware...)
+
+	server.UseH2C = conf.Server.Mode == config.H2CMode
+	httpServer := &http.Server{
+		Addr:    conf.Server.Address,
+		Handler: server.Handler(),
+	}
+
+	listen := httpServer.ListenAndServe
+	if conf.Server.Mode == config.HTTPSMode {
+		httpServer.TLSConfig, err = newTLSConfig(conf.TLS)
+		if err != nil {
+			return errors.New(errInitializingTLS + err.Error())
+		}
+
+		listen = func() error {
+			return httpServer.ListenAndServeTLS("", "")
+		}
+	}
+
+	// Kubernetes sends SIGTERM before the pod is stopped. The second signal stops the server immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	return serve(ctx, httpServer, listen, conf.Server.DrainDelay, conf.Server.ShutdownTimeout,
+		warmUpIndex(indexedStore))
+}
+
+// warmUpIndex returns the job, which builds the search index of stored articles in the background,
+// so the first search after start does not wait for it
+func warmUpIndex(store *storage.IndexedStore) backgroundJob {
+	return func(ctx context.Context) {
+		err := store.Refresh(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Println(errWarmingUpIndex + err.Error())
+		}
+	}
+}
+
+// newEngine creates *gin.Engine, which logs to the configured output.
+// Debug level enables debug output of gin, and error level disables logging of requests.
+func newEngine(conf config.Log) (*gin.Engine, error) {
+	var output io.Writer
+
+	switch conf.Output {
+	case config.StderrOutput, "":
+		output = os.Stderr
+	case config.StdoutOutput:
+		output = os.Stdout
+	default:
+		file, err := os.OpenFile(conf.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		output = file
+	}
+
+	log.SetOutput(output)
+	gin.DefaultWriter = output
+	gin.DefaultErrorWriter = output
+
+	if conf.Level == config.DebugLevel {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	server := gin.New()
+	server.Use(gin.Recovery())
+	if conf.Level != config.ErrorLevel {
+		server.Use(gin.Logger())
 	}
 
-	return nil
+	return server, nil
 }
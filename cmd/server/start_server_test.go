@@ -3,80 +3,101 @@ package server
 import (
 	"context"
 	"flag"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/metrics"
 	"gogator/cmd/parsers"
+	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"testing"
 	"time"
 )
 
+// TestMain runs tests against a copy of the sources file, because tests add, update and delete sources
 func TestMain(m *testing.M) {
-	parsers.StoragePath = filepath.Join("..", "parsers", "data")
+	data, err := os.ReadFile(filepath.Join("..", "parsers", "data", "sources.json"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	dir, err := os.MkdirTemp(".", "storage-")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "sources.json"), data, 0644)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	parsers.StoragePath = dir
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
 }
 
 func TestConfAndRun(t *testing.T) {
 	testCases := []struct {
 		Name        string
+		Args        []string
 		Setup       func()
 		Cleanup     func()
 		ExpectError bool
 	}{
 		{
-			Name: "Successful run",
-			Setup: func() {
-				err := flag.Set("p", "443")
-				assert.Nil(t, err)
-				err = flag.Set("f", "1")
-				assert.Nil(t, err)
-			},
+			Name:        "Successful run",
+			Args:        []string{"-insecure-no-auth", "-p", "0", "-self-signed"},
+			Setup:       func() {},
 			Cleanup:     func() {},
 			ExpectError: false,
 		},
 		{
-			Name: "No certificates for server",
-			Setup: func() {
-				err := flag.Set("c", "")
-				assert.Nil(t, err)
-
-				err = flag.Set("k", "")
-				assert.Nil(t, err)
-			},
+			Name:        "No credentials in https mode",
+			Args:        []string{"-p", "0", "-self-signed"},
+			Setup:       func() {},
 			Cleanup:     func() {},
 			ExpectError: true,
 		},
 		{
-			Name: "Invalid port number",
-			Setup: func() {
-				err := flag.Set("p", "-1")
-				assert.Nil(t, err)
-			},
+			Name:        "No credentials in http mode",
+			Args:        []string{"-p", "0", "-mode", "http"},
+			Setup:       func() {},
 			Cleanup:     func() {},
 			ExpectError: true,
 		},
 		{
-			Name: "Invalid certificate paths",
-			Setup: func() {
-				err := flag.Set("c", "invalid/cert.pem")
-				assert.Nil(t, err)
-
-				err = flag.Set("k", "invalid/key.pem")
-				assert.Nil(t, err)
-			},
+			Name:        "No certificates for server",
+			Args:        []string{"-insecure-no-auth", "-p", "0", "-c", "", "-k", ""},
+			Setup:       func() {},
 			Cleanup:     func() {},
 			ExpectError: true,
 		},
 		{
-			Name: "Invalid storage path",
-			Setup: func() {
-				err := flag.Set("fs", "/invalid/path")
-				assert.Nil(t, err)
-			},
+			Name:        "Invalid port number",
+			Args:        []string{"-insecure-no-auth", "-p", "-1", "-self-signed"},
+			Setup:       func() {},
+			Cleanup:     func() {},
+			ExpectError: true,
+		},
+		{
+			Name:        "Invalid certificate paths",
+			Args:        []string{"-insecure-no-auth", "-p", "0", "-c", "invalid/cert.pem", "-k", "invalid/key.pem"},
+			Setup:       func() {},
+			Cleanup:     func() {},
+			ExpectError: true,
+		},
+		{
+			Name:        "Invalid storage path",
+			Args:        []string{"-insecure-no-auth", "-p", "0", "-self-signed", "-fs", "/invalid/path"},
+			Setup:       func() {},
 			Cleanup:     func() {},
 			ExpectError: true,
 		},
 		{
 			Name: "Invalid .PEM Certificate and Key files",
+			Args: []string{"-insecure-no-auth", "-p", "0", "-c", "invalid_cert.pem", "-k", "invalid_key.pem"},
 			Setup: func() {
 				invalidCert := []byte("invalid certificate content")
 				invalidKey := []byte("invalid key content")
@@ -86,12 +107,6 @@ func TestConfAndRun(t *testing.T) {
 
 				err = os.WriteFile("invalid_key.pem", invalidKey, 0644)
 				assert.Nil(t, err)
-
-				err = flag.Set("c", "invalid_cert.pem")
-				assert.Nil(t, err)
-
-				err = flag.Set("k", "invalid_key.pem")
-				assert.Nil(t, err)
 			},
 			Cleanup: func() {
 				err := os.Remove("invalid_cert.pem")
@@ -103,11 +118,25 @@ func TestConfAndRun(t *testing.T) {
 		},
 	}
 
+	// the running server is stopped at the end of its case, so the next run does not share globals with it
+	t.Setenv("GOGATOR_SERVER_DRAIN_DELAY", "0s")
+
+	args, commandLine, registry := os.Args, flag.CommandLine, metrics.Registry
+	defer func() {
+		os.Args, flag.CommandLine, metrics.Registry = args, commandLine, registry
+	}()
+
 	for _, tt := range testCases {
 		t.Run(tt.Name, func(t *testing.T) {
 			tt.Setup()
 			defer tt.Cleanup()
 
+			// ConfAndRun defines and parses flags of the command line, and registers collectors of metrics,
+			// so every run gets new ones
+			flag.CommandLine = flag.NewFlagSet(args[0], flag.ContinueOnError)
+			os.Args = append([]string{args[0], "-fs", parsers.StoragePath}, tt.Args...)
+			metrics.Registry = prometheus.NewRegistry()
+
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 			defer cancel()
 
@@ -125,7 +154,39 @@ func TestConfAndRun(t *testing.T) {
 				}
 			case <-ctx.Done():
 				t.Log("ConfAndRun took too long, returning nil error because server is working fine.")
+
+				err := stopServer(errCh)
+				assert.Nil(t, err)
 			}
 		})
 	}
 }
+
+// stopServer interrupts the process, until the server shuts down and ConfAndRun returns
+func stopServer(errCh <-chan error) error {
+	// interrupts are also delivered to this channel, so they never terminate tests
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		err = process.Signal(os.Interrupt)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case err = <-errCh:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
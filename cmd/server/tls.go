@@ -0,0 +1,249 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gogator/cmd/config"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// certCheckInterval is how often files of the certificate are checked for changes
+	certCheckInterval = 10 * time.Second
+
+	// selfSignedValidity is how long the generated self-signed certificate is valid
+	selfSignedValidity = 365 * 24 * time.Hour
+
+	// errReloadingCert is logged when the changed certificate can not be loaded, so the previous one is served
+	errReloadingCert = "Error reloading TLS certificate, the previous one is used: "
+
+	// errClientCertRequired is returned, when admin route is requested without verified client certificate
+	errClientCertRequired = "client certificate is required"
+
+	// warnSelfSigned is logged when the server uses the generated certificate
+	warnSelfSigned = "WARNING: serving self-signed certificate. It is meant for development only."
+)
+
+// ErrNoClientCAs is returned, when the file of client authorities contains no certificates
+var ErrNoClientCAs = errors.New("no certificates found in client CA file")
+
+// newTLSConfig creates TLS configuration of the https mode.
+// The certificate is either generated in memory, or loaded from files and reloaded, when they change.
+// If client authorities are configured, certificates of clients are verified, when clients send them.
+func newTLSConfig(conf config.TLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if conf.SelfSigned {
+		cert, err := selfSignedCertificate(time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		log.Println(warnSelfSigned)
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else {
+		reloader, err := newCertReloader(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.GetCertificate = reloader.GetCertificate
+	}
+
+	if conf.ClientCAFile != "" {
+		data, err := os.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, ErrNoClientCAs
+		}
+
+		// only admin routes require certificates, so clients of news may connect without them
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+// requireClientCert rejects requests, which were not made with a client certificate, verified
+// by the authorities of the TLS configuration
+func requireClientCert() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errClientCertRequired})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// certReloader serves the certificate of the files, and reloads it after the files are changed,
+// e.g. when cert-manager rotates the certificate in the mounted Secret.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+
+	// now returns the current time, which the check interval is measured with
+	now func() time.Time
+}
+
+// newCertReloader loads the certificate and its private key.
+//
+// Throws an error, if they can not be loaded.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		now:      time.Now,
+	}
+
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.load(modTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns the current certificate. Files are checked for changes at most once in certCheckInterval.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfChanged()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// reloadIfChanged loads the certificate, if its files were modified since the previous load.
+// If the new certificate can not be loaded, the previous one is kept.
+func (r *certReloader) reloadIfChanged() {
+	r.mu.Lock()
+	now := r.now()
+	if now.Sub(r.checkedAt) < certCheckInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.checkedAt = now
+	r.mu.Unlock()
+
+	modTime, err := r.filesModTime()
+	if err != nil {
+		log.Println(errReloadingCert + err.Error())
+		return
+	}
+
+	r.mu.RLock()
+	changed := !modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+
+	if !changed {
+		return
+	}
+
+	err = r.load(modTime)
+	if err != nil {
+		log.Println(errReloadingCert + err.Error())
+	}
+}
+
+// load reads the certificate and the key, and remembers modification time of their files
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.modTime = modTime
+	r.checkedAt = r.now()
+
+	return nil
+}
+
+// filesModTime returns the latest modification time of the certificate and the key
+func (r *certReloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// selfSignedCertificate generates a certificate for localhost and the host name of the machine
+func selfSignedCertificate(now time.Time) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Go-Gator"}, CommonName: "localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(selfSignedValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		template.DNSNames = append(template.DNSNames, hostname)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate generates a self-signed certificate, and writes it and its key to PEM files inside of dir
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string, cert tls.Certificate) {
+	cert, err := selfSignedCertificate(time.Now())
+	assert.Nil(t, err)
+
+	key, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	assert.Nil(t, err)
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600)
+	assert.Nil(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key}), 0600)
+	assert.Nil(t, err)
+
+	return certFile, keyFile, cert
+}
+
+func TestSelfSignedCertificate(t *testing.T) {
+	now := time.Now()
+
+	cert, err := selfSignedCertificate(now)
+	assert.Nil(t, err)
+
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.Nil(t, err)
+	assert.Contains(t, parsed.DNSNames, "localhost")
+	assert.True(t, parsed.NotAfter.After(now.Add(selfSignedValidity-time.Minute)))
+
+	err = parsed.VerifyHostname("127.0.0.1")
+	assert.Nil(t, err)
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, first := writeCertificate(t, dir)
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	assert.Nil(t, err)
+
+	now := time.Now()
+	reloader.now = func() time.Time { return now }
+
+	cert, err := reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, first.Certificate[0], cert.Certificate[0])
+
+	_, _, second := writeCertificate(t, dir)
+	modTime := now.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(certFile, modTime, modTime))
+
+	cert, err = reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, first.Certificate[0], cert.Certificate[0], "files are checked once in the interval")
+
+	now = now.Add(certCheckInterval)
+	cert, err = reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, second.Certificate[0], cert.Certificate[0])
+
+	err = os.WriteFile(keyFile, []byte("broken key"), 0600)
+	assert.Nil(t, err)
+	modTime = modTime.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(keyFile, modTime, modTime))
+
+	now = now.Add(certCheckInterval)
+	cert, err = reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, second.Certificate[0], cert.Certificate[0], "previous certificate is kept")
+
+	_, err = newCertReloader(filepath.Join(dir, "missing.crt"), keyFile)
+	assert.NotNil(t, err)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeCertificate(t, dir)
+
+	tests := []struct {
+		name               string
+		conf               config.TLS
+		expectedClientAuth tls.ClientAuthType
+		expectedErr        error
+		expectErr          bool
+	}{
+		{
+			name: "Self-signed certificate",
+			conf: config.TLS{SelfSigned: true},
+		},
+		{
+			name: "Certificate of files with client authorities",
+			conf: config.TLS{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: certFile,
+			},
+			expectedClientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			name:      "Missing certificate",
+			conf:      config.TLS{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyFile},
+			expectErr: true,
+		},
+		{
+			name:        "Client authorities without certificates",
+			conf:        config.TLS{SelfSigned: true, ClientCAFile: keyFile},
+			expectedErr: ErrNoClientCAs,
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := newTLSConfig(tt.conf)
+			if tt.expectErr {
+				assert.NotNil(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedClientAuth, tlsConfig.ClientAuth)
+			assert.True(t, len(tlsConfig.Certificates) != 0 || tlsConfig.GetCertificate != nil)
+		})
+	}
+}
+
+func TestRequireClientCert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		tls            *tls.ConnectionState
+		expectedStatus int
+	}{
+		{
+			name:           "Plain connection",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Connection without client certificate",
+			tls:            &tls.ConnectionState{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Verified client certificate",
+			tls:            &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/admin/stats", requireClientCert(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+			req.TLS = tt.tls
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package sorting orders articles in the orders supported by the server and the CLI:
+// by publication date (newest or oldest first), by publisher, or by relevance score.
+//
+// Every order is total: articles, which are equal by the order, are ordered by their ID,
+// so the same articles are always returned in the same order, and can be paginated.
+package sorting
@@ -0,0 +1,97 @@
+package sorting
+
+import (
+	"cmp"
+	"gogator/cmd/types"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Orders are supported orders of articles. Empty order means types.DateDescSort.
+var Orders = []string{types.DateDescSort, types.DateAscSort, types.SourceSort, types.RelevanceSort}
+
+// IsSupported checks if articles can be sorted in the order
+func IsSupported(order string) bool {
+	return order == "" || slices.Contains(Orders, order)
+}
+
+// Sort sorts articles in the order:
+// /  1. types.DateDescSort - newest articles first. It is used, when order is empty
+// /  2. types.DateAscSort  - oldest articles first
+// /  3. types.SourceSort   - by publisher in alphabetical order, newest articles of each publisher first
+// /  4. types.RelevanceSort - by score, the most relevant articles first, then newest ones
+//
+// Articles are sorted by their normalized publication date (see types.Article.NormalizePubDate).
+// Articles, which publication date is not normalized, are considered the oldest ones.
+func Sort(articles []types.Article, order string) {
+	keys := make([]sortKey, len(articles))
+	for i, article := range articles {
+		keys[i] = newSortKey(article)
+	}
+
+	sort.Sort(byKey{articles: articles, keys: keys, order: order})
+}
+
+// Compare returns a negative number, if article a goes before b in the order, a positive number,
+// if it goes after b, and zero, if they are the same article.
+func Compare(a, b types.Article, order string) int {
+	return compareKeys(newSortKey(a), newSortKey(b), order)
+}
+
+// sortKey contains fields of the article, which it is sorted by
+type sortKey struct {
+	pubDate   time.Time
+	publisher string
+	score     float64
+	id        string
+}
+
+func newSortKey(article types.Article) sortKey {
+	return sortKey{
+		pubDate:   article.PublishedAt,
+		publisher: strings.ToLower(article.Publisher),
+		score:     article.Score,
+		id:        article.ID,
+	}
+}
+
+// compareKeys compares keys of articles in the order, see Compare
+func compareKeys(a, b sortKey, order string) int {
+	newestFirst := b.pubDate.Compare(a.pubDate)
+
+	var result int
+	switch order {
+	case types.DateAscSort:
+		result = a.pubDate.Compare(b.pubDate)
+	case types.SourceSort:
+		result = cmp.Or(cmp.Compare(a.publisher, b.publisher), newestFirst)
+	case types.RelevanceSort:
+		result = cmp.Or(cmp.Compare(b.score, a.score), newestFirst)
+	default:
+		result = newestFirst
+	}
+
+	return cmp.Or(result, cmp.Compare(a.id, b.id))
+}
+
+// byKey implements sort.Interface for articles and their keys, which are swapped together
+type byKey struct {
+	articles []types.Article
+	keys     []sortKey
+	order    string
+}
+
+func (s byKey) Len() int {
+	return len(s.articles)
+}
+
+func (s byKey) Swap(i, j int) {
+	s.articles[i], s.articles[j] = s.articles[j], s.articles[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s byKey) Less(i, j int) bool {
+	return compareKeys(s.keys[i], s.keys[j], s.order) < 0
+}
@@ -0,0 +1,88 @@
+package sorting
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"slices"
+	"testing"
+	"time"
+)
+
+// pubDate returns an article with normalized publication date. Invalid date is normalized to the zero time.
+func pubDate(raw string) types.Article {
+	article := types.Article{PubDate: raw}
+	article.NormalizePubDate(time.Time{})
+
+	return article
+}
+
+func TestSort(t *testing.T) {
+	articles := []types.Article{
+		{ID: "a", Publisher: "BBC", PubDate: "2024-07-20T10:00:00Z", Score: 1},
+		{ID: "b", Publisher: "ABC", PubDate: "2024-07-21T10:00:00Z", Score: 2},
+		{ID: "c", Publisher: "bbc", PubDate: "2024-07-22T10:00:00Z", Score: 1},
+		{ID: "d", Publisher: "ABC", PubDate: "invalid-date", Score: 2},
+		{ID: "e", Publisher: "ABC", PubDate: "2024-07-21T10:00:00Z", Score: 0},
+	}
+	types.NormalizePubDates(articles, time.Time{})
+
+	testCases := []struct {
+		name     string
+		order    string
+		expected []string
+	}{
+		{
+			name:     "Empty order is newest first",
+			order:    "",
+			expected: []string{"c", "b", "e", "a", "d"},
+		},
+		{
+			name:     "Newest first",
+			order:    types.DateDescSort,
+			expected: []string{"c", "b", "e", "a", "d"},
+		},
+		{
+			name:     "Oldest first",
+			order:    types.DateAscSort,
+			expected: []string{"d", "a", "b", "e", "c"},
+		},
+		{
+			name:     "By publisher ignoring case",
+			order:    types.SourceSort,
+			expected: []string{"b", "e", "d", "c", "a"},
+		},
+		{
+			name:     "By relevance",
+			order:    types.RelevanceSort,
+			expected: []string{"b", "d", "c", "a", "e"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted := slices.Clone(articles)
+			Sort(sorted, tc.order)
+
+			var ids []string
+			for _, article := range sorted {
+				ids = append(ids, article.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	older := pubDate("2024-07-20")
+	newer := pubDate("2024-07-21")
+
+	assert.Negative(t, Compare(newer, older, types.DateDescSort))
+	assert.Positive(t, Compare(newer, older, types.DateAscSort))
+	assert.Zero(t, Compare(older, older, types.DateDescSort))
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported(""))
+	assert.True(t, IsSupported(types.SourceSort))
+	assert.False(t, IsSupported("popularity"))
+}
@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	bolt "go.etcd.io/bbolt"
+	"gogator/cmd/dedup"
+	"gogator/cmd/types"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// BoltFile is the filename of the database inside of the storage directory
+	BoltFile = "articles.db"
+
+	// boltFilePermissions are file permissions of the database
+	boltFilePermissions = 0600
+
+	// boltLockTimeout limits waiting for the database, which is opened by another process
+	boltLockTimeout = 10 * time.Second
+
+	// errOpeningBolt is thrown when the database can not be opened
+	errOpeningBolt = "Error opening articles database: "
+
+	// keySeparator separates day and ID in keys of articles
+	keySeparator = "/"
+)
+
+var (
+	// articlesBucket maps "<day>/<id>" keys to articles encoded in JSON,
+	// so articles of a date range are retrieved by a single cursor scan
+	articlesBucket = []byte("articles")
+
+	// idsBucket maps article IDs to their keys in articlesBucket,
+	// so the article is replaced, even if its publication day was changed
+	idsBucket = []byte("ids")
+)
+
+// BoltStore keeps articles in a single bbolt database file: <dir>/articles.db.
+//
+// The database is opened once by NewBoltStore and stays open, until Close is called.
+// bbolt allows only one process to open the database at a time, so another process waits for it
+// up to boltLockTimeout, and fails, if the database is still open.
+type BoltStore struct {
+	path string
+	db   *bolt.DB
+}
+
+// NewBoltStore opens the database inside of dir, creating it, if it does not exist.
+// The store should be closed with Close.
+func NewBoltStore(dir string) (*BoltStore, error) {
+	path := filepath.Join(dir, BoltFile)
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, errors.New(errOpeningBolt + err.Error())
+	}
+
+	db, err := bolt.Open(path, boltFilePermissions, &bolt.Options{Timeout: boltLockTimeout})
+	if err != nil {
+		return nil, errors.New(errOpeningBolt + err.Error())
+	}
+
+	return &BoltStore{
+		path: path,
+		db:   db,
+	}, nil
+}
+
+// Upsert stores articles, replacing previous versions with the same IDs
+func (s *BoltStore) Upsert(articles []types.Article) error {
+	now := time.Now()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		articlesB, err := tx.CreateBucketIfNotExists(articlesBucket)
+		if err != nil {
+			return err
+		}
+		idsB, err := tx.CreateBucketIfNotExists(idsBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, article := range dedup.Deduplicate(articles) {
+			article.NormalizePubDate(now)
+
+			previous := idsB.Get([]byte(article.ID))
+			if previous != nil {
+				var stored types.Article
+				err = json.Unmarshal(articlesB.Get(previous), &stored)
+				if err != nil {
+					return err
+				}
+				keepIngestDate(&article, stored)
+			}
+
+			key := []byte(articleDay(article, now) + keySeparator + article.ID)
+			if previous != nil && !bytes.Equal(previous, key) {
+				err = articlesB.Delete(previous)
+				if err != nil {
+					return err
+				}
+			}
+
+			data, err := json.Marshal(article)
+			if err != nil {
+				return err
+			}
+
+			err = articlesB.Put(key, data)
+			if err != nil {
+				return err
+			}
+			err = idsB.Put([]byte(article.ID), key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Query scans articles of days inside of the date range, and filters them by params
+func (s *BoltStore) Query(params *types.FilteringParams) ([]types.Article, error) {
+	first, last, err := dayRange(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var articles []types.Article
+
+	err = s.view(func(articlesB *bolt.Bucket) error {
+		c := articlesB.Cursor()
+
+		k, v := c.First()
+		if first != "" {
+			k, v = c.Seek([]byte(first))
+		}
+
+		for ; k != nil; k, v = c.Next() {
+			day := keyDay(k)
+			if !inRange(day, first, last) {
+				break
+			}
+
+			var article types.Article
+			err := json.Unmarshal(v, &article)
+			if err != nil {
+				return err
+			}
+			articles = append(articles, article)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return applyParams(articles, params), nil
+}
+
+// DeleteBySource removes articles of the publisher
+func (s *BoltStore) DeleteBySource(source string) (int, error) {
+	removed := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		articlesB := tx.Bucket(articlesBucket)
+		idsB := tx.Bucket(idsBucket)
+		if articlesB == nil || idsB == nil {
+			return nil
+		}
+
+		var keys [][]byte
+		err := articlesB.ForEach(func(k, v []byte) error {
+			var article types.Article
+			err := json.Unmarshal(v, &article)
+			if err != nil {
+				return err
+			}
+
+			if article.Publisher == source {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// bucket should not be modified, while it is iterated by ForEach
+		for _, k := range keys {
+			err = articlesB.Delete(k)
+			if err != nil {
+				return err
+			}
+			_, id, _ := bytes.Cut(k, []byte(keySeparator))
+			err = idsB.Delete(id)
+			if err != nil {
+				return err
+			}
+		}
+
+		removed = len(keys)
+		return nil
+	})
+
+	return removed, err
+}
+
+// Stats counts stored articles
+func (s *BoltStore) Stats() (Stats, error) {
+	stats := Stats{
+		SourceToArticles: make(map[string]int),
+		DayToArticles:    make(map[string]int),
+	}
+
+	err := s.view(func(articlesB *bolt.Bucket) error {
+		return articlesB.ForEach(func(k, v []byte) error {
+			var article types.Article
+			err := json.Unmarshal(v, &article)
+			if err != nil {
+				return err
+			}
+
+			addToStats(&stats, article, keyDay(k))
+			return nil
+		})
+	})
+
+	return stats, err
+}
+
+// ModTime returns modification time of the database file
+func (s *BoltStore) ModTime() (time.Time, error) {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// Close closes the database, letting other processes open it
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// view runs read-only fn over the bucket with articles.
+// Missing bucket means there are no articles, so fn is not called.
+func (s *BoltStore) view(fn func(articlesB *bolt.Bucket) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		articlesB := tx.Bucket(articlesBucket)
+		if articlesB == nil {
+			return nil
+		}
+
+		return fn(articlesB)
+	})
+}
+
+// keyDay returns the day part of the article key
+func keyDay(key []byte) string {
+	day, _, _ := bytes.Cut(key, []byte(keySeparator))
+	return string(day)
+}
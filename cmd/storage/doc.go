@@ -0,0 +1,11 @@
+// Package storage is used to persist fetched articles and to retrieve them.
+//
+// Articles are stored through the ArticleStore interface, which has two implementations:
+// JsonStore keeps articles in JSON files, one per publication day (the original layout of the application),
+// and BoltStore keeps them in a single embedded database file.
+// Backend is selected by its name with New, so the server and the fetching job share the same storage.
+//
+// Articles are partitioned by the day of publication. If publication date can not be parsed,
+// the day of the first storing of the article is used instead, and it is kept, when the article is fetched again.
+// Every article is stored once: if its day is changed, it is moved to the new day.
+package storage
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"gogator/cmd/dedup"
+	"gogator/cmd/filters"
+	"gogator/cmd/search"
+	"gogator/cmd/types"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IndexedStore wraps an ArticleStore with a full-text index of stored articles, kept in memory.
+//
+// Articles stored through IndexedStore are added to the index right away. Articles can also be changed
+// by other processes, e.g. the fetching job, so before searching the index is rebuilt,
+// if ModTime of the store is changed since the index was built.
+type IndexedStore struct {
+	ArticleStore
+
+	mu      sync.Mutex
+	index   *search.Index
+	indexed bool
+	modTime time.Time
+}
+
+// NewIndexedStore creates IndexedStore over the store. The index is built on the first search.
+func NewIndexedStore(store ArticleStore) *IndexedStore {
+	return &IndexedStore{
+		ArticleStore: store,
+		index:        search.NewIndex(),
+	}
+}
+
+// Upsert stores articles and adds them to the index
+func (s *IndexedStore) Upsert(articles []types.Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	articles = dedup.Deduplicate(articles)
+	return s.write(func() error {
+		err := s.ArticleStore.Upsert(articles)
+		if err != nil {
+			return err
+		}
+
+		if s.indexed {
+			s.index.Add(articles...)
+		}
+		return nil
+	})
+}
+
+// DeleteBySource removes articles of the publisher from the store.
+// Removing is rare, so the index is rebuilt on the next search.
+func (s *IndexedStore) DeleteBySource(source string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.indexed = false
+	return s.ArticleStore.DeleteBySource(source)
+}
+
+// Search filters stored articles by params, and ranks them by relevance to terms of the keywords query
+func (s *IndexedStore) Search(params *types.FilteringParams) ([]types.Article, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.refresh(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = &types.FilteringParams{}
+	}
+
+	query, err := filters.ParseQuery(params.Keywords, params.MatchMode)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.ArticleStore.Query(params)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := s.index.Score(strings.Join(filters.Terms(query), " "))
+	return search.Rank(articles, scores), nil
+}
+
+// Refresh builds the index, if it is not up-to-date, so the next search does not wait for it.
+// If ctx is cancelled, while stored articles are read, the index is not built.
+func (s *IndexedStore) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	return s.refresh(ctx)
+}
+
+// write runs fn, which changes the store. If the index was up-to-date before the change,
+// it is considered up-to-date after the change as well, since fn updates it.
+// Caller should hold the lock.
+func (s *IndexedStore) write(fn func() error) error {
+	before, err := s.ArticleStore.ModTime()
+	if err != nil {
+		return err
+	}
+	upToDate := s.indexed && before.Equal(s.modTime)
+
+	err = fn()
+	if err != nil {
+		s.indexed = false
+		return err
+	}
+
+	if !upToDate {
+		s.indexed = false
+		return nil
+	}
+
+	s.modTime, err = s.ArticleStore.ModTime()
+	return err
+}
+
+// refresh rebuilds the index from all stored articles, if the store was changed since the index was built.
+// Caller should hold the lock.
+func (s *IndexedStore) refresh(ctx context.Context) error {
+	modTime, err := s.ArticleStore.ModTime()
+	if err != nil {
+		return err
+	}
+
+	if s.indexed && modTime.Equal(s.modTime) {
+		return nil
+	}
+
+	articles, err := s.ArticleStore.Query(nil)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	s.index = search.NewIndex()
+	s.index.Add(articles...)
+	s.indexed = true
+	s.modTime = modTime
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"testing"
+	"time"
+)
+
+func TestIndexedStore_Search(t *testing.T) {
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			indexed := NewIndexedStore(store)
+
+			err := indexed.Upsert([]types.Article{
+				{Title: "UK election called", PubDate: "2024-07-19", Publisher: "bbc", Link: "https://bbc.com/1"},
+				{Title: "Weather", Description: "Rain on the day of the election", PubDate: "2024-07-20",
+					Publisher: "abc", Link: "https://abc.com/1"},
+				{Title: "Football results", PubDate: "2024-07-20", Publisher: "bbc", Link: "https://bbc.com/2"},
+			})
+			assert.Nil(t, err)
+
+			articles, err := indexed.Search(&types.FilteringParams{Keywords: "election"})
+			assert.Nil(t, err)
+			assert.Len(t, articles, 2)
+			assert.Equal(t, "UK election called", articles[0].Title)
+			assert.Greater(t, articles[0].Score, articles[1].Score)
+
+			articles, err = indexed.Search(&types.FilteringParams{Keywords: "election", Sources: "abc"})
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"Weather"}, titles(articles))
+
+			// articles added after the index was built are found
+			err = indexed.Upsert([]types.Article{
+				{Title: "Local election results", PubDate: "2024-07-21", Publisher: "abc", Link: "https://abc.com/2"},
+			})
+			assert.Nil(t, err)
+
+			articles, err = indexed.Search(&types.FilteringParams{Keywords: "election"})
+			assert.Nil(t, err)
+			assert.Len(t, articles, 3)
+
+			articles, err = indexed.Search(&types.FilteringParams{Keywords: "election -results"})
+			assert.Nil(t, err)
+			assert.Len(t, articles, 2)
+
+			_, err = indexed.DeleteBySource("abc")
+			assert.Nil(t, err)
+
+			articles, err = indexed.Search(&types.FilteringParams{Keywords: "election"})
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"UK election called"}, titles(articles))
+		})
+	}
+}
+
+func TestIndexedStore_SearchChangedByAnotherStore(t *testing.T) {
+	dir := t.TempDir()
+	indexed := NewIndexedStore(NewJsonStore(dir))
+
+	articles, err := indexed.Search(&types.FilteringParams{Keywords: "election"})
+	assert.Nil(t, err)
+	assert.Empty(t, articles)
+
+	// modification time of the directory may have coarse resolution
+	time.Sleep(10 * time.Millisecond)
+
+	// e.g. the fetching job, which runs in another process
+	err = NewJsonStore(dir).Upsert([]types.Article{
+		{Title: "Local election results", PubDate: "2024-07-21", Publisher: "abc", Link: "https://abc.com/2"},
+	})
+	assert.Nil(t, err)
+
+	articles, err = indexed.Search(&types.FilteringParams{Keywords: "election"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Local election results"}, titles(articles))
+}
+
+func TestIndexedStore_Refresh(t *testing.T) {
+	store := NewJsonStore(t.TempDir())
+	err := store.Upsert([]types.Article{
+		{Title: "Local election results", PubDate: "2024-07-21", Publisher: "abc", Link: "https://abc.com/2"},
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	indexed := NewIndexedStore(store)
+	assert.ErrorIs(t, indexed.Refresh(ctx), context.Canceled)
+	assert.False(t, indexed.indexed, "index is not built, when ctx is cancelled")
+
+	assert.Nil(t, indexed.Refresh(context.Background()))
+	assert.True(t, indexed.indexed)
+}
@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"gogator/cmd/dedup"
+	"gogator/cmd/fsutil"
+	"gogator/cmd/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jsonExtension is the extension of files with articles
+	jsonExtension = ".json"
+
+	// articlesFilePermissions are file permissions of files with articles
+	articlesFilePermissions = 0644
+)
+
+// JsonStore keeps articles in JSON files named after the day of publication: <dir>/YYYY-MM-DD.json.
+//
+// Every file is rewritten as a whole, so the store is suitable for small amounts of data.
+// Files are replaced atomically, so readers in other processes never see partially written files.
+type JsonStore struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewJsonStore creates a store, which keeps files with articles inside of dir
+func NewJsonStore(dir string) *JsonStore {
+	return &JsonStore{
+		dir: dir,
+	}
+}
+
+// Upsert merges articles into files of their days.
+//
+// Article is stored once, even if its day is changed: it is removed from the file of the previous day.
+// All files are read for it, since the day of the stored article is not known in advance.
+func (s *JsonStore) Upsert(articles []types.Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idToStored, err := s.storedArticles()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	dayToArticles := make(map[string][]types.Article)
+	dayToRemoved := make(map[string]map[string]bool)
+	for _, article := range dedup.Deduplicate(articles) {
+		article.NormalizePubDate(now)
+
+		stored, exists := idToStored[article.ID]
+		if exists {
+			keepIngestDate(&article, stored.article)
+		}
+
+		day := articleDay(article, now)
+		dayToArticles[day] = append(dayToArticles[day], article)
+
+		if exists && stored.day != day {
+			if dayToRemoved[stored.day] == nil {
+				dayToRemoved[stored.day] = make(map[string]bool)
+			}
+			dayToRemoved[stored.day][article.ID] = true
+		}
+	}
+
+	changedDays := make(map[string]bool, len(dayToArticles)+len(dayToRemoved))
+	for day := range dayToArticles {
+		changedDays[day] = true
+	}
+	for day := range dayToRemoved {
+		changedDays[day] = true
+	}
+
+	for day := range changedDays {
+		stored, err := s.readDay(day)
+		if err != nil {
+			return err
+		}
+
+		var kept []types.Article
+		for _, article := range dedup.Deduplicate(stored) {
+			if !dayToRemoved[day][article.ID] {
+				kept = append(kept, article)
+			}
+		}
+
+		err = s.writeDay(day, dedup.Merge(kept, dayToArticles[day]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Query reads files of days inside of the date range, and filters their articles by params
+func (s *JsonStore) Query(params *types.FilteringParams) ([]types.Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	first, last, err := dayRange(params)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.days()
+	if err != nil {
+		return nil, err
+	}
+
+	var articles []types.Article
+	for _, day := range days {
+		if !inRange(day, first, last) {
+			continue
+		}
+
+		stored, err := s.readDay(day)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, stored...)
+	}
+
+	return applyParams(dedup.Deduplicate(articles), params), nil
+}
+
+// DeleteBySource rewrites files, which contain articles of the publisher
+func (s *JsonStore) DeleteBySource(source string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.days()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, day := range days {
+		stored, err := s.readDay(day)
+		if err != nil {
+			return removed, err
+		}
+
+		var kept []types.Article
+		for _, article := range stored {
+			if article.Publisher != source {
+				kept = append(kept, article)
+			}
+		}
+
+		if len(kept) == len(stored) {
+			continue
+		}
+
+		err = s.writeDay(day, kept)
+		if err != nil {
+			return removed, err
+		}
+		removed += len(stored) - len(kept)
+	}
+
+	return removed, nil
+}
+
+// Stats counts articles in all files
+func (s *JsonStore) Stats() (Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := Stats{
+		SourceToArticles: make(map[string]int),
+		DayToArticles:    make(map[string]int),
+	}
+
+	days, err := s.days()
+	if err != nil {
+		return stats, err
+	}
+
+	for _, day := range days {
+		stored, err := s.readDay(day)
+		if err != nil {
+			return stats, err
+		}
+
+		for _, article := range stored {
+			addToStats(&stats, article, day)
+		}
+	}
+
+	return stats, nil
+}
+
+// ModTime returns modification time of the directory: every change of articles renames a file inside of it
+func (s *JsonStore) ModTime() (time.Time, error) {
+	info, err := os.Stat(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// storedArticle is the article, which is stored in the file of the day
+type storedArticle struct {
+	article types.Article
+	day     string
+}
+
+// storedArticles maps IDs of all stored articles to them and to their days
+func (s *JsonStore) storedArticles() (map[string]storedArticle, error) {
+	days, err := s.days()
+	if err != nil {
+		return nil, err
+	}
+
+	idToStored := make(map[string]storedArticle)
+	for _, day := range days {
+		stored, err := s.readDay(day)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, article := range dedup.Deduplicate(stored) {
+			idToStored[article.ID] = storedArticle{article: article, day: day}
+		}
+	}
+
+	return idToStored, nil
+}
+
+// days returns sorted days, which have files with articles.
+// Other JSON files in the directory, e.g. sources.json, are skipped.
+func (s *JsonStore) days() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var days []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, jsonExtension) {
+			continue
+		}
+
+		day := strings.TrimSuffix(name, jsonExtension)
+		if _, err := time.Parse(dayLayout, day); err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+
+	sort.Strings(days)
+	return days, nil
+}
+
+// readDay returns articles from the file of the day. Missing file means there are no articles.
+func (s *JsonStore) readDay(day string) ([]types.Article, error) {
+	data, err := os.ReadFile(s.dayFilepath(day))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+
+	var articles []types.Article
+	err = json.Unmarshal(data, &articles)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// writeDay replaces the file of the day with articles.
+// Data is written to a temporary file first, which is then renamed.
+func (s *JsonStore) writeDay(day string, articles []types.Article) error {
+	if articles == nil {
+		articles = []types.Article{}
+	}
+
+	data, err := json.Marshal(articles)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFile(s.dayFilepath(day), data, articlesFilePermissions)
+}
+
+// Close implements ArticleStore. Files are not kept open, so there is nothing to release.
+func (s *JsonStore) Close() error {
+	return nil
+}
+
+// dayFilepath returns path to the file of the day
+func (s *JsonStore) dayFilepath(day string) string {
+	return filepath.Join(s.dir, day+jsonExtension)
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJsonStore_ExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	// files written before the store was introduced have no article IDs
+	err := os.WriteFile(filepath.Join(dir, "2024-07-19.json"),
+		[]byte(`[{"title":"Article on 2024-07-19","publishedAt":"2024-07-19","url":"https://bbc.com/1"}]`), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "2024-07-20.json"), []byte(""), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "sources.json"), []byte(`[{"name":"bbc"}]`), 0644)
+	assert.NoError(t, err)
+
+	store := NewJsonStore(dir)
+
+	got, err := store.Query(&types.FilteringParams{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "Article on 2024-07-19", got[0].Title)
+	assert.NotEmpty(t, got[0].ID)
+
+	err = store.Upsert([]types.Article{
+		{Title: "Article on 2024-07-19", PubDate: "2024-07-19", Link: "https://bbc.com/1"},
+		{Title: "Another article on 2024-07-19", PubDate: "2024-07-19", Link: "https://bbc.com/2"},
+	})
+	assert.NoError(t, err)
+
+	got, err = store.Query(&types.FilteringParams{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Another article on 2024-07-19", "Article on 2024-07-19"}, titles(got))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3, "temporary files should be removed")
+}
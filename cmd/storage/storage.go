@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/filters"
+	"gogator/cmd/types"
+	"time"
+)
+
+const (
+	// JsonBackend stores articles in JSON files, one file per day
+	JsonBackend = "json"
+
+	// BoltBackend stores articles in a single embedded bbolt database
+	BoltBackend = "bolt"
+
+	// DefaultBackend is used, when backend is not specified
+	DefaultBackend = JsonBackend
+
+	// dayLayout is the layout of days, which articles are partitioned by
+	dayLayout = time.DateOnly
+)
+
+// ErrUnknownBackend is returned, when there is no store with the requested backend name
+var ErrUnknownBackend = errors.New("unknown storage backend")
+
+// ArticleStore persists articles and retrieves them.
+//
+// Implementations are safe for concurrent use.
+type ArticleStore interface {
+	// Upsert stores articles. Articles with IDs, which are already stored, are replaced.
+	// Publication dates, which are not normalized yet, are normalized (see types.Article.NormalizePubDate).
+	Upsert(articles []types.Article) error
+
+	// Query returns stored articles, filtered by params: date range, sources and keywords.
+	// Empty params return all stored articles.
+	Query(params *types.FilteringParams) ([]types.Article, error)
+
+	// DeleteBySource removes all articles of the publisher, and returns amount of removed articles
+	DeleteBySource(source string) (int, error)
+
+	// Stats returns amount of stored articles
+	Stats() (Stats, error)
+
+	// ModTime returns time of the last change of stored articles, made by any process.
+	// Zero time is returned, if nothing was stored yet.
+	ModTime() (time.Time, error)
+
+	// Close releases resources of the store. The store can not be used after it.
+	Close() error
+}
+
+// SearchableStore is an ArticleStore, which also ranks articles by relevance to keywords
+type SearchableStore interface {
+	ArticleStore
+
+	// Search returns stored articles, filtered by params like Query. Articles are ordered from the most relevant
+	// to the terms of the keywords query, and have their scores set.
+	Search(params *types.FilteringParams) ([]types.Article, error)
+}
+
+// Stats describes stored articles.
+// It has several fields:
+// /  1. TotalArticles    - Amount of stored articles
+// /  2. SourceToArticles - Amount of stored articles of every publisher
+// /  3. DayToArticles    - Amount of stored articles of every publication day
+// /  4. FirstDay         - Earliest day, which has stored articles
+// /  5. LastDay          - Latest day, which has stored articles
+type Stats struct {
+	TotalArticles    int            `json:"totalArticles"`
+	SourceToArticles map[string]int `json:"sources"`
+	DayToArticles    map[string]int `json:"days"`
+	FirstDay         string         `json:"firstDay,omitempty"`
+	LastDay          string         `json:"lastDay,omitempty"`
+}
+
+// New creates the store of the backend, which keeps data inside of dir.
+// Empty backend means DefaultBackend. The store should be closed with Close.
+func New(backend, dir string) (ArticleStore, error) {
+	switch backend {
+	case JsonBackend, "":
+		return NewJsonStore(dir), nil
+	case BoltBackend:
+		store, err := NewBoltStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s. Supported backends are: %v", ErrUnknownBackend, backend,
+		[]string{BoltBackend, JsonBackend})
+}
+
+// articleDay returns the day, which article is stored in: the day of publication in UTC,
+// or the day of storing, if publication date is not normalized
+func articleDay(article types.Article, now time.Time) string {
+	if article.PublishedAt.IsZero() {
+		return now.UTC().Format(dayLayout)
+	}
+
+	return article.PublishedAt.UTC().Format(dayLayout)
+}
+
+// keepIngestDate keeps the publication date of the stored version of the article, if the publication date
+// of the article can still not be parsed. Otherwise, the time of every ingest would be used instead of it,
+// and the article would be moved to the day of the last fetching.
+func keepIngestDate(article *types.Article, stored types.Article) {
+	if article.InvalidPubDate && stored.InvalidPubDate {
+		article.PublishedAt = stored.PublishedAt
+	}
+}
+
+// dayRange returns the first and the last day of the date range of params, in UTC like stored days.
+// Empty day means the range is not bounded from that side.
+func dayRange(params *types.FilteringParams) (string, string, error) {
+	if params == nil {
+		return "", "", nil
+	}
+
+	from, to, err := filters.DateRange(params, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+
+	var first, last string
+	if !from.IsZero() {
+		first = from.UTC().Format(dayLayout)
+	}
+	if !to.IsZero() {
+		last = to.UTC().Format(dayLayout)
+	}
+
+	return first, last, nil
+}
+
+// inRange reports whether the day is between the first and the last days. Bounds are inclusive and optional.
+func inRange(day, first, last string) bool {
+	if first != "" && day < first {
+		return false
+	}
+	if last != "" && day > last {
+		return false
+	}
+
+	return true
+}
+
+// addToStats counts the article of the day in stats
+func addToStats(stats *Stats, article types.Article, day string) {
+	stats.TotalArticles++
+	stats.SourceToArticles[article.Publisher]++
+	stats.DayToArticles[day]++
+
+	if stats.FirstDay == "" || day < stats.FirstDay {
+		stats.FirstDay = day
+	}
+	if day > stats.LastDay {
+		stats.LastDay = day
+	}
+}
+
+// applyParams filters articles by params. Nil params leave articles as they are.
+func applyParams(articles []types.Article, params *types.FilteringParams) []types.Article {
+	if params == nil {
+		return articles
+	}
+
+	return filters.Apply(articles, params)
+}
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/types"
+	"sort"
+	"testing"
+	"time"
+)
+
+// testStores returns stores of all backends, which keep data in a temporary directory
+func testStores(t *testing.T) map[string]ArticleStore {
+	boltStore, err := NewBoltStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { boltStore.Close() })
+
+	return map[string]ArticleStore{
+		JsonBackend: NewJsonStore(t.TempDir()),
+		BoltBackend: boltStore,
+	}
+}
+
+// titles returns sorted titles of articles
+func titles(articles []types.Article) []string {
+	var result []string
+	for _, article := range articles {
+		result = append(result, article.Title)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name         string
+		backend      string
+		expectedType ArticleStore
+		expectedErr  error
+	}{
+		{
+			name:         "Default backend",
+			backend:      "",
+			expectedType: &JsonStore{},
+		},
+		{
+			name:         "Json backend",
+			backend:      JsonBackend,
+			expectedType: &JsonStore{},
+		},
+		{
+			name:         "Bolt backend",
+			backend:      BoltBackend,
+			expectedType: &BoltStore{},
+		},
+		{
+			name:        "Unknown backend",
+			backend:     "yaml",
+			expectedErr: ErrUnknownBackend,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := New(tt.backend, t.TempDir())
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.IsType(t, tt.expectedType, store)
+			assert.NoError(t, store.Close())
+		})
+	}
+}
+
+func TestArticleStore(t *testing.T) {
+	articles := []types.Article{
+		{Title: "BBC on 19th", PubDate: "Fri, 19 Jul 2024 10:00:00 GMT", Publisher: "bbc", Link: "https://bbc.com/1"},
+		{Title: "ABC on 20th", PubDate: "Sat, 20 Jul 2024 15:00:00 GMT", Publisher: "abc", Link: "https://abc.com/1"},
+		{
+			Title: "BBC on 21st about Ukraine", PubDate: "2024-07-21", Publisher: "bbc", Link: "https://bbc.com/2",
+			Author: "John Doe", Categories: []string{"World", "Europe"}, ImageUrl: "https://bbc.com/2.jpg",
+			Content: "<p>Full story</p>", Language: "en-gb", Guid: "bbc-2",
+		},
+	}
+
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			got, err := store.Query(&types.FilteringParams{})
+			assert.NoError(t, err)
+			assert.Empty(t, got)
+
+			err = store.Upsert(articles)
+			assert.NoError(t, err)
+
+			// repeated article is stored once, and its newer version replaces the stored one
+			err = store.Upsert([]types.Article{
+				{Title: "BBC on 19th, updated", PubDate: "2024-07-19", Publisher: "bbc", Link: "https://bbc.com/1"},
+			})
+			assert.NoError(t, err)
+
+			got, err = store.Query(&types.FilteringParams{})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"ABC on 20th", "BBC on 19th, updated", "BBC on 21st about Ukraine"}, titles(got))
+
+			got, err = store.Query(&types.FilteringParams{StartingTimestamp: "2024-07-20", EndingTimestamp: "2024-07-20"})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"ABC on 20th"}, titles(got))
+
+			// time of the day is interpreted in the time zone: 02:00 in Kyiv is 23:00 of the previous day in UTC
+			got, err = store.Query(&types.FilteringParams{StartingTimestamp: "2024-07-21T02:00", Timezone: "Europe/Kyiv"})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"BBC on 21st about Ukraine"}, titles(got))
+
+			got, err = store.Query(&types.FilteringParams{Sources: "bbc", Keywords: "Ukraine"})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"BBC on 21st about Ukraine"}, titles(got))
+
+			// optional fields are persisted, and articles can be filtered by them
+			got, err = store.Query(&types.FilteringParams{Categories: "europe", Authors: "doe"})
+			assert.NoError(t, err)
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, "John Doe", got[0].Author)
+				assert.Equal(t, []string{"World", "Europe"}, got[0].Categories)
+				assert.Equal(t, "https://bbc.com/2.jpg", got[0].ImageUrl)
+				assert.Equal(t, "<p>Full story</p>", got[0].Content)
+				assert.Equal(t, "en-gb", got[0].Language)
+				assert.Equal(t, "bbc-2", got[0].Guid)
+			}
+
+			stats, err := store.Stats()
+			assert.NoError(t, err)
+			assert.Equal(t, Stats{
+				TotalArticles:    3,
+				SourceToArticles: map[string]int{"bbc": 2, "abc": 1},
+				DayToArticles:    map[string]int{"2024-07-19": 1, "2024-07-20": 1, "2024-07-21": 1},
+				FirstDay:         "2024-07-19",
+				LastDay:          "2024-07-21",
+			}, stats)
+
+			removed, err := store.DeleteBySource("bbc")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, removed)
+
+			got, err = store.Query(&types.FilteringParams{})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"ABC on 20th"}, titles(got))
+
+			removed, err = store.DeleteBySource("bbc")
+			assert.NoError(t, err)
+			assert.Equal(t, 0, removed)
+		})
+	}
+}
+
+func TestArticleStore_ChangedDay(t *testing.T) {
+	ingestedAt := time.Date(2024, 7, 19, 10, 0, 0, 0, time.UTC)
+
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			err := store.Upsert([]types.Article{
+				{Title: "Undated", PubDate: "yesterday", Publisher: "bbc", Link: "https://bbc.com/undated",
+					PublishedAt: ingestedAt, InvalidPubDate: true},
+				{Title: "Moved", PubDate: "2024-07-19", Publisher: "abc", Link: "https://abc.com/moved"},
+			})
+			assert.NoError(t, err)
+
+			// fetched again: undated article keeps the day of its first ingest,
+			// and the article with the changed date is moved to the new day
+			err = store.Upsert([]types.Article{
+				{Title: "Undated", PubDate: "yesterday", Publisher: "bbc", Link: "https://bbc.com/undated"},
+				{Title: "Moved", PubDate: "2024-07-20", Publisher: "abc", Link: "https://abc.com/moved"},
+			})
+			assert.NoError(t, err)
+
+			stats, err := store.Stats()
+			assert.NoError(t, err)
+			assert.Equal(t, 2, stats.TotalArticles)
+			assert.Equal(t, map[string]int{"2024-07-19": 1, "2024-07-20": 1}, stats.DayToArticles)
+
+			got, err := store.Query(&types.FilteringParams{Sources: "bbc"})
+			assert.NoError(t, err)
+			if assert.Len(t, got, 1) {
+				assert.True(t, ingestedAt.Equal(got[0].PublishedAt))
+				assert.True(t, got[0].InvalidPubDate)
+			}
+		})
+	}
+}
+
+func TestArticleDay(t *testing.T) {
+	now := time.Date(2024, 7, 23, 23, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		pubDate  string
+		expected string
+	}{
+		{
+			name:     "RFC 1123 date",
+			pubDate:  "Mon, 22 Jul 2024 10:00:00 GMT",
+			expected: "2024-07-22",
+		},
+		{
+			name:     "Date with time zone is converted to UTC",
+			pubDate:  "2024-07-22T01:00:00+03:00",
+			expected: "2024-07-21",
+		},
+		{
+			name:     "Unparsable date",
+			pubDate:  "yesterday",
+			expected: "2024-07-23",
+		},
+		{
+			name:     "Empty date",
+			pubDate:  "",
+			expected: "2024-07-23",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			article := types.Article{PubDate: tt.pubDate}
+			article.NormalizePubDate(now)
+
+			assert.Equal(t, tt.expected, articleDay(article, now))
+		})
+	}
+}
+
+func TestBoltStore_Close(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewBoltStore(dir)
+	assert.NoError(t, err)
+	err = store.Upsert([]types.Article{{Title: "Stored", PubDate: "2024-07-19", Publisher: "bbc"}})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Close())
+
+	reopened, err := NewBoltStore(dir)
+	assert.NoError(t, err, "closed database can be opened again")
+	defer reopened.Close()
+
+	got, err := reopened.Query(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Stored"}, titles(got))
+}
@@ -2,6 +2,8 @@ package templates
 
 import (
 	"fmt"
+	"gogator/cmd/filters"
+	"gogator/cmd/sorting"
 	"gogator/cmd/types"
 	"html/template"
 	"os"
@@ -17,6 +19,8 @@ var (
 		"formatDate": formatDate,
 		"contains":   contains,
 		"trim":       strings.TrimSpace,
+		"join":       strings.Join,
+		"inc":        inc,
 	}
 
 	BaseTemplatePath = filepath.Join("cmd", "templates", "templates", "article.plain.tmpl")
@@ -24,44 +28,46 @@ var (
 
 const (
 	BaseTemplate = "article.plain.tmpl"
+
+	// highlightMark surrounds highlighted keywords
+	highlightMark = "[!]"
 )
 
-func PrintTemplate(f *types.FilteringParams, articles []types.Article) error {
-	sortNewsByPubDate(articles)
+// PrintTemplate displays articles, applied filters and fetching results of sources in the terminal.
+// Articles are sorted in the order of the filtering params, the same way the server sorts them.
+func PrintTemplate(f *types.FilteringParams, articles []types.Article, results []types.FetchResult) error {
+	sorting.Sort(articles, f.Sort)
 
-	cwdPath, err := os.Getwd()
+	cwdPath, err := templatePath(BaseTemplatePath)
 	if err != nil {
 		return err
 	}
 
-	for strings.Contains(cwdPath, "cmd") {
-		cwdPath = filepath.Dir(cwdPath)
-	}
-	cwdPath = filepath.Join(cwdPath, BaseTemplatePath)
-
 	tmpl := template.Must(template.New(BaseTemplate).Funcs(templateFuncs).ParseFiles(cwdPath))
 
 	for i := 0; i <= len(articles)-1; i++ {
 		articles[i] = types.Article{
-			Title:       strings.TrimSpace(articles[i].Title),
-			Description: strings.TrimSpace(articles[i].Description),
-			PubDate:     articles[i].PubDate,
-			Publisher:   articles[i].PubDate,
-			Link:        articles[i].Link,
+			Title:          strings.TrimSpace(articles[i].Title),
+			Description:    strings.TrimSpace(articles[i].Description),
+			PublishedAt:    articles[i].PublishedAt,
+			PubDate:        articles[i].PubDate,
+			InvalidPubDate: articles[i].InvalidPubDate,
+			Publisher:      articles[i].Publisher,
+			Link:           articles[i].Link,
+			Author:         strings.TrimSpace(articles[i].Author),
+			Categories:     articles[i].Categories,
+			ImageUrl:       articles[i].ImageUrl,
+			Language:       articles[i].Language,
+			Score:          articles[i].Score,
 		}
 	}
 
 	data := types.TemplateData{
-		NewsItems:  articles,
-		FilterInfo: "Applied Filters: " + fmt.Sprintf("%v", f),
-		TotalItems: len(articles),
-		Keywords:   strings.Split(f.Keywords, ","),
-	}
-
-	for i, v := range data.Keywords {
-		if v == "" {
-			data.Keywords = append(data.Keywords[:i], data.Keywords[i+1:]...)
-		}
+		NewsItems:    articles,
+		FilterInfo:   "Applied Filters: " + fmt.Sprintf("%v", f),
+		TotalItems:   len(articles),
+		Keywords:     highlightedTerms(f.Keywords, f.MatchMode),
+		FetchResults: results,
 	}
 
 	err = tmpl.Execute(os.Stdout, data)
@@ -72,16 +78,33 @@ func PrintTemplate(f *types.FilteringParams, articles []types.Article) error {
 	return nil
 }
 
-// Custom function to highlight keywords
-func highlight(content string, keywords []string) string {
-	if keywords == nil {
-		return content
+// templatePath returns the absolute path of the template, relative to the root of the project
+func templatePath(path string) (string, error) {
+	cwdPath, err := os.Getwd()
+	if err != nil {
+		return "", err
 	}
 
-	for _, keyword := range keywords {
-		content = strings.ReplaceAll(content, keyword, "[!]"+keyword+"[!]")
+	for strings.Contains(cwdPath, "cmd") {
+		cwdPath = filepath.Dir(cwdPath)
 	}
-	return content
+
+	return filepath.Join(cwdPath, path), nil
+}
+
+// highlightedTerms returns matchers of the keywords query terms, which should be highlighted in articles
+func highlightedTerms(keywords, matchMode string) []types.TermMatcher {
+	query, err := filters.ParseQuery(keywords, matchMode)
+	if err != nil {
+		return nil
+	}
+
+	return filters.Matchers(query)
+}
+
+// Custom function to highlight keywords, matched according to the match mode
+func highlight(content string, keywords []types.TermMatcher) string {
+	return filters.Highlight(content, keywords, highlightMark)
 }
 
 // Custom function to format date
@@ -89,6 +112,11 @@ func formatDate(t time.Time, layout string) string {
 	return t.Format(layout)
 }
 
+// Custom function to number items starting from one
+func inc(i int) int {
+	return i + 1
+}
+
 func contains(s string, arr []string) bool {
 	for _, keyword := range arr {
 		if strings.Contains(s, keyword) {
@@ -1,7 +1,10 @@
 package templates
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/filters"
+	"gogator/cmd/trends"
 	"gogator/cmd/types"
 	"testing"
 	"time"
@@ -9,19 +12,32 @@ import (
 
 func TestHighlight(t *testing.T) {
 	tests := []struct {
-		content  string
-		keywords []string
-		expected string
+		content   string
+		keywords  []string
+		matchMode string
+		expected  string
 	}{
-		{"hello world", []string{"world"}, "hello [!]world[!]"},
-		{"hello world", []string{"hello", "world"}, "[!]hello[!] [!]world[!]"},
-		{"hello world", nil, "hello world"},
-		{"", []string{"world"}, ""},
-		{"hello", []string{"world"}, "hello"},
+		{"hello world", []string{"world"}, types.ExactMatch, "hello [!]world[!]"},
+		{"hello world", []string{"hello", "world"}, types.ExactMatch, "[!]hello[!] [!]world[!]"},
+		{"hello world", nil, types.ExactMatch, "hello world"},
+		{"", []string{"world"}, types.ExactMatch, ""},
+		{"hello", []string{"world"}, types.ExactMatch, "hello"},
+		{"Hello World", []string{"world"}, types.ExactMatch, "Hello World"},
+		{"Hello World", []string{"world"}, types.CaseInsensitiveMatch, "Hello [!]World[!]"},
+		{"art and artists", []string{"art"}, types.WholeWordMatch, "[!]art[!] and artists"},
+		{"COVID19 and COVID-19", []string{"COVID-?19"}, types.RegexMatch, "[!]COVID19[!] and [!]COVID-19[!]"},
+		{"hello world", []string{"hello wo", "lo world"}, types.ExactMatch, "[!]hello world[!]"},
 	}
 
 	for _, test := range tests {
-		result := highlight(test.content, test.keywords)
+		var matchers []types.TermMatcher
+		for _, keyword := range test.keywords {
+			matcher, err := filters.NewMatcher(keyword, test.matchMode)
+			assert.NoError(t, err)
+			matchers = append(matchers, matcher)
+		}
+
+		result := highlight(test.content, matchers)
 		if result != test.expected {
 			t.Errorf("highlight(%q, %v) = %q; want %q", test.content, test.keywords, result, test.expected)
 		}
@@ -76,6 +92,7 @@ func TestPrintTemplate(t *testing.T) {
 		Input struct {
 			Filters  *types.FilteringParams
 			Articles []types.Article
+			Results  []types.FetchResult
 		}
 	}{
 		{
@@ -83,6 +100,7 @@ func TestPrintTemplate(t *testing.T) {
 			Input: struct {
 				Filters  *types.FilteringParams
 				Articles []types.Article
+				Results  []types.FetchResult
 			}{
 				Filters: types.NewFilteringParams("", "", "", ""),
 				Articles: []types.Article{
@@ -95,13 +113,34 @@ func TestPrintTemplate(t *testing.T) {
 						Description: "Description 2",
 					},
 				},
+				Results: []types.FetchResult{
+					types.NewFetchResult("abc", 2, time.Second, nil),
+					types.NewFetchResult("bbc", 0, time.Second, errors.New("connection refused")),
+				},
 			},
 		},
 	}
 
 	for _, tt := range testCases {
-		err := PrintTemplate(tt.Input.Filters, tt.Input.Articles)
+		err := PrintTemplate(tt.Input.Filters, tt.Input.Articles, tt.Input.Results)
 
 		assert.Nil(t, err)
 	}
 }
+
+func TestPrintTrends(t *testing.T) {
+	now := time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+	params := trends.Params{Window: 6 * time.Hour, Baseline: 7 * 24 * time.Hour, Now: now}
+
+	trendList := []trends.Trend{
+		{
+			Term:     "storm",
+			Count:    2,
+			Score:    1.414,
+			Articles: []trends.Example{{Title: "Storm hits the coast", Publisher: "bbc", PublishedAt: now}},
+		},
+	}
+
+	assert.Nil(t, PrintTrends(params, 3, trendList, nil))
+	assert.Nil(t, PrintTrends(params, 0, nil, []types.FetchResult{types.NewFetchResult("abc", 0, time.Second, nil)}))
+}
@@ -0,0 +1,40 @@
+package templates
+
+import (
+	"gogator/cmd/trends"
+	"gogator/cmd/types"
+	"html/template"
+	"os"
+	"path/filepath"
+)
+
+const TrendsTemplate = "trends.plain.tmpl"
+
+var TrendsTemplatePath = filepath.Join("cmd", "templates", "templates", TrendsTemplate)
+
+// trendsData is a model, which is passed into the trends template
+type trendsData struct {
+	Params       trends.Params
+	Articles     int
+	Trends       []trends.Trend
+	FetchResults []types.FetchResult
+}
+
+// PrintTrends displays trending terms of articles, their examples and fetching results of sources in the terminal.
+// Amount of articles, which trends were computed from, is displayed in the header.
+func PrintTrends(params trends.Params, articles int, trendList []trends.Trend, results []types.FetchResult) error {
+	path, err := templatePath(TrendsTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	funcs := template.FuncMap{"formatDuration": trends.FormatDuration}
+	tmpl := template.Must(template.New(TrendsTemplate).Funcs(templateFuncs).Funcs(funcs).ParseFiles(path))
+
+	return tmpl.Execute(os.Stdout, trendsData{
+		Params:       params,
+		Articles:     articles,
+		Trends:       trendList,
+		FetchResults: results,
+	})
+}
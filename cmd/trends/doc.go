@@ -0,0 +1,8 @@
+// Package trends is used to find topics, which are trending in the news.
+//
+// Titles and descriptions of articles are split into terms by the search analyzer, and sequences
+// of capitalized words, e.g. "Federal Reserve", are collected as named entities. For every term,
+// amount of articles mentioning it in the recent window is compared with amount of articles, which
+// mentioned it during the baseline period before the window. Terms mentioned more often than the baseline
+// predicts are trending, and are ranked by how much they exceed the prediction.
+package trends
@@ -0,0 +1,374 @@
+package trends
+
+import (
+	"errors"
+	"fmt"
+	"gogator/cmd/filters"
+	"gogator/cmd/search"
+	"gogator/cmd/types"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultWindow is the period, which trending terms are computed for, if it is not specified
+	DefaultWindow = 6 * time.Hour
+
+	// DefaultBaseline is the period before the window, which predicts usual frequency of terms
+	DefaultBaseline = 7 * 24 * time.Hour
+
+	// DefaultLimit is amount of trends, which are returned, if it is not specified
+	DefaultLimit = 20
+
+	// MaxLimit is the largest amount of trends, which can be requested
+	MaxLimit = 100
+
+	// minCount is amount of articles in the window, starting from which the term can be trending.
+	// A term of a single article is not a trend, however rare it was before.
+	minCount = 2
+
+	// minTermLength is the length of the shortest word, which is considered a term
+	minTermLength = 3
+
+	// maxExamples is amount of the newest articles, which are returned with every trend
+	maxExamples = 3
+)
+
+// ErrInvalidLimit is returned, when limit is not a number from 1 to MaxLimit
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// Params configure computation of trends
+type Params struct {
+	// Window is the recent period, ending now, which trending terms are computed for
+	Window time.Duration
+
+	// Baseline is the period right before the window, which usual frequency of terms is computed for
+	Baseline time.Duration
+
+	// Limit is the maximum amount of returned trends
+	Limit int
+
+	// Now is the end of the window
+	Now time.Time
+}
+
+// Trend is a term, which is mentioned in the window more often than during the baseline.
+// It has several fields:
+// /  1. Term          - The most frequent form of the word, or the entity as it is written in articles
+// /  2. Entity        - Term is a named entity, e.g. "Federal Reserve", rather than a single word
+// /  3. Count         - Amount of articles of the window, which mention the term
+// /  4. BaselineCount - Amount of articles of the baseline period, which mention the term
+// /  5. Expected      - Amount of articles of the window, which would mention the term at the baseline rate
+// /  6. Score         - How much Count exceeds Expected, in standard deviations. Trends are ranked by it.
+// /  7. Articles      - The newest articles of the window, which mention the term
+type Trend struct {
+	Term          string    `json:"term"`
+	Entity        bool      `json:"entity,omitempty"`
+	Count         int       `json:"count"`
+	BaselineCount int       `json:"baselineCount"`
+	Expected      float64   `json:"expected"`
+	Score         float64   `json:"score"`
+	Articles      []Example `json:"articles"`
+}
+
+// Example is a short reference to the article, which mentions the trend
+type Example struct {
+	Title       string    `json:"title"`
+	Link        string    `json:"url"`
+	Publisher   string    `json:"Publisher"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+// NewParams parses window and baseline durations like "6h" or "7d", and limit of trends.
+// Empty values mean DefaultWindow, DefaultBaseline and DefaultLimit.
+func NewParams(window, baseline, limit string, now time.Time) (Params, error) {
+	params := Params{
+		Window:   DefaultWindow,
+		Baseline: DefaultBaseline,
+		Limit:    DefaultLimit,
+		Now:      now,
+	}
+
+	var err error
+	if window != "" {
+		params.Window, err = filters.ParseDuration(window)
+		if err != nil {
+			return Params{}, fmt.Errorf("window: %w", err)
+		}
+	}
+
+	if baseline != "" {
+		params.Baseline, err = filters.ParseDuration(baseline)
+		if err != nil {
+			return Params{}, fmt.Errorf("baseline: %w", err)
+		}
+	}
+
+	if limit != "" {
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil || params.Limit < 1 || params.Limit > MaxLimit {
+			return Params{}, fmt.Errorf("%w %q: expected a number from 1 to %d", ErrInvalidLimit, limit, MaxLimit)
+		}
+	}
+
+	return params, nil
+}
+
+// FormatDuration formats the duration the same way NewParams parses it: "30m", "6h", "7d" or "2w"
+func FormatDuration(duration time.Duration) string {
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+	)
+
+	switch {
+	case duration == 0:
+		return "0m"
+	case duration%week == 0:
+		return strconv.Itoa(int(duration/week)) + "w"
+	case duration%day == 0:
+		return strconv.Itoa(int(duration/day)) + "d"
+	case duration%time.Hour == 0:
+		return strconv.Itoa(int(duration/time.Hour)) + "h"
+	}
+
+	return strconv.Itoa(int(duration/time.Minute)) + "m"
+}
+
+// Since returns the start of the baseline period, so callers retrieve only articles, which are needed
+func (p Params) Since() time.Time {
+	return p.Now.Add(-p.Window - p.Baseline)
+}
+
+// termStats counts articles mentioning the term, and forms of the term as they are written
+type termStats struct {
+	entity        bool
+	count         int
+	baselineCount int
+	forms         map[string]int
+	articles      []types.Article
+}
+
+// Compute returns terms of articles, which are trending in the window of params, from the most trending one.
+// Articles without a valid publication date are ignored, since the time of ingest does not tell when they were news.
+func Compute(articles []types.Article, params Params) []Trend {
+	windowStart := params.Now.Add(-params.Window)
+	baselineStart := params.Since()
+
+	stats := make(map[string]*termStats)
+	for _, article := range articles {
+		if article.InvalidPubDate || article.PublishedAt.After(params.Now) ||
+			article.PublishedAt.Before(baselineStart) {
+			continue
+		}
+		inWindow := !article.PublishedAt.Before(windowStart)
+
+		for key, term := range articleTerms(article) {
+			s, exists := stats[key]
+			if !exists {
+				s = &termStats{entity: term.entity, forms: make(map[string]int)}
+				stats[key] = s
+			}
+
+			if !inWindow {
+				s.baselineCount++
+				continue
+			}
+
+			s.count++
+			s.forms[term.form]++
+			s.articles = append(s.articles, article)
+		}
+	}
+
+	trends := make([]Trend, 0)
+	for _, s := range stats {
+		if s.count < minCount {
+			continue
+		}
+
+		expected := 0.0
+		if params.Baseline > 0 {
+			expected = float64(s.baselineCount) * float64(params.Window) / float64(params.Baseline)
+		}
+
+		score := (float64(s.count) - expected) / math.Sqrt(expected+1)
+		if score <= 0 {
+			continue
+		}
+
+		trends = append(trends, Trend{
+			Term:          mostFrequentForm(s.forms),
+			Entity:        s.entity,
+			Count:         s.count,
+			BaselineCount: s.baselineCount,
+			Expected:      math.Round(expected*100) / 100,
+			Score:         math.Round(score*1000) / 1000,
+			Articles:      examples(s.articles),
+		})
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Score != trends[j].Score {
+			return trends[i].Score > trends[j].Score
+		}
+		if trends[i].Count != trends[j].Count {
+			return trends[i].Count > trends[j].Count
+		}
+		return trends[i].Term < trends[j].Term
+	})
+
+	if params.Limit > 0 && len(trends) > params.Limit {
+		trends = trends[:params.Limit]
+	}
+
+	return trends
+}
+
+// articleTerm is a form of the term, as it is written in the article
+type articleTerm struct {
+	form   string
+	entity bool
+}
+
+// articleTerms returns terms of title and description of the article by their keys.
+// Words are keyed by their stems, so "election" and "elections" are the same term,
+// and entities are keyed by their lower-cased words.
+func articleTerms(article types.Article) map[string]articleTerm {
+	terms := make(map[string]articleTerm)
+	text := article.Title + "\n" + article.Description
+
+	for _, token := range search.Tokenize(text, article.Language) {
+		if len([]rune(token.Word)) < minTermLength || !hasLetter(token.Word) {
+			continue
+		}
+		terms[token.Term] = articleTerm{form: token.Word}
+	}
+
+	for _, line := range []string{article.Title, article.Description} {
+		for _, entity := range entities(line) {
+			terms["entity:"+strings.ToLower(entity)] = articleTerm{form: entity, entity: true}
+		}
+	}
+
+	return terms
+}
+
+// entities returns sequences of two or more capitalized words of the text, e.g. "Federal Reserve".
+// Punctuation ends the sequence, and the stop-word starting the text is skipped. Texts in title case have no entities, since every word is capitalized there.
+func entities(text string) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	if isTitleCase(words) {
+		return nil
+	}
+
+	var (
+		found    []string
+		sequence []string
+	)
+	flush := func() {
+		if len(sequence) >= 2 {
+			found = append(found, strings.Join(sequence, " "))
+		}
+		sequence = nil
+	}
+
+	for i, word := range words {
+		trimmed := strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		if !isCapitalized(trimmed) {
+			flush()
+			continue
+		}
+
+		// stop-words like "The" start the text, rather than entities
+		if i == 0 && len(search.Analyze(trimmed)) == 0 {
+			continue
+		}
+
+		sequence = append(sequence, trimmed)
+		if trimmed != strings.TrimLeftFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			// the word is followed by punctuation, e.g. "Kyiv," so the entity ends here
+			flush()
+		}
+	}
+	flush()
+
+	return found
+}
+
+// isTitleCase reports whether every word of the text, except stop-words, is capitalized,
+// e.g. "Stocks Fall as Markets Open". Text should have at least two such words.
+func isTitleCase(words []string) bool {
+	significant := 0
+	for _, word := range words {
+		if len(search.Analyze(word)) == 0 {
+			continue
+		}
+
+		significant++
+		if !isCapitalized(word) {
+			return false
+		}
+	}
+
+	return significant >= 2
+}
+
+// isCapitalized reports whether the word starts with an upper-case letter
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+
+	return false
+}
+
+// hasLetter reports whether the word contains a letter, so numbers like years are not terms
+func hasLetter(word string) bool {
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
+
+// mostFrequentForm returns the form, which articles use most. Ties are resolved alphabetically.
+func mostFrequentForm(forms map[string]int) string {
+	var best string
+	for form, count := range forms {
+		if best == "" || count > forms[best] || count == forms[best] && form < best {
+			best = form
+		}
+	}
+
+	return best
+}
+
+// examples returns the newest articles as examples of the trend
+func examples(articles []types.Article) []Example {
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].PublishedAt.After(articles[j].PublishedAt)
+	})
+
+	result := make([]Example, 0, maxExamples)
+	for _, article := range articles {
+		if len(result) == maxExamples {
+			break
+		}
+
+		result = append(result, Example{
+			Title:       strings.TrimSpace(article.Title),
+			Link:        article.Link,
+			Publisher:   article.Publisher,
+			PublishedAt: article.PublishedAt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package trends
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gogator/cmd/filters"
+	"gogator/cmd/types"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 7, 21, 12, 0, 0, 0, time.UTC)
+
+func article(title string, ago time.Duration) types.Article {
+	return types.Article{
+		Title:       title,
+		Link:        "https://example.com/" + title,
+		Publisher:   "bbc",
+		PublishedAt: now.Add(-ago),
+		Language:    "en",
+	}
+}
+
+func TestCompute(t *testing.T) {
+	articles := []types.Article{
+		article("Storm hits the coast", time.Hour),
+		article("Coast guard rescues sailors after the storm", 2*time.Hour),
+		article("Storms expected to continue", 3*time.Hour),
+		article("Markets rise", 4*time.Hour),
+		article("Markets fall", 5*time.Hour),
+		article("Markets open", 30*time.Hour),
+		article("Markets close", 50*time.Hour),
+		article("Storm of the century", 30*24*time.Hour),
+		{Title: "Storm without a date", PublishedAt: now, InvalidPubDate: true},
+	}
+
+	trends := Compute(articles, Params{Window: 6 * time.Hour, Baseline: 3 * 24 * time.Hour, Now: now})
+	assert.NotEmpty(t, trends)
+
+	storm := trends[0]
+	assert.Equal(t, "storm", storm.Term)
+	assert.Equal(t, 3, storm.Count)
+	assert.Equal(t, 0, storm.BaselineCount, "articles before the baseline are ignored")
+	assert.Len(t, storm.Articles, 3)
+	assert.Equal(t, "Storm hits the coast", storm.Articles[0].Title, "the newest article goes first")
+
+	for _, trend := range trends {
+		if trend.Term == "markets" {
+			assert.Equal(t, 2, trend.Count)
+			assert.Equal(t, 2, trend.BaselineCount)
+			assert.Less(t, trend.Score, storm.Score, "terms, which were frequent before, trend less")
+		}
+		assert.NotEqual(t, "the", trend.Term)
+	}
+
+	limited := Compute(articles, Params{Window: 6 * time.Hour, Baseline: 3 * 24 * time.Hour, Limit: 1, Now: now})
+	assert.Len(t, limited, 1)
+}
+
+func TestCompute_Entities(t *testing.T) {
+	articles := []types.Article{
+		article("The Federal Reserve raised rates", time.Hour),
+		article("Why the Federal Reserve is worried about inflation", 2*time.Hour),
+	}
+
+	trends := Compute(articles, Params{Window: 6 * time.Hour, Baseline: 24 * time.Hour, Now: now})
+
+	var entity *Trend
+	for i := range trends {
+		if trends[i].Entity {
+			entity = &trends[i]
+		}
+	}
+
+	if assert.NotNil(t, entity) {
+		assert.Equal(t, "Federal Reserve", entity.Term)
+		assert.Equal(t, 2, entity.Count)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{text: "The Federal Reserve met in New York on Monday", expected: []string{"Federal Reserve", "New York"}},
+		{text: "Talks in Kyiv, Warsaw and Berlin", expected: nil},
+		{text: "Stocks Fall As Federal Reserve Raises Rates", expected: nil},
+		{text: "", expected: nil},
+	}
+
+	for _, tt := range testCases {
+		assert.Equal(t, tt.expected, entities(tt.text), tt.text)
+	}
+}
+
+func TestNewParams(t *testing.T) {
+	params, err := NewParams("", "", "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, Params{Window: DefaultWindow, Baseline: DefaultBaseline, Limit: DefaultLimit, Now: now}, params)
+
+	params, err = NewParams("12h", "2w", "5", now)
+	assert.NoError(t, err)
+	assert.Equal(t, Params{Window: 12 * time.Hour, Baseline: 14 * 24 * time.Hour, Limit: 5, Now: now}, params)
+	assert.Equal(t, now.Add(-12*time.Hour-14*24*time.Hour), params.Since())
+
+	_, err = NewParams("6y", "", "", now)
+	assert.ErrorIs(t, err, filters.ErrInvalidDuration)
+
+	_, err = NewParams("", "-1d", "", now)
+	assert.ErrorIs(t, err, filters.ErrInvalidDuration)
+
+	_, err = NewParams("", "", "1000", now)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "30m", FormatDuration(30*time.Minute))
+	assert.Equal(t, "6h", FormatDuration(DefaultWindow))
+	assert.Equal(t, "1w", FormatDuration(DefaultBaseline))
+	assert.Equal(t, "3d", FormatDuration(3*24*time.Hour))
+	assert.Equal(t, "90m", FormatDuration(90*time.Minute))
+}
@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+// FetchResult describes the outcome of fetching a single source.
+// It has several fields:
+// /  1. Source      - Name of the fetched source
+// /  2. Articles    - Amount of articles, retrieved from the source
+// /  3. Duration    - Time spent on fetching and parsing the source (nanoseconds in JSON)
+// /  4. FetchedAt   - Time when fetching of the source has finished
+// /  5. Error       - Error message, if fetching has failed
+// /  6. NotModified - Source responded with 304 Not Modified, so there are no new articles
+//
+// Err keeps the original error, so callers are able to inspect it. It is not serialized.
+type FetchResult struct {
+	Source      string        `json:"source"`
+	Articles    int           `json:"articles"`
+	Duration    time.Duration `json:"duration"`
+	FetchedAt   time.Time     `json:"fetchedAt"`
+	Error       string        `json:"error,omitempty"`
+	NotModified bool          `json:"notModified,omitempty"`
+	Err         error         `json:"-"`
+}
+
+// NewFetchResult creates an instance of FetchResult, finished at the current moment
+func NewFetchResult(source string, articles int, duration time.Duration, err error) FetchResult {
+	result := FetchResult{
+		Source:    source,
+		Articles:  articles,
+		Duration:  duration,
+		FetchedAt: time.Now().UTC(),
+		Err:       err,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// Failed reports whether fetching of the source has failed
+func (r FetchResult) Failed() bool {
+	return r.Error != ""
+}
@@ -0,0 +1,19 @@
+package types
+
+// FetchValidators are cache validators of the last successful response of the source.
+// They are sent back in conditional requests, so the source responds with 304 Not Modified,
+// if feed has not changed.
+// It has several fields:
+// /  1. Endpoint     - URL, which returned the validators. They are not reused, once the endpoint is changed
+// /  2. ETag         - Value of ETag response header, sent in If-None-Match header
+// /  3. LastModified - Value of Last-Modified response header, sent in If-Modified-Since header
+type FetchValidators struct {
+	Endpoint     string `json:"endpoint"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Empty reports whether the response had no validators, so the request can not be conditional
+func (v FetchValidators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
@@ -1,11 +1,44 @@
 package types
 
+const (
+	// DateDescSort orders articles by publication date, starting from the newest one. It is used, when sort is empty.
+	DateDescSort = "date_desc"
+
+	// DateAscSort orders articles by publication date, starting from the oldest one
+	DateAscSort = "date_asc"
+
+	// SourceSort orders articles by publisher in alphabetical order, and articles of a publisher from the newest one
+	SourceSort = "source"
+
+	// RelevanceSort orders articles by relevance to the keywords, starting from the most relevant one
+	RelevanceSort = "relevance"
+
+	// ExactMatch matches keywords as they are, respecting case
+	ExactMatch = "exact"
+
+	// CaseInsensitiveMatch matches keywords in any case. It is used, when match mode is empty,
+	// since keywords are typed by people, who do not know the case of words in titles.
+	CaseInsensitiveMatch = "case-insensitive"
+
+	// WholeWordMatch matches keywords, which are not parts of longer words, respecting case
+	WholeWordMatch = "whole-word"
+
+	// RegexMatch treats keywords as regular expressions (RE2 syntax)
+	RegexMatch = "regex"
+)
+
 // FilteringParams represents the parameters used for filtering news articles.
 // It has several fields:
 // /  1. Keywords          - Keywords to filter articles
-// /  2. StartingTimestamp - Starting timestamp for filtering articles
-// /  3. EndingTimestamp   - Ending timestamp for filtering articles
+// /  2. StartingTimestamp - Starting timestamp for filtering articles: date, RFC 3339 timestamp or relative date
+// /  3. EndingTimestamp   - Ending timestamp for filtering articles: date, RFC 3339 timestamp or relative date
 // /  4. Sources           - Sources to filter articles
+// /  5. Sort              - Order of articles: DateDescSort (if empty), DateAscSort, SourceSort or RelevanceSort
+// /  6. MatchMode         - How keywords are matched: CaseInsensitiveMatch (if empty), ExactMatch, WholeWordMatch or RegexMatch
+// /  7. Timezone          - IANA time zone, which dates and times without offset are interpreted in. Empty means UTC
+// /  8. Categories        - Comma separated categories, any of which articles should belong to
+// /  9. Authors           - Comma separated names, any of which authors of articles should contain
+// / 10. Language          - Comma separated ISO 639-1 codes, any of which articles should be written in
 //
 // This struct will be used for:
 //  1. Handling user input
@@ -15,6 +48,12 @@ type FilteringParams struct {
 	StartingTimestamp string `json:"starting_timestamp" xml:"starting_timestamp"`
 	EndingTimestamp   string `json:"ending_timestamp" xml:"ending_timestamp"`
 	Sources           string `json:"sources" xml:"sources"`
+	Sort              string `json:"sort,omitempty" xml:"sort,omitempty"`
+	MatchMode         string `json:"match,omitempty" xml:"match,omitempty"`
+	Timezone          string `json:"tz,omitempty" xml:"tz,omitempty"`
+	Categories        string `json:"category,omitempty" xml:"category,omitempty"`
+	Authors           string `json:"author,omitempty" xml:"author,omitempty"`
+	Language          string `json:"lang,omitempty" xml:"lang,omitempty"`
 }
 
 // NewFilteringParams creates an instance of FilteringParams
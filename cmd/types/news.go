@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // RSS struct is used to parse articles in RSS format.
 // Because each resource has its own data output format,
 // this model will be used when we have the following structure:
@@ -15,29 +17,205 @@ type RSS struct {
 	Channel Channel `xml:"channel"`
 }
 
+// Json struct is used to parse articles from JSON APIs which wrap them into "articles" array
 type Json struct {
 	Articles []Article `json:"articles"`
 }
 
+// JsonFeed struct is used to parse articles in JSON Feed 1.1 format (https://jsonfeed.org/version/1.1).
+// Version field contains the URL of the JSON Feed specification version, which helps to detect the format.
+type JsonFeed struct {
+	Version  string         `json:"version"`
+	Language string         `json:"language"`
+	Items    []JsonFeedItem `json:"items"`
+}
+
+// JsonFeedItem is a single article from JSON Feed.
+// All fields, except for id, are optional in the specification.
+type JsonFeedItem struct {
+	Id            string           `json:"id"`
+	Title         string           `json:"title"`
+	Url           string           `json:"url"`
+	ExternalUrl   string           `json:"external_url"`
+	Summary       string           `json:"summary"`
+	ContentText   string           `json:"content_text"`
+	ContentHtml   string           `json:"content_html"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []JsonFeedAuthor `json:"authors"`
+	Tags          []string         `json:"tags"`
+	Language      string           `json:"language"`
+}
+
+// JsonFeedAuthor is an author of JSON Feed item
+type JsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// Channel is the <channel> element of RSS 2.0 feed.
+// Language of the channel is the language of all its items.
 type Channel struct {
-	Items []Article `xml:"item"`
+	Language string    `xml:"language"`
+	Items    []RSSItem `xml:"item"`
+}
+
+// RSSItem is a single article from RSS 2.0 feed.
+//
+// Besides the core elements, it has optional <author> (or Dublin Core <dc:creator>), <category>,
+// <guid>, full content of <content:encoded>, and image of <enclosure>, <media:content> or <media:thumbnail>.
+type RSSItem struct {
+	Title          string       `xml:"title"`
+	Link           string       `xml:"link"`
+	Description    string       `xml:"description"`
+	PubDate        string       `xml:"pubDate"`
+	Guid           string       `xml:"guid"`
+	Author         string       `xml:"author"`
+	Creator        string       `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories     []string     `xml:"category"`
+	ContentEncoded string       `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Enclosures     []RSSMedia   `xml:"enclosure"`
+	MediaContents  []RSSMedia   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaGroups    []MediaGroup `xml:"http://search.yahoo.com/mrss/ group"`
+	Thumbnails     []RSSMedia   `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// RSSMedia is a media file of RSS item: <enclosure>, <media:content> or <media:thumbnail>.
+// Enclosures have url attribute and MIME type, media elements may have medium instead of the type.
+type RSSMedia struct {
+	Url    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+// MediaGroup is the <media:group> element, which wraps alternative versions of the same media
+type MediaGroup struct {
+	Contents []RSSMedia `xml:"http://search.yahoo.com/mrss/ content"`
+}
+
+// RDF struct is used to parse articles in RSS 1.0 format.
+// Unlike RSS 2.0, items are placed next to the channel element:
+//
+// <rdf:RDF>
+//
+//	 <channel>...</channel>
+//	 <item>
+//		  Article fields...
+//	 </item>
+//
+// </rdf:RDF>
+type RDF struct {
+	Items []RDFItem `xml:"item"`
+}
+
+// RDFItem is a single article from RSS 1.0 feed.
+// Publication date, author, categories and language are stored in Dublin Core elements.
+type RDFItem struct {
+	Title          string   `xml:"title"`
+	Link           string   `xml:"link"`
+	Description    string   `xml:"description"`
+	Date           string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Creator        string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Subjects       []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	Language       string   `xml:"http://purl.org/dc/elements/1.1/ language"`
+	ContentEncoded string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+// Atom struct is used to parse articles in Atom 1.0 format:
+//
+// <feed xmlns="http://www.w3.org/2005/Atom">
+//
+//	 <entry>
+//		  Article fields...
+//	 </entry>
+//
+// </feed>
+type Atom struct {
+	Language string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// AtomEntry is a single article from Atom feed
+type AtomEntry struct {
+	Id         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Summary    string         `xml:"summary"`
+	Content    string         `xml:"content"`
+	Links      []AtomLink     `xml:"link"`
+	Authors    []AtomAuthor   `xml:"author"`
+	Categories []AtomCategory `xml:"category"`
+	Language   string         `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+}
+
+// AtomLink is a <link> element of Atom entry.
+// Link to the article itself has "alternate" relation, which is also a default one,
+// and image of the article is linked with "enclosure" relation.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// AtomAuthor is an <author> element of Atom entry
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// AtomCategory is a <category> element of Atom entry. Human-readable label is optional.
+type AtomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
 }
 
 // Article is one of the main models in news aggregator.
 // It has few fields inside:
 // /   1. Title			- Headline of the article
 // /   2. Description 	- Description of the article
-// /   3. PubDate 		- Publication Date
-// /   4. Link 			- Link to the article
-// /   5. Publisher 	- Optional: Author or publisher of the article
+// /   3. PublishedAt 	- Publication date, normalized to UTC when the article is parsed (see NormalizePubDate)
+// /   4. PubDate 		- Original publication date, as it was published by the source
+// /   5. InvalidPubDate - Original publication date is missing or can not be parsed, so PublishedAt is the time of ingest
+// /   6. Link 			- Link to the article
+// /   7. Publisher 	- Optional: Source, which published the article
+// /   8. Author 		- Optional: Author of the article
+// /   9. Categories 	- Optional: Categories or tags of the article
+// /  10. ImageUrl 		- Optional: Link to the image of the article
+// /  11. Content 		- Optional: Full content of the article, if the source provides it
+// /  12. Language 		- Optional: ISO 639-1 code of the language of the article, e.g. en or uk. It is detected from the text,
+// /                      and the language declared by the feed is used, if the text is too short
+// /  13. Guid 			- Optional: Identifier of the article, assigned by the source
+// /  14. ID 			- Stable identifier, derived from the link (see package dedup)
+// /  15. AlternateSources - Other publishers of the same story, if near-identical articles were collapsed
+// /  16. Score 		- Relevance of the article to the search query, if articles were sorted by relevance
 //
+// In JSON, PublishedAt is encoded in RFC 3339 format, and PubDate is encoded as rawPubDate.
 // It will be used through the application for different operations, such as:
 //  1. Parsing
 //  2. Logging
 type Article struct {
-	Title       string `json:"title" xml:"title"`
-	PubDate     string `json:"publishedAt" xml:"pubDate"`
-	Description string `json:"description" xml:"description"`
-	Publisher   string `xml:"source" json:"Publisher"`
-	Link        string `json:"url" xml:"link"`
+	ID               string            `json:"id,omitempty" xml:"-"`
+	Title            string            `json:"title" xml:"title"`
+	PublishedAt      time.Time         `json:"publishedAt" xml:"-"`
+	PubDate          string            `json:"rawPubDate,omitempty" xml:"pubDate"`
+	InvalidPubDate   bool              `json:"invalidPubDate,omitempty" xml:"-"`
+	Description      string            `json:"description" xml:"description"`
+	Publisher        string            `xml:"source" json:"Publisher"`
+	Link             string            `json:"url" xml:"link"`
+	Author           string            `json:"author,omitempty" xml:"author"`
+	Categories       []string          `json:"categories,omitempty" xml:"category"`
+	ImageUrl         string            `json:"imageUrl,omitempty" xml:"-"`
+	Content          string            `json:"content,omitempty" xml:"-"`
+	Language         string            `json:"language,omitempty" xml:"-"`
+	Guid             string            `json:"guid,omitempty" xml:"guid"`
+	AlternateSources []AlternateSource `json:"alternateSources,omitempty" xml:"-"`
+	Score            float64           `json:"score,omitempty" xml:"-"`
+}
+
+// AlternateSource is another publication of the story, which was collapsed into a single article
+type AlternateSource struct {
+	ID        string `json:"id"`
+	Publisher string `json:"Publisher"`
+	Link      string `json:"url"`
 }
@@ -0,0 +1,225 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPubDate is returned, when publication date is not in any of the supported layouts
+var ErrInvalidPubDate = errors.New("unsupported publication date format")
+
+// pubDateLayouts are layouts of publication dates in feeds, APIs and scraped pages, from the most common ones.
+// Layouts without a year are not supported, since the date would be in the year 0.
+var pubDateLayouts = []string{
+	// ISO 8601 and RFC 3339, with or without seconds, fractions and colon in the zone
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05Z0700",
+	"2006-01-02 15:04:05 -0700",
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+
+	// RSS dates (RFC 822 and RFC 1123), with one or two digits of the day, and numeric or named zones
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"Mon, 2 Jan 2006 15:04:05 -0700 (MST)",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC850,
+
+	// Go and C formats
+	time.Layout,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+
+	// Human-readable dates of scraped pages
+	"January 2, 2006 3:04 PM",
+	"Jan 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+}
+
+// zoneOffsets are offsets from UTC in seconds of zone abbreviations, which are supported in dates with named zones:
+// zones of RFC 822 and zones, which are common in feeds. Go parses unknown abbreviations with zero offset,
+// so they are resolved here, and dates with other abbreviations are rejected.
+var zoneOffsets = map[string]int{
+	"UT":   0,
+	"UTC":  0,
+	"GMT":  0,
+	"Z":    0,
+	"EST":  -5 * 60 * 60,
+	"EDT":  -4 * 60 * 60,
+	"CST":  -6 * 60 * 60,
+	"CDT":  -5 * 60 * 60,
+	"MST":  -7 * 60 * 60,
+	"MDT":  -6 * 60 * 60,
+	"PST":  -8 * 60 * 60,
+	"PDT":  -7 * 60 * 60,
+	"BST":  1 * 60 * 60,
+	"CET":  1 * 60 * 60,
+	"CEST": 2 * 60 * 60,
+	"EET":  2 * 60 * 60,
+	"EEST": 3 * 60 * 60,
+	"MSK":  3 * 60 * 60,
+	"JST":  9 * 60 * 60,
+	"AEST": 10 * 60 * 60,
+	"AEDT": 11 * 60 * 60,
+}
+
+// ParsePubDate parses publication date in any of the supported layouts, or Unix time in seconds
+// or milliseconds. Dates without zone are considered to be in UTC. Returned date is in UTC.
+// Named zones are resolved by zoneOffsets, dates with unknown zone abbreviations are not supported.
+func ParsePubDate(raw string) (time.Time, error) {
+	value := strings.Join(strings.Fields(raw), " ")
+	if value == "" {
+		return time.Time{}, ErrInvalidPubDate
+	}
+
+	for _, layout := range pubDateLayouts {
+		date, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		if hasNamedZoneOnly(layout) {
+			date, err = resolveZone(date)
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+
+		return date.UTC(), nil
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil && unix > 0 {
+		switch len(value) {
+		case 10:
+			return time.Unix(unix, 0).UTC(), nil
+		case 13:
+			return time.UnixMilli(unix).UTC(), nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidPubDate
+}
+
+// hasNamedZoneOnly reports whether the layout has a zone abbreviation without a numeric offset
+func hasNamedZoneOnly(layout string) bool {
+	return strings.Contains(layout, "MST") && !strings.Contains(layout, "-0700") && !strings.Contains(layout, "Z07")
+}
+
+// resolveZone replaces the zone of date, which was parsed from its abbreviation, with the offset from zoneOffsets.
+// Zones like "GMT+3" get their offset from the name: Go keeps the clock of such dates in UTC.
+func resolveZone(date time.Time) (time.Time, error) {
+	name, offset := date.Zone()
+
+	known, ok := zoneOffsets[strings.ToUpper(name)]
+	if !ok {
+		if offset == 0 || !strings.HasPrefix(name, "GMT") {
+			return time.Time{}, ErrInvalidPubDate
+		}
+
+		date, known = date.UTC(), offset
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(),
+		date.Nanosecond(), time.FixedZone(name, known)), nil
+}
+
+// NormalizePubDate sets PublishedAt from the original publication date, if it is not set yet.
+//
+// If the original date is missing or can not be parsed, PublishedAt is set to now, the moment of ingest,
+// and InvalidPubDate is set, so the article is still filtered and sorted like other articles of that moment.
+func (a *Article) NormalizePubDate(now time.Time) {
+	if !a.PublishedAt.IsZero() {
+		return
+	}
+
+	date, err := ParsePubDate(a.PubDate)
+	if err != nil {
+		a.PublishedAt = now.UTC()
+		a.InvalidPubDate = true
+		return
+	}
+
+	a.PublishedAt = date
+	a.InvalidPubDate = false
+}
+
+// NormalizePubDates calls NormalizePubDate for every article
+func NormalizePubDates(articles []Article, now time.Time) {
+	for i := range articles {
+		articles[i].NormalizePubDate(now)
+	}
+}
+
+// articleAlias has the same fields as Article, but not its methods, so it is encoded by default rules
+type articleAlias Article
+
+// articleJSON is the JSON representation of Article. Normalized publication date is encoded as a string,
+// so zero date is omitted, and articles with publication date in any layout can be decoded,
+// e.g. articles of JSON feeds or day-files stored before dates were normalized.
+type articleJSON struct {
+	articleAlias
+	PublishedAt string `json:"publishedAt,omitempty"`
+}
+
+// MarshalJSON encodes the article with normalized publication date in RFC 3339 format
+func (a Article) MarshalJSON() ([]byte, error) {
+	encoded := articleJSON{articleAlias: articleAlias(a)}
+	if !a.PublishedAt.IsZero() {
+		encoded.PublishedAt = a.PublishedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes the article. If publication date is not in RFC 3339 format, it is kept as
+// the original date and normalized.
+func (a *Article) UnmarshalJSON(data []byte) error {
+	var decoded articleJSON
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*a = Article(decoded.articleAlias)
+	a.PublishedAt = time.Time{}
+	if decoded.PublishedAt == "" {
+		return nil
+	}
+
+	// articles of other APIs have only publishedAt, which is their original date
+	if a.PubDate == "" && !a.InvalidPubDate {
+		a.PubDate = decoded.PublishedAt
+	}
+
+	date, err := time.Parse(time.RFC3339Nano, decoded.PublishedAt)
+	if err == nil {
+		a.PublishedAt = date.UTC()
+		return nil
+	}
+
+	date, err = ParsePubDate(a.PubDate)
+	a.PublishedAt = date
+	a.InvalidPubDate = err != nil
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParsePubDate(t *testing.T) {
+	kyivSummer := time.FixedZone("EEST", 3*60*60)
+
+	testCases := []struct {
+		name     string
+		raw      string
+		expected time.Time
+	}{
+		{
+			name:     "RFC 3339",
+			raw:      "2024-07-23T10:00:00+03:00",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "RFC 3339 with milliseconds, like USA Today publishdate",
+			raw:      "2024-07-23T19:59:31.637Z",
+			expected: time.Date(2024, 7, 23, 19, 59, 31, 637000000, time.UTC),
+		},
+		{
+			name:     "ISO 8601 with numeric zone without colon",
+			raw:      "2024-07-23T10:00:00.000+0300",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "ISO 8601 without seconds",
+			raw:      "2024-07-23T10:00+03:00",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "ISO 8601 without seconds and zone",
+			raw:      "2024-07-23T10:00",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Date and time separated by space",
+			raw:      "2024-07-23 10:00:00",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Date",
+			raw:      "2024-07-23",
+			expected: time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 1123",
+			raw:      "Tue, 23 Jul 2024 10:00:00 GMT",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 1123 with named zone of RFC 822",
+			raw:      "Tue, 23 Jul 2024 10:00:00 EDT",
+			expected: time.Date(2024, 7, 23, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 1123 with named zone of Europe",
+			raw:      "Tue, 23 Jul 2024 10:00:00 EEST",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "Unix date with named zone",
+			raw:      "Tue Jul 23 10:00:00 PST 2024",
+			expected: time.Date(2024, 7, 23, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 1123 with offset from GMT",
+			raw:      "Tue, 23 Jul 2024 10:00:00 GMT+3",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "Named zone in parentheses is ignored in favour of numeric offset",
+			raw:      "Tue, 23 Jul 2024 10:00:00 +0300 (XYZT)",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "RFC 1123 with numeric zone",
+			raw:      "Tue, 23 Jul 2024 10:00:00 +0300",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, kyivSummer),
+		},
+		{
+			name:     "RFC 1123 with one digit day and numeric zone",
+			raw:      "Tue, 2 Jul 2024 10:00:00 -0400",
+			expected: time.Date(2024, 7, 2, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 1123 without seconds",
+			raw:      "Tue, 2 Jul 2024 10:00 +0000",
+			expected: time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 822 with extra spaces",
+			raw:      "  23 Jul 24   10:00 +0000 ",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Human-readable date",
+			raw:      "July 23, 2024",
+			expected: time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Unix time in seconds",
+			raw:      "1721728800",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Unix time in milliseconds",
+			raw:      "1721728800000",
+			expected: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			date, err := ParsePubDate(tc.raw)
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.Equal(date), "expected %v, got %v", tc.expected, date)
+			assert.Equal(t, time.UTC, date.Location())
+		})
+	}
+}
+
+func TestParsePubDate_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "yesterday", "2024-05-55", "10:00", "Jul 23 10:00:00", "123",
+		"Tue, 23 Jul 2024 10:00:00 XYZT", "Tue Jul 23 10:00:00 ABC 2024"} {
+		_, err := ParsePubDate(raw)
+		assert.ErrorIs(t, err, ErrInvalidPubDate, raw)
+	}
+}
+
+func TestArticle_NormalizePubDate(t *testing.T) {
+	now := time.Date(2024, 7, 24, 8, 0, 0, 0, time.UTC)
+
+	article := Article{PubDate: "Tue, 23 Jul 2024 10:00:00 GMT"}
+	article.NormalizePubDate(now)
+	assert.Equal(t, time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC), article.PublishedAt)
+	assert.False(t, article.InvalidPubDate)
+	assert.Equal(t, "Tue, 23 Jul 2024 10:00:00 GMT", article.PubDate)
+
+	invalid := Article{PubDate: "yesterday"}
+	invalid.NormalizePubDate(now)
+	assert.Equal(t, now, invalid.PublishedAt)
+	assert.True(t, invalid.InvalidPubDate)
+	assert.Equal(t, "yesterday", invalid.PubDate)
+
+	normalized := Article{PubDate: "yesterday", PublishedAt: time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC)}
+	normalized.NormalizePubDate(now)
+	assert.Equal(t, time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC), normalized.PublishedAt)
+	assert.False(t, normalized.InvalidPubDate)
+}
+
+func TestArticle_JSON(t *testing.T) {
+	article := Article{
+		Title:       "Title",
+		PubDate:     "Tue, 23 Jul 2024 10:00:00 +0300",
+		PublishedAt: time.Date(2024, 7, 23, 7, 0, 0, 0, time.UTC),
+		Link:        "https://example.com",
+	}
+
+	data, err := json.Marshal(article)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"publishedAt":"2024-07-23T07:00:00Z"`)
+	assert.Contains(t, string(data), `"rawPubDate":"Tue, 23 Jul 2024 10:00:00 +0300"`)
+	assert.NotContains(t, string(data), "invalidPubDate")
+
+	var decoded Article
+	err = json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, article, decoded)
+
+	data, err = json.Marshal(Article{Title: "Without date"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "publishedAt")
+}
+
+func TestArticle_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected Article
+	}{
+		{
+			name: "Stored before dates were normalized",
+			data: `{"title":"Title","publishedAt":"Tue, 23 Jul 2024 10:00:00 GMT"}`,
+			expected: Article{
+				Title:       "Title",
+				PubDate:     "Tue, 23 Jul 2024 10:00:00 GMT",
+				PublishedAt: time.Date(2024, 7, 23, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Article of other API with RFC 3339 date",
+			data: `{"title":"Title","publishedAt":"2024-07-23T10:00:00+03:00"}`,
+			expected: Article{
+				Title:       "Title",
+				PubDate:     "2024-07-23T10:00:00+03:00",
+				PublishedAt: time.Date(2024, 7, 23, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Unparsable date",
+			data: `{"title":"Title","publishedAt":"yesterday"}`,
+			expected: Article{
+				Title:          "Title",
+				PubDate:        "yesterday",
+				InvalidPubDate: true,
+			},
+		},
+		{
+			name: "Invalid date normalized to the time of ingest",
+			data: `{"title":"Title","publishedAt":"2024-07-24T08:00:00Z","invalidPubDate":true}`,
+			expected: Article{
+				Title:          "Title",
+				PublishedAt:    time.Date(2024, 7, 24, 8, 0, 0, 0, time.UTC),
+				InvalidPubDate: true,
+			},
+		},
+		{
+			name:     "Without date",
+			data:     `{"title":"Title"}`,
+			expected: Article{Title: "Title"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var article Article
+			err := json.Unmarshal([]byte(tc.data), &article)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, article)
+		})
+	}
+}
@@ -4,8 +4,74 @@ package types
 // Name is basically name of the source
 // Format is used to check what parsers should be used for that source
 // Endpoint this field will be used to dynamically parse articles from that source
+// Mapping is optional, and describes where article fields are located in responses of JSON sources
+// Scraping is optional, and describes how articles are extracted from pages of HTML sources
+// Readability is optional, and enables extraction of the full text of articles from their pages. Disabled by default
 type Feed struct {
-	Name     string `json:"name"`
-	Format   string `json:"format"`
-	Endpoint string `json:"endpoint"`
+	Name        string           `json:"name"`
+	Format      string           `json:"format"`
+	Endpoint    string           `json:"endpoint"`
+	Mapping     *FieldMapping    `json:"mapping,omitempty"`
+	Scraping    *ScrapingProfile `json:"scraping,omitempty"`
+	Readability *bool            `json:"readability,omitempty"`
+}
+
+// ReadabilityEnabled reports whether links of the feed articles are followed to extract their full text
+func (f Feed) ReadabilityEnabled() bool {
+	return f.Readability != nil && *f.Readability
+}
+
+// FieldMapping describes how to extract articles from arbitrary JSON response.
+//
+// Each field is a JSONPath-like expression: keys are separated by dots, array elements are
+// addressed by their index, and an optional "$." prefix is allowed, e.g. "$.data.results" or "media.0.url".
+// Items is resolved from the root of the response and should point to an array of articles,
+// all other paths are resolved relatively to a single article.
+// Empty paths are defaulted to the field names of Article: "title", "publishedAt", "description", "url",
+// "author", "categories", "imageUrl", "content", "language" and "guid".
+// Categories may point either to an array or to a single value.
+type FieldMapping struct {
+	Items       string `json:"items"`
+	Title       string `json:"title,omitempty"`
+	PubDate     string `json:"publishedAt,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"url,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Categories  string `json:"categories,omitempty"`
+	Image       string `json:"imageUrl,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Guid        string `json:"guid,omitempty"`
+}
+
+// ScrapingProfile describes how articles are extracted from HTML page using CSS selectors.
+//
+// ItemSelector matches a block of the page with a single article, and all other selectors
+// are applied inside of that block. If selector of the field is empty, the article block itself is used.
+// If attribute of the field is empty, text of the matched element is used, otherwise the value of the attribute.
+// LinkAttribute is defaulted to "href", and ImageAttribute is defaulted to "src".
+// CategorySelector may match several elements, which are all categories of the article.
+// Language of articles is taken from the lang attribute of the page.
+//
+// DateLayout is an optional Go time layout (e.g. "Jan 2, 2006 15:04"), which is used to parse dates
+// in the non-standard format. BaseUrl is used to resolve relative links, and defaults to the source endpoint.
+type ScrapingProfile struct {
+	ItemSelector         string `json:"itemSelector"`
+	TitleSelector        string `json:"titleSelector,omitempty"`
+	TitleAttribute       string `json:"titleAttribute,omitempty"`
+	LinkSelector         string `json:"linkSelector,omitempty"`
+	LinkAttribute        string `json:"linkAttribute,omitempty"`
+	DateSelector         string `json:"dateSelector,omitempty"`
+	DateAttribute        string `json:"dateAttribute,omitempty"`
+	DateLayout           string `json:"dateLayout,omitempty"`
+	DescriptionSelector  string `json:"descriptionSelector,omitempty"`
+	DescriptionAttribute string `json:"descriptionAttribute,omitempty"`
+	AuthorSelector       string `json:"authorSelector,omitempty"`
+	AuthorAttribute      string `json:"authorAttribute,omitempty"`
+	CategorySelector     string `json:"categorySelector,omitempty"`
+	CategoryAttribute    string `json:"categoryAttribute,omitempty"`
+	ImageSelector        string `json:"imageSelector,omitempty"`
+	ImageAttribute       string `json:"imageAttribute,omitempty"`
+	ContentSelector      string `json:"contentSelector,omitempty"`
+	BaseUrl              string `json:"baseUrl,omitempty"`
 }
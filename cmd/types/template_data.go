@@ -6,11 +6,22 @@ package types
 // /  1. NewsItems  - Array of articles, which should be displayed
 // /  2. FilterInfo - Display filtering info, e.g. user inputted arguments
 // /  3. TotalItems - Total amount of news
-// /  4. Keywords   - Array of news. It will be used when user provided specific keywords to search articles for,
-// / and using this field we will highlight these keywords.
+// /  4. Keywords   - Matchers of keywords. It will be used when user provided specific keywords to search articles for,
+// / and using this field we will highlight these keywords, respecting the match mode.
+// /  5. FetchResults - Outcome of fetching every source, displayed to explain missing articles
 type TemplateData struct {
-	NewsItems  []Article
-	FilterInfo string
-	TotalItems int
-	Keywords   []string
+	NewsItems    []Article
+	FilterInfo   string
+	TotalItems   int
+	Keywords     []TermMatcher
+	FetchResults []FetchResult
+}
+
+// TermMatcher finds a keyword in texts, according to the match mode
+type TermMatcher interface {
+	// Match reports whether the text contains the keyword
+	Match(text string) bool
+
+	// FindAll returns start and end byte indexes of all non-overlapping occurrences of the keyword in the text
+	FindAll(text string) [][]int
 }
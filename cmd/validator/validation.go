@@ -3,7 +3,11 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"gogator/cmd/filters"
+	"gogator/cmd/language"
 	parsers "gogator/cmd/parsers"
+	"gogator/cmd/sorting"
+	"gogator/cmd/types"
 	"strings"
 	"time"
 )
@@ -13,14 +17,36 @@ const (
 	ErrDateFromAfter = "date from can not be after date end"
 
 	// ErrFailedDateValidation is thrown when user submitted date in wrong format
-	ErrFailedDateValidation = "error while validating date. correct format is YYYY-mm-dd - 2024-05-15"
+	ErrFailedDateValidation = "error while validating date. correct formats are YYYY-mm-dd - 2024-05-15, " +
+		"RFC 3339 timestamp - 2024-05-15T09:00:00+03:00, time of today - 09:00, now, today, yesterday " +
+		"and relative dates - -6h, 30m, 7d, 2w"
+
+	// ErrFailedTimezoneValidation is thrown when user submitted unknown time zone
+	ErrFailedTimezoneValidation = "error while validating time zone: "
 
 	// ErrFailedSourceValidation is thrown when user submitted wrong source
 	ErrFailedSourceValidation = "error while validating source "
+
+	// ErrFailedKeywordsValidation is thrown when user submitted keywords, which are not a valid query
+	ErrFailedKeywordsValidation = "error while validating keywords: "
+
+	// ErrFailedMatchModeValidation is thrown when user submitted unknown match mode
+	ErrFailedMatchModeValidation = "error while validating match mode: "
+
+	// ErrUnsupportedSort is thrown when user submitted unknown sort
+	ErrUnsupportedSort = "unsupported sort, supported ones are: " + types.DateDescSort + ", " + types.DateAscSort + ", " +
+		types.SourceSort + ", " + types.RelevanceSort
+
+	// ErrRelevanceWithoutKeywords is thrown when user asked to sort by relevance without keywords
+	ErrRelevanceWithoutKeywords = "sorting by relevance requires keywords"
+
+	// ErrFailedLanguageValidation is thrown when user submitted a language, which is not an ISO 639-1 code
+	ErrFailedLanguageValidation = "error while validating language, it should be a comma separated list " +
+		"of ISO 639-1 codes, e.g. en,uk: "
 )
 
 type Validator interface {
-	Validate(keywords, dateFrom, dateEnd string) error
+	Validate(keywords, matchMode, sources, dateFrom, dateEnd, timezone string) error
 }
 
 // ArgValidator struct is used to validate arguments which user inputs in get-news handler
@@ -36,7 +62,17 @@ func (u *UnsupportedFlagError) Error() string {
 }
 
 // Validate checks if all user-given arguments are correct
-func (v *ArgValidator) Validate(sources, dateFrom, dateEnd string) error {
+func (v *ArgValidator) Validate(keywords, matchMode, sources, dateFrom, dateEnd, timezone string) error {
+	matchModeValidator := &MatchModeValidationHandler{
+		matchMode: matchMode,
+	}
+	keywordsValidator := &KeywordsValidationHandler{
+		keywords:  keywords,
+		matchMode: matchMode,
+	}
+	timezoneValidator := &TimezoneValidationHandler{
+		timezone: timezone,
+	}
 	dateFromValidator := &DateValidationHandler{
 		date: dateFrom,
 	}
@@ -46,16 +82,20 @@ func (v *ArgValidator) Validate(sources, dateFrom, dateEnd string) error {
 	dateRangeValidator := &DateRangeHandler{
 		dateFrom: dateFrom,
 		dateEnd:  dateEnd,
+		timezone: timezone,
 	}
 	sourcesValidator := &SourceValidationHandler{
 		sources: sources,
 	}
 
+	matchModeValidator.SetNext(keywordsValidator)
+	keywordsValidator.SetNext(timezoneValidator)
+	timezoneValidator.SetNext(dateFromValidator)
 	dateFromValidator.SetNext(dateEndValidator)
 	dateEndValidator.SetNext(dateRangeValidator)
 	dateRangeValidator.SetNext(sourcesValidator)
 
-	if err := dateFromValidator.Handle(); err != nil {
+	if err := matchModeValidator.Handle(); err != nil {
 		return err
 	}
 
@@ -90,22 +130,39 @@ func (h *BaseHandler) HandleNext() error {
 }
 
 // DateRangeHandler validates that the date-from parameter is not after the date-end parameter
+// in the time zone
 type DateRangeHandler struct {
 	BaseHandler
 	dateFrom string
 	dateEnd  string
+	timezone string
 }
 
 // Handle validates the date range and calls the next handler in the chain
 func (h *DateRangeHandler) Handle() error {
-	if err := ByDateRange(h.dateFrom, h.dateEnd); err != nil {
+	if err := ByDateRange(h.dateFrom, h.dateEnd, h.timezone); err != nil {
 		return err
 	}
 
 	return h.HandleNext()
 }
 
-// DateValidationHandler checks if the date parameters are in the correct format (YYYY-MM-DD)
+// TimezoneValidationHandler checks if the time zone is a known IANA time zone name
+type TimezoneValidationHandler struct {
+	BaseHandler
+	timezone string
+}
+
+// Handle validates the time zone and calls the next handler in the chain
+func (h *TimezoneValidationHandler) Handle() error {
+	if _, err := filters.LoadTimezone(h.timezone); err != nil {
+		return errors.New(ErrFailedTimezoneValidation + err.Error())
+	}
+
+	return h.HandleNext()
+}
+
+// DateValidationHandler checks if the date parameters are in one of the supported formats
 type DateValidationHandler struct {
 	BaseHandler
 	date string
@@ -135,29 +192,66 @@ func (h *SourceValidationHandler) Handle() error {
 	return h.HandleNext()
 }
 
-// ByDateRange verifies if date range is correct: date from should be before date end
-func ByDateRange(dateFrom, dateEnd string) error {
-	if dateFrom != "" && dateEnd != "" {
-		if dateFrom > dateEnd {
-			return errors.New(ErrDateFromAfter)
-		}
+// MatchModeValidationHandler checks if the match mode is supported
+type MatchModeValidationHandler struct {
+	BaseHandler
+	matchMode string
+}
+
+// Handle validates the match mode and calls the next handler in the chain
+func (h *MatchModeValidationHandler) Handle() error {
+	if err := filters.ValidateMatchMode(h.matchMode); err != nil {
+		return errors.New(ErrFailedMatchModeValidation + err.Error())
 	}
 
-	return nil
+	return h.HandleNext()
 }
 
-// ByDate checks if the date string is in the correct format (YYYY-MM-DD)
-func ByDate(dateStr string) error {
-	if dateStr == "" {
-		return nil
+// KeywordsValidationHandler checks if the keywords are a valid query, e.g. parentheses and quotes are closed,
+// and in regex match mode every term is a valid regular expression
+type KeywordsValidationHandler struct {
+	BaseHandler
+	keywords  string
+	matchMode string
+}
+
+// Handle validates the keywords and calls the next handler in the chain
+func (h *KeywordsValidationHandler) Handle() error {
+	if err := ByKeywords(h.keywords, h.matchMode); err != nil {
+		return errors.New(ErrFailedKeywordsValidation + err.Error())
 	}
 
-	_, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
-		return fmt.Errorf("invalid date format for %s, expected YYYY-MM-DD", dateStr)
+	return h.HandleNext()
+}
+
+// ByDateRange verifies if date range is correct: date from should be before date end,
+// when both of them are interpreted in the time zone
+func ByDateRange(dateFrom, dateEnd, timezone string) error {
+	params := &types.FilteringParams{
+		StartingTimestamp: dateFrom,
+		EndingTimestamp:   dateEnd,
+		Timezone:          timezone,
 	}
 
-	return nil
+	_, _, err := filters.DateRange(params, time.Now())
+	if errors.Is(err, filters.ErrInvalidDateRange) {
+		return errors.New(ErrDateFromAfter)
+	}
+
+	return err
+}
+
+// ByDate checks if the date string is in one of the formats supported by filters.ParseDateBound
+func ByDate(dateStr string) error {
+	_, err := filters.ParseDateBound(dateStr, time.UTC, time.Now(), false)
+	return err
+}
+
+// ByKeywords checks if the keywords can be parsed as a query in the match mode.
+// Parsed query is cached, so filtering does not parse it again.
+func ByKeywords(keywords, matchMode string) error {
+	_, err := filters.ParseQuery(keywords, matchMode)
+	return err
 }
 
 // BySources checks if the provided sources are within the supported list
@@ -177,6 +271,35 @@ func BySources(sources string) error {
 	return nil
 }
 
+// BySort checks if the sort is supported. Sorting by relevance is only possible, when keywords are provided.
+func BySort(sort, keywords string) error {
+	if !sorting.IsSupported(sort) {
+		return errors.New(ErrUnsupportedSort)
+	}
+
+	if sort == types.RelevanceSort && strings.Trim(keywords, ", ") == "" {
+		return errors.New(ErrRelevanceWithoutKeywords)
+	}
+
+	return nil
+}
+
+// ByLanguage checks if every comma separated language is a language code, e.g. "en" or "en-GB".
+// Empty languages are valid.
+func ByLanguage(languages string) error {
+	if languages == "" {
+		return nil
+	}
+
+	for _, code := range strings.Split(languages, ",") {
+		if !language.IsCode(strings.TrimSpace(code)) {
+			return errors.New(ErrFailedLanguageValidation + code)
+		}
+	}
+
+	return nil
+}
+
 // CheckFlagErr enhances flag-related error messages with more user-friendly versions
 func CheckFlagErr(err error) error {
 	if err != nil {
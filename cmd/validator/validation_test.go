@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gogator/cmd/filters"
 	"gogator/cmd/parsers"
 	"net/http"
 	"net/http/httptest"
@@ -21,6 +22,9 @@ func TestDateRangeHandler_Handle(t *testing.T) {
 		{"ValidDateRange", "2024-05-01", "2024-05-15", nil},
 		{"InvalidDateRange", "2024-05-16", "2024-05-15", errors.New(ErrDateFromAfter)},
 		{"EmptyDates", "", "", nil},
+		{"SameDay", "2024-05-15", "2024-05-15", nil},
+		{"Timestamps", "2024-05-15T10:00:00Z", "2024-05-15T12:00:00+03:00", errors.New(ErrDateFromAfter)},
+		{"Relative", "-7d", "today", nil},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +53,9 @@ func TestDateValidationHandler_Handle(t *testing.T) {
 		{"InvalidDateFrom", "2024-15-01", errors.New(ErrFailedDateValidation)},
 		{"InvalidDateEnd", "2024-05-51", errors.New(ErrFailedDateValidation)},
 		{"EmptyDates", "", nil},
+		{"Timestamp", "2024-05-01T10:00:00+03:00", nil},
+		{"Relative", "-6h", nil},
+		{"Yesterday", "yesterday", nil},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +73,35 @@ func TestDateValidationHandler_Handle(t *testing.T) {
 	}
 }
 
+func TestTimezoneValidationHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		timezone  string
+		expectErr bool
+	}{
+		{"Empty", "", false},
+		{"UTC", "UTC", false},
+		{"Kyiv", "Europe/Kyiv", false},
+		{"Unknown", "Mars/Olympus", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &TimezoneValidationHandler{
+				timezone: tt.timezone,
+			}
+
+			err := handler.Handle()
+			if !tt.expectErr {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, ErrFailedTimezoneValidation)
+		})
+	}
+}
+
 func TestSourceValidationHandler_Handle(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -97,6 +133,69 @@ func TestSourceValidationHandler_Handle(t *testing.T) {
 	}
 }
 
+func TestKeywordsValidationHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		keywords  string
+		matchMode string
+		expectErr error
+	}{
+		{"ValidQuery", `(Ukraine OR Poland) -Russia "prime minister"`, "", nil},
+		{"UnclosedParenthesis", "(Ukraine OR Poland", "", filters.ErrQuerySyntax},
+		{"UnterminatedPhrase", `"prime minister`, "", filters.ErrQuerySyntax},
+		{"EmptyKeywords", "", "", nil},
+		{"ValidPattern", "COVID-?19", "regex", nil},
+		{"InvalidPattern", `"COVID-(19"`, "regex", filters.ErrInvalidPattern},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &KeywordsValidationHandler{
+				keywords:  tt.keywords,
+				matchMode: tt.matchMode,
+			}
+
+			err := handler.Handle()
+			if tt.expectErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, ErrFailedKeywordsValidation)
+			assert.ErrorContains(t, err, tt.expectErr.Error())
+		})
+	}
+}
+
+func TestMatchModeValidationHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		matchMode string
+		expectErr bool
+	}{
+		{"EmptyMode", "", false},
+		{"CaseInsensitive", "case-insensitive", false},
+		{"WholeWord", "whole-word", false},
+		{"UnknownMode", "fuzzy", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &MatchModeValidationHandler{
+				matchMode: tt.matchMode,
+			}
+
+			err := handler.Handle()
+			if !tt.expectErr {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, ErrFailedMatchModeValidation)
+		})
+	}
+}
+
 func TestValidateDate(t *testing.T) {
 	tests := []struct {
 		dateStr string
@@ -109,6 +208,14 @@ func TestValidateDate(t *testing.T) {
 		{"", false},
 		{"2023/01/01", true},
 		{"01-01-2023", true},
+		{"2023-01-01T10:00:00Z", false},
+		{"2023-01-01T10:00", false},
+		{"10:30", false},
+		{"now", false},
+		{"7d", false},
+		{"-2w", false},
+		{"-6x", true},
+		{"d", true},
 	}
 
 	for _, tt := range tests {
@@ -139,6 +246,45 @@ func TestValidateSources(t *testing.T) {
 	}
 }
 
+func TestValidateSort(t *testing.T) {
+	tests := []struct {
+		sort      string
+		keywords  string
+		expectErr error
+	}{
+		{"", "", nil},
+		{"relevance", "ukraine,election", nil},
+		{"date_desc", "", nil},
+		{"date_asc", "", nil},
+		{"source", "ukraine", nil},
+		{"relevance", "", errors.New(ErrRelevanceWithoutKeywords)},
+		{"relevance", ",", errors.New(ErrRelevanceWithoutKeywords)},
+		{"popularity", "ukraine", errors.New(ErrUnsupportedSort)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expectErr, BySort(tt.sort, tt.keywords))
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	tests := []struct {
+		languages string
+		expectErr error
+	}{
+		{"", nil},
+		{"en", nil},
+		{"en, uk", nil},
+		{"en-GB", nil},
+		{"english", errors.New(ErrFailedLanguageValidation + "english")},
+		{"en,", errors.New(ErrFailedLanguageValidation)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expectErr, ByLanguage(tt.languages))
+	}
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		slice  []string
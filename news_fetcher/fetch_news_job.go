@@ -1,45 +1,68 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"gogator/cmd/filters"
 	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
 	"gogator/cmd/types"
 	"log"
-	"os"
-	"path/filepath"
 	"time"
 )
 
 // NewsFetchingJob struct is used to fetch and parse articles feeds,
-// and then writes the parsed data to a JSON file named with the current date
+// and then stores the parsed data in the articles storage
 //
-// Using Kubernetes CronJob object, it will run once in a day, to parse
+// # Using Kubernetes CronJob object, it will run once in a day, to parse
+//
+// If strict is true, the job fails when any of the sources can not be fetched.
+// Otherwise, articles from healthy sources are stored, and failures are only reported.
 type NewsFetchingJob struct {
 	params      *types.FilteringParams
 	storagePath string
+	store       storage.ArticleStore
+	strict      bool
 }
 
 const (
-	// errCreatingFile is thrown when there was an error while creating sources file
-	errCreatingFile = "Error while creating a file: "
-
 	// errParsingSources is thrown when we have error while parsing sources
 	errParsingSources = "Error while parsing sources: "
 
-	// errMarshalData is used for better error logging when we have error during json.Marshal call
-	errMarshalData = "Error while performing JSON encoding articles: "
+	// errStoringNews is thrown when we have error while storing fetched articles
+	errStoringNews = "Error while storing articles: "
+
+	// errInitializingStorage is thrown when storage of articles can not be created
+	errInitializingStorage = "Error initializing articles storage: "
 
-	// errWritingData is thrown when we have error during writing data to the file
-	errWritingData = "Error while writing data to file: "
+	// errWritingReport is thrown when we have error while storing results of fetching
+	errWritingReport = "Error while writing fetch report: "
 
-	// errClosingFile is thrown when we have error while closing file
-	errClosingFile = "Error closing file: "
+	// errLoadingFetchCache is thrown when validators of the previous fetch can not be loaded
+	errLoadingFetchCache = "Error while loading fetch cache, all sources will be fetched in full: "
+
+	// errSavingFetchCache is thrown when validators of the responses can not be stored
+	errSavingFetchCache = "Error while saving fetch cache: "
 )
 
-// RunJob initializes and runs NewsFetchingJob, which will parse data from feeds into respective files
-func RunJob(storagePath string) error {
+// RunJob initializes and runs NewsFetchingJob, which will parse data from feeds and store them
+// in the storage of the backend inside of storagePath.
+// Cancelling ctx aborts requests to the sources, which are still in progress.
+//
+// Sources are fetched with conditional requests, so feeds which have not changed since the previous run
+// are not downloaded again.
+func RunJob(ctx context.Context, storagePath, backend string, strict bool) error {
+	store, err := storage.New(backend, storagePath)
+	if err != nil {
+		return errors.New(errInitializingStorage + err.Error())
+	}
+	defer store.Close()
+
+	err = parsers.EnableConditionalFetch()
+	if err != nil {
+		log.Println(errLoadingFetchCache + err.Error())
+	}
+
 	dateTimestamp := time.Now().Format(time.DateOnly)
 	job := &NewsFetchingJob{
 		params: types.NewFilteringParams("",
@@ -47,9 +70,11 @@ func RunJob(storagePath string) error {
 			"",
 			""),
 		storagePath: storagePath,
+		store:       store,
+		strict:      strict,
 	}
 
-	err := job.Execute()
+	err = job.Execute(ctx)
 	if err != nil {
 		return err
 	}
@@ -57,41 +82,52 @@ func RunJob(storagePath string) error {
 	return nil
 }
 
-// Execute is a function that fetches news, parses it, and writes the parsed data
-// to a JSON file named with the current date in the format YYYY-MM-DD.
-func (j *NewsFetchingJob) Execute() error {
-	articleFilepath := filepath.Join(
-		j.storagePath,
-		j.params.StartingTimestamp+".json")
-
-	articlesFile, err := os.Create(articleFilepath)
+// Execute is a function that fetches news, parses it, and stores the parsed data.
+//
+// Result of fetching every source is logged and stored in the fetch report inside of the storage path.
+// Stored articles are updated, and new ones are added, so every article is stored once.
+// Articles of sources, which have not changed since the previous run, are kept as they are.
+func (j *NewsFetchingJob) Execute(ctx context.Context) error {
+	news, results, fetchErr := parsers.FetchBySource(ctx, parsers.AllSources, j.strict)
+	logFetchResults(results)
+
+	err := parsers.WriteFetchReport(j.storagePath, results)
 	if err != nil {
-		return errors.New(errCreatingFile + err.Error())
+		log.Println(errWritingReport + err.Error())
 	}
 
-	defer func(articlesFile *os.File) {
-		err = articlesFile.Close()
-		if err != nil {
-			log.Fatalln(errClosingFile + err.Error())
-		}
-	}(articlesFile)
-
-	news, err := parsers.ParseBySource(parsers.AllSources)
-	if err != nil {
-		return errors.New(errParsingSources + err.Error())
+	if fetchErr != nil {
+		return errors.New(errParsingSources + fetchErr.Error())
 	}
 
 	news = filters.Apply(news, j.params)
 
-	articlesData, err := json.Marshal(news)
+	err = j.store.Upsert(news)
 	if err != nil {
-		return errors.New(errMarshalData + err.Error())
+		return errors.New(errStoringNews + err.Error())
 	}
 
-	_, err = articlesFile.Write(articlesData)
+	err = parsers.SaveFetchCache()
 	if err != nil {
-		return errors.New(errWritingData + err.Error())
+		log.Println(errSavingFetchCache + err.Error())
 	}
 
 	return nil
 }
+
+// logFetchResults logs amount of articles and duration of every fetched source, or the reason of its failure
+func logFetchResults(results []types.FetchResult) {
+	for _, result := range results {
+		if result.Failed() {
+			log.Printf("Failed to fetch %s after %v: %s\n", result.Source, result.Duration, result.Error)
+			continue
+		}
+
+		if result.NotModified {
+			log.Printf("Source %s is not modified since the last fetch\n", result.Source)
+			continue
+		}
+
+		log.Printf("Fetched %d articles from %s in %v\n", result.Articles, result.Source, result.Duration)
+	}
+}
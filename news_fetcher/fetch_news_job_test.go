@@ -1,48 +1,53 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
 	"gogator/cmd/types"
-	"os"
-	"path/filepath"
 	"testing"
 	"time"
 )
 
-func TestRunJob(t *testing.T) {
+// useTempStorage points parsers to a temporary directory, so sources file and fetch cache,
+// written by the job, do not end up in the package directory
+func useTempStorage(t *testing.T) string {
 	storagePath := parsers.StoragePath
+	t.Cleanup(func() {
+		parsers.StoragePath = storagePath
+	})
+
+	parsers.StoragePath = t.TempDir()
+	return parsers.StoragePath
+}
+
+func TestRunJob(t *testing.T) {
+	storagePath := useTempStorage(t)
 	tests := []struct {
 		name      string
-		job       *NewsFetchingJob
 		args      string
+		backend   string
 		expectErr bool
 		setup     func()
 		finish    func()
 	}{
 		{
-			name: "Successful job execution",
-			args: storagePath,
-			job: &NewsFetchingJob{
-				params: types.NewFilteringParams("", time.Now().Format("2006-01-02"), "", ""),
-			},
+			name:      "Successful job execution",
+			args:      storagePath,
+			backend:   storage.JsonBackend,
 			expectErr: false,
 			setup:     func() {},
 			finish:    func() {},
 		},
 		{
-			name: "Invalid storage path",
-			job: &NewsFetchingJob{
-				params: types.NewFilteringParams("", time.Now().Format("2006-01-02"), "", ""),
-			},
-			args:      "\\invalid-path\\invalid-dir\\",
+			name:      "Unknown storage backend",
+			args:      storagePath,
+			backend:   "yaml",
 			expectErr: true,
-			setup: func() {
-				parsers.StoragePath = ""
-			},
-			finish: func() {
-				parsers.StoragePath = storagePath
-			},
+			setup:     func() {},
+			finish:    func() {},
 		},
 	}
 
@@ -50,7 +55,7 @@ func TestRunJob(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 
-			err := RunJob(tt.args)
+			err := RunJob(context.Background(), tt.args, tt.backend, false)
 
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -63,28 +68,21 @@ func TestRunJob(t *testing.T) {
 	}
 }
 
+// failingStore is an ArticleStore, which fails to store articles
+type failingStore struct {
+	storage.ArticleStore
+}
+
+func (s failingStore) Upsert(articles []types.Article) error {
+	return errors.New("storage is not available")
+}
+
 func TestFetchingJob_Execute(t *testing.T) {
-	storagePath := parsers.StoragePath
-	tempDir, err := os.MkdirTemp("", "test_execute")
-	if err != nil {
-		t.Fatalf("failed to create temp directory: %v", err)
-	}
-	defer func(path string) {
-		time.Sleep(time.Second * 5)
-		err := os.Remove(filepath.Join(path, time.Now().Format(time.DateOnly)+".json"))
-		if err != nil {
-			t.Fatalf("failed to remove data file: %v", err)
-		}
-		err = os.RemoveAll(path)
-		if err != nil {
-			t.Fatalf("failed to remove temp directory: %v", err)
-		}
-	}(tempDir)
+	tempDir := useTempStorage(t)
 
 	testCases := []struct {
 		name      string
 		job       *NewsFetchingJob
-		args      string
 		expectErr bool
 		setup     func()
 		finish    func()
@@ -93,31 +91,18 @@ func TestFetchingJob_Execute(t *testing.T) {
 			name: "Default job run",
 			job: &NewsFetchingJob{
 				params: types.NewFilteringParams("", time.Now().Format(time.DateOnly), "", ""),
+				store:  storage.NewJsonStore(tempDir),
 			},
-			args:      storagePath,
 			expectErr: false,
 			setup:     func() {},
-			finish: func() {
-				err := os.Remove(time.Now().Format(time.DateOnly) + ".json")
-				assert.Nil(t, err)
-			},
-		},
-		{
-			name: "Invalid storage path",
-			job: &NewsFetchingJob{
-				params: types.NewFilteringParams("", time.Now().Format(time.DateOnly), "", ""),
-			},
-			args:      "\\invalid-path\\invalid-dir\\",
-			expectErr: true,
-			setup:     func() {},
 			finish:    func() {},
 		},
 		{
-			name: "File creation error",
+			name: "Storage error",
 			job: &NewsFetchingJob{
-				params: types.NewFilteringParams("", string([]byte{0x00, 0x3C, 0x3E, 0x7C}), "", ""),
+				params: types.NewFilteringParams("", time.Now().Format(time.DateOnly), "", ""),
+				store:  failingStore{},
 			},
-			args:      tempDir,
 			expectErr: true,
 			setup:     func() {},
 			finish:    func() {},
@@ -126,8 +111,9 @@ func TestFetchingJob_Execute(t *testing.T) {
 			name: "Parse by source error",
 			job: &NewsFetchingJob{
 				params: types.NewFilteringParams("", time.Now().Format(time.DateOnly), "", ""),
+				store:  storage.NewJsonStore(tempDir),
+				strict: true,
 			},
-			args:      tempDir,
 			expectErr: true,
 			setup: func() {
 				err := parsers.AddNewSource("xml", "nonexistent", "nonexistent")
@@ -144,9 +130,9 @@ func TestFetchingJob_Execute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setup()
 			defer tc.finish()
-			tc.job.storagePath = tc.args
+			tc.job.storagePath = tempDir
 
-			err := tc.job.Execute()
+			err := tc.job.Execute(context.Background())
 			if tc.expectErr {
 				assert.NotNil(t, err)
 				return
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"gogator/cmd/config"
+	"gogator/cmd/parsers"
+	"gogator/cmd/storage"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 const (
@@ -11,13 +18,53 @@ const (
 )
 
 func main() {
-	var storagePath string
+	var (
+		configFile     string
+		storagePath    string
+		storageBackend string
+		strict         bool
+	)
+
+	flag.StringVar(&configFile, "config", "",
+		"Path to YAML configuration file, which fetch settings and sources are read from. "+
+			"Environment variable "+config.EnvConfigFile+" is used, if empty")
 
 	flag.StringVar(&storagePath, "fs", defaultStoragePath,
 		"Path to directory where all data will be stored")
+	flag.StringVar(&storageBackend, "storage", storage.DefaultBackend,
+		"Storage of articles inside of the data directory: json (file per day) or bolt (single database file)")
+	flag.BoolVar(&strict, "strict", false,
+		"Fail the job if any of the sources can not be fetched")
 	flag.Parse()
 
-	err := RunJob(storagePath)
+	conf, err := config.Load(configFile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// storage of the job is specified only by its flags
+	conf.Storage = config.Storage{Path: storagePath, Backend: storageBackend}
+	err = conf.ValidateFetching()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	parsers.ConfigureFetcher(conf.FetcherConfig())
+	if len(conf.Sources) != 0 {
+		err = parsers.SetDefaultSources(conf.Feeds())
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	// sources and fetch cache are kept next to the stored articles
+	parsers.StoragePath = storagePath
+
+	// Kubernetes sends SIGTERM, when the job is deleted or its deadline is exceeded
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	err = RunJob(ctx, storagePath, storageBackend, strict)
 
 	if err != nil {
 		log.Fatalln(err)
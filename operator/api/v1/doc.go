@@ -22,7 +22,9 @@ The mutating webhook sets the default values for the HotNews resource, if they a
 
 The validating webhook ensures that the HotNews resources meet the required validation criteria, in particular:
 - Either Feeds or FeedGroups should be specified.
-- DateStart should be before DateEnd.
+- DateStart and DateEnd are in one of the supported formats, and DateStart is not after DateEnd.
+- Timezone is a known IANA time zone name.
+- Languages are ISO 639-1 codes.
 - All feed names should be correct.
 - Keywords are not empty.
 */
@@ -53,17 +53,33 @@ const (
 // For example, we can specify keywords, date range, feeds and feed groups
 // And then we will make requests to our news aggregator server with this parameters, and get the news
 type HotNewsSpec struct {
-	// Keywords is a comma-separated list of keywords which will be used to search news
+	// Keywords is a list of keywords queries which will be used to search news.
+	// Every item is a query of the news aggregator server, e.g. `bitcoin -scam` or `"central bank" AND rates`,
+	// and news matching any of them are retrieved. Invalid queries are rejected by the server.
 	// +kubebuilder:validation:Required
 	Keywords []string `json:"keywords"`
 
-	// DateStart is a news starting date in format "YYYY-MM-DD", can be empty
+	// DateStart is a news starting date, can be empty. Besides "YYYY-MM-DD", it accepts RFC 3339 timestamps,
+	// times of today like "09:00", "now", "today", "yesterday" and relative dates like "-6h" or "7d".
+	// Relative dates are resolved by the server on every request, so the range moves with time.
 	// +optional
 	DateStart string `json:"dateStart,omitempty"`
 
-	// DateEnd is a news final date in format "YYYY-MM-DD", can be empty
+	// DateEnd is a news final date in the same formats as DateStart, can be empty.
+	// Dates, today and yesterday include the whole day.
+	// +optional
 	DateEnd string `json:"dateEnd,omitempty"`
 
+	// Timezone is an IANA time zone name, e.g. "Europe/Kyiv", which dates and times without offset
+	// are interpreted in. UTC is used by default.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Languages is a list of ISO 639-1 codes, e.g. "en" or "uk". If specified, only news
+	// written in any of these languages are retrieved.
+	// +optional
+	Languages []string `json:"languages,omitempty"`
+
 	// Feeds is a list of Feeds CRD, which will be used to subscribe to news
 	// +optional
 	Feeds []string `json:"feeds,omitempty"`
@@ -2,15 +2,25 @@ package v1
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	// urlValidationError is a constant that represents the error message for invalid url
 	urlValidationError = "url must contain http or https"
+
+	// languageValidationError is a constant that represents the error message for invalid language code
+	languageValidationError = "language must be an ISO 639-1 code, e.g. en or uk: "
 )
 
+// languageCodePattern matches language tags like "en", "uk" or "en-GB", the same way the news aggregator server does
+var languageCodePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{1,8})*$`)
+
 // validateFeedSpec function initializes a chain from all existing validation handlers
 // and returns an error if any of the handlers fails
 func validateFeedSpec(feed FeedSpec) error {
@@ -20,7 +30,12 @@ func validateFeedSpec(feed FeedSpec) error {
 }
 
 func validateHotNewsSpec(hotNewsSpec HotNewsSpec) error {
-	dateValidationHandler := &dateValidate{dateStart: hotNewsSpec.DateStart, dateEnd: hotNewsSpec.DateEnd}
+	dateValidationHandler := &dateValidate{
+		dateStart: hotNewsSpec.DateStart,
+		dateEnd:   hotNewsSpec.DateEnd,
+		timezone:  hotNewsSpec.Timezone,
+	}
+	dateValidationHandler.SetNext(&languageValidate{languages: hotNewsSpec.Languages})
 
 	return dateValidationHandler.Validate()
 }
@@ -67,27 +82,125 @@ func (u *urlValidate) Validate() error {
 }
 
 // dateValidate struct is used to check if the start date is before the end date
-// and if the date format is correct
+// and if the date format and the time zone are correct. Both dates can be empty.
 type dateValidate struct {
 	baseHandler
 	dateStart string
 	dateEnd   string
+	timezone  string
 }
 
 func (d *dateValidate) Validate() error {
-	if d.dateStart > d.dateEnd {
-		return errors.New(errInvalidDateRange)
+	location := time.UTC
+	if d.timezone != "" {
+		var err error
+		location, err = time.LoadLocation(d.timezone)
+		if err != nil {
+			return errors.New("invalid time zone: " + err.Error())
+		}
 	}
 
-	_, err := time.Parse(time.DateOnly, d.dateStart)
+	now := time.Now()
+
+	start, err := parseDateBound(d.dateStart, location, now, false)
 	if err != nil {
 		return errors.New("invalid start date format: " + err.Error())
 	}
 
-	_, err = time.Parse(time.DateOnly, d.dateEnd)
+	end, err := parseDateBound(d.dateEnd, location, now, true)
 	if err != nil {
 		return errors.New("invalid end date format: " + err.Error())
 	}
 
+	if !start.IsZero() && !end.IsZero() && start.After(end) {
+		return errors.New(errInvalidDateRange)
+	}
+
 	return d.HandleNext()
 }
+
+// languageValidate struct is used to check if every language is a language code
+type languageValidate struct {
+	baseHandler
+	languages []string
+}
+
+func (l *languageValidate) Validate() error {
+	for _, language := range l.languages {
+		if !languageCodePattern.MatchString(language) {
+			return errors.New(languageValidationError + language)
+		}
+	}
+
+	return l.HandleNext()
+}
+
+// relativeUnits are units of relative dates, e.g. "-6h" or "7d"
+var relativeUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseDateBound parses a bound of the date range in the same formats as the news aggregator server does:
+// dates, RFC 3339 timestamps, dates and times without offset, times of today, "now", "today", "yesterday"
+// and relative dates like "-6h" or "7d". Dates, today and yesterday mean the whole day, so at the end
+// of the range they are parsed as the end of the day. Empty value is parsed as zero time.
+func parseDateBound(value string, location *time.Location, now time.Time, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	localNow := now.In(location)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, location)
+	dayBound := func(date time.Time) time.Time {
+		if end {
+			return date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return date
+	}
+
+	switch strings.ToLower(value) {
+	case "now":
+		return now, nil
+	case "today":
+		return dayBound(today), nil
+	case "yesterday":
+		return dayBound(today.AddDate(0, 0, -1)), nil
+	}
+
+	if date, err := time.ParseInLocation(time.DateOnly, value, location); err == nil {
+		return dayBound(date), nil
+	}
+
+	if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return date, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", time.DateTime, "2006-01-02 15:04"} {
+		if date, err := time.ParseInLocation(layout, value, location); err == nil {
+			return date, nil
+		}
+	}
+
+	for _, layout := range []string{time.TimeOnly, "15:04"} {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return time.Date(today.Year(), today.Month(), today.Day(),
+				clock.Hour(), clock.Minute(), clock.Second(), 0, location), nil
+		}
+	}
+
+	relative := strings.TrimPrefix(value, "-")
+	if len(relative) > 1 {
+		unit, exists := relativeUnits[relative[len(relative)-1]]
+		amount, err := strconv.Atoi(relative[:len(relative)-1])
+		if exists && err == nil && amount >= 0 {
+			return now.Add(-time.Duration(amount) * unit), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date %q: expected YYYY-MM-DD, RFC 3339 timestamp, HH:MM, "+
+		"now, today, yesterday or relative duration like -6h or 7d", value)
+}
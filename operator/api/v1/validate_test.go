@@ -102,6 +102,7 @@ func Test_dateValidate_Validate(t *testing.T) {
 		baseHandler baseHandler
 		dateStart   string
 		dateEnd     string
+		timezone    string
 	}
 	tests := []struct {
 		name    string
@@ -143,6 +144,64 @@ func Test_dateValidate_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Empty dates",
+			fields: fields{
+				baseHandler: baseHandler{},
+			},
+		},
+		{
+			name: "Same day",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "2024-06-01",
+				dateEnd:     "2024-06-01",
+			},
+		},
+		{
+			name: "Relative dates and time zone",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "-7d",
+				dateEnd:     "today",
+				timezone:    "Europe/Kyiv",
+			},
+		},
+		{
+			name: "Timestamps",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "2024-06-01T09:00",
+				dateEnd:     "2024-06-01T12:00:00+03:00",
+				timezone:    "Europe/Kyiv",
+			},
+		},
+		{
+			name: "Invalid range of timestamps",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "2024-06-01T10:00:00Z",
+				dateEnd:     "2024-06-01T12:00:00+03:00",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported relative unit",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "-6y",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown time zone",
+			fields: fields{
+				baseHandler: baseHandler{},
+				dateStart:   "today",
+				timezone:    "Mars/Olympus",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -150,6 +209,7 @@ func Test_dateValidate_Validate(t *testing.T) {
 				baseHandler: tt.fields.baseHandler,
 				dateStart:   tt.fields.dateStart,
 				dateEnd:     tt.fields.dateEnd,
+				timezone:    tt.fields.timezone,
 			}
 			got := d.Validate()
 			if tt.wantErr {
@@ -190,6 +250,24 @@ func Test_validateHotNews(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid languages",
+			args: args{
+				hotNewsSpec: HotNewsSpec{
+					Languages: []string{"en", "uk-UA"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid language",
+			args: args{
+				hotNewsSpec: HotNewsSpec{
+					Languages: []string{"english"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,6 +212,11 @@ func (in *HotNewsSpec) DeepCopyInto(out *HotNewsSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.SummaryConfig = in.SummaryConfig
 }
 
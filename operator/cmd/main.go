@@ -66,6 +66,13 @@ const (
 
 	// defaultEnableHttp2 is the default value for enabling HTTP/2
 	defaultEnableHttp2 = false
+
+	// defaultCredentialsSecret is the default name of the Secret with credentials of the news aggregator server.
+	// Empty name means that requests are sent without credentials.
+	defaultCredentialsSecret = ""
+
+	// defaultCredentialsNamespace is the default namespace of the Secret with credentials of the news aggregator server
+	defaultCredentialsNamespace = "operator-system"
 )
 
 func init() {
@@ -84,6 +91,8 @@ func main() {
 		enableLeaderElection bool
 		secureMetrics        bool
 		enableHTTP2          bool
+		credentialsSecret    string
+		credentialsNamespace string
 		tlsOpts              []func(*tls.Config)
 	)
 	flag.StringVar(&feedManagementUrl, "server-addr", defaultFeedManagementUrl, "The address of the news aggregator service, to perform CRUD operations on feeds.")
@@ -98,6 +107,10 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", defaultEnableHttp2,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&credentialsSecret, "server-credentials-secret", defaultCredentialsSecret,
+		"Name of the Secret with api-key or token, which are sent to the news aggregator server.")
+	flag.StringVar(&credentialsNamespace, "server-credentials-namespace", defaultCredentialsNamespace,
+		"Namespace of the Secret with credentials of the news aggregator server.")
 
 	opts := zap.Options{
 		Development: true,
@@ -144,16 +157,24 @@ func main() {
 	}
 	newsaggregatorv1.SetupClient(mgr.GetClient())
 
+	credentials := controller.ServerCredentials{
+		Reader:    mgr.GetAPIReader(),
+		Namespace: credentialsNamespace,
+		Name:      credentialsSecret,
+	}
+
 	if err = (&controller.FeedReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Credentials: credentials,
 	}).SetupWithManager(mgr, feedManagementUrl); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Feed")
 		os.Exit(1)
 	}
 	if err = (&controller.HotNewsReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Credentials: credentials,
 	}).SetupWithManager(mgr, newsFetchingUrl); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HotNews")
 		os.Exit(1)
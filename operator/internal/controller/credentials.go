@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// APIKeySecretKey is the key of the API key in the Secret with credentials of the news aggregator server
+	APIKeySecretKey = "api-key"
+
+	// TokenSecretKey is the key of the bearer token in the Secret with credentials of the news aggregator server
+	TokenSecretKey = "token"
+
+	// apiKeyHeader is the header, which the news aggregator server reads API keys from
+	apiKeyHeader = "X-API-Key"
+
+	// errReadingCredentials is thrown when the Secret with credentials can not be read
+	errReadingCredentials = "Error while trying to read server credentials: "
+)
+
+// ServerCredentials refers to the Secret with credentials, which are sent to the news aggregator server.
+//
+// The Secret should contain either api-key, which is sent in X-API-Key header, or token, which is sent
+// as bearer token. The Secret is read on every request, so rotated credentials are used without
+// restarting the operator. If Name is empty, requests are sent without credentials.
+type ServerCredentials struct {
+	// Reader is used to read the Secret. It should not be a cached client, so the operator
+	// does not need to watch all Secrets of the cluster.
+	Reader client.Reader
+
+	// Namespace of the Secret
+	Namespace string
+
+	// Name of the Secret
+	Name string
+}
+
+// authorize adds credentials of the Secret to the request
+func (c ServerCredentials) authorize(ctx context.Context, req *http.Request) error {
+	if c.Name == "" {
+		return nil
+	}
+
+	if c.Reader == nil {
+		return errors.New(errReadingCredentials + "client is not set")
+	}
+
+	var secret v1.Secret
+	err := c.Reader.Get(ctx, client.ObjectKey{Namespace: c.Namespace, Name: c.Name}, &secret)
+	if err != nil {
+		return errors.New(errReadingCredentials + err.Error())
+	}
+
+	if key := secret.Data[APIKeySecretKey]; len(key) != 0 {
+		req.Header.Set(apiKeyHeader, string(key))
+		return nil
+	}
+
+	if token := secret.Data[TokenSecretKey]; len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+string(token))
+		return nil
+	}
+
+	return fmt.Errorf("%ssecret %s/%s has neither %s nor %s", errReadingCredentials,
+		c.Namespace, c.Name, APIKeySecretKey, TokenSecretKey)
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestServerCredentials_authorize(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := func(data map[string][]byte) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "operator-system", Name: "credentials"},
+			Data:       data,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		credentials    func() ServerCredentials
+		expectedApiKey string
+		expectedAuth   string
+		expectedErr    bool
+	}{
+		{
+			name: "No secret configured",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{}
+			},
+		},
+		{
+			name: "API key",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{
+					Reader: fake.NewClientBuilder().WithScheme(scheme).
+						WithObjects(secret(map[string][]byte{APIKeySecretKey: []byte("key")})).Build(),
+					Namespace: "operator-system",
+					Name:      "credentials",
+				}
+			},
+			expectedApiKey: "key",
+		},
+		{
+			name: "Bearer token",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{
+					Reader: fake.NewClientBuilder().WithScheme(scheme).
+						WithObjects(secret(map[string][]byte{TokenSecretKey: []byte("token")})).Build(),
+					Namespace: "operator-system",
+					Name:      "credentials",
+				}
+			},
+			expectedAuth: "Bearer token",
+		},
+		{
+			name: "Secret without credentials",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{
+					Reader:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret(nil)).Build(),
+					Namespace: "operator-system",
+					Name:      "credentials",
+				}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Secret does not exist",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{
+					Reader:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+					Namespace: "operator-system",
+					Name:      "credentials",
+				}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "Reader is not set",
+			credentials: func() ServerCredentials {
+				return ServerCredentials{Name: "credentials"}
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "http://example.com/news", nil)
+			assert.Nil(t, err)
+
+			err = tt.credentials().authorize(context.Background(), req)
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedApiKey, req.Header.Get(apiKeyHeader))
+			assert.Equal(t, tt.expectedAuth, req.Header.Get("Authorization"))
+		})
+	}
+}
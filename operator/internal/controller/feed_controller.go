@@ -39,6 +39,9 @@ type FeedReconciler struct {
 	serverAddress string
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Credentials are sent to the news aggregator server, which requires admin role to manage sources
+	Credentials ServerCredentials
 }
 
 const (
@@ -101,7 +104,7 @@ func (r *FeedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	} else {
 		if controllerutil.ContainsFinalizer(&feed, feedFinalizerName) {
 			logger.Info("Handling the delete event")
-			if err = r.handleDelete(&feed); err != nil {
+			if err = r.handleDelete(ctx, &feed); err != nil {
 				return ctrl.Result{}, err
 			}
 
@@ -120,10 +123,10 @@ func (r *FeedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	if isNew {
 		logger.Info("Handling the create event")
-		err = r.handleCreate(&feed)
+		err = r.handleCreate(ctx, &feed)
 	} else {
 		logger.Info("Handling the update event")
-		err = r.handleUpdate(&feed)
+		err = r.handleUpdate(ctx, &feed)
 	}
 
 	if err != nil {
@@ -167,8 +170,9 @@ type sourceBody struct {
 // handleCreate makes a request to the news-aggregator service to create a new feed when a new Feed object is instantiated.
 // It constructs a Feed object from the Feed specifications, marshals it to JSON, and sends a POST request with the JSON payload.
 // The function handles potential errors in JSON marshalling, request creation, and the HTTP request itself.
+// Credentials of the Secret are added to the request.
 // If the server responds with a status other than 201 Created, it attempts to decode and print the server's error message.
-func (r *FeedReconciler) handleCreate(feed *newsaggregatorv1.Feed) error {
+func (r *FeedReconciler) handleCreate(ctx context.Context, feed *newsaggregatorv1.Feed) error {
 	source := sourceBody{
 		Name:     feed.Spec.Name,
 		Endpoint: feed.Spec.Link,
@@ -185,6 +189,11 @@ func (r *FeedReconciler) handleCreate(feed *newsaggregatorv1.Feed) error {
 		return errors.New(errCreatingRequest + err.Error())
 	}
 
+	err = r.Credentials.authorize(ctx, req)
+	if err != nil {
+		return err
+	}
+
 	customTransport := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
@@ -214,8 +223,9 @@ func (r *FeedReconciler) handleCreate(feed *newsaggregatorv1.Feed) error {
 // handleUpdate makes a request to the news-aggregator service to update an existing feed when the Feed object is modified.
 // It constructs a Feed object from the Feed specifications, marshals it to JSON, and sends a PUT request with the JSON payload.
 // This function handles potential errors in JSON marshalling, request creation, and the HTTP request itself.
+// Credentials of the Secret are added to the request.
 // If the server responds with a status other than 200 OK, it attempts to decode and print the server's error message.
-func (r *FeedReconciler) handleUpdate(feed *newsaggregatorv1.Feed) error {
+func (r *FeedReconciler) handleUpdate(ctx context.Context, feed *newsaggregatorv1.Feed) error {
 	source := sourceBody{
 		Name:     feed.Spec.Name,
 		Endpoint: feed.Spec.Link,
@@ -233,6 +243,11 @@ func (r *FeedReconciler) handleUpdate(feed *newsaggregatorv1.Feed) error {
 		return errors.New(errCreatingRequest + err.Error())
 	}
 
+	err = r.Credentials.authorize(ctx, req)
+	if err != nil {
+		return err
+	}
+
 	customTransport := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
@@ -263,8 +278,9 @@ func (r *FeedReconciler) handleUpdate(feed *newsaggregatorv1.Feed) error {
 // handleDelete makes a request to the news-aggregator service to delete an existing feed based on the Feed object.
 // It constructs a Feed object from the Feed specifications, marshals it to JSON, and sends a DELETE request with the JSON payload.
 // This function handles potential errors in JSON marshalling, request creation, and the HTTP request itself.
+// Credentials of the Secret are added to the request.
 // If the server responds with a status other than 200 OK, it attempts to decode and print the server's error message.
-func (r *FeedReconciler) handleDelete(feed *newsaggregatorv1.Feed) error {
+func (r *FeedReconciler) handleDelete(ctx context.Context, feed *newsaggregatorv1.Feed) error {
 	source := sourceBody{
 		Name:     feed.Spec.Name,
 		Endpoint: feed.Spec.Link,
@@ -282,6 +298,11 @@ func (r *FeedReconciler) handleDelete(feed *newsaggregatorv1.Feed) error {
 		return errors.New(errCreatingRequest + err.Error())
 	}
 
+	err = r.Credentials.authorize(ctx, req)
+	if err != nil {
+		return err
+	}
+
 	customTransport := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
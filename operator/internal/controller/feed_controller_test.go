@@ -362,7 +362,7 @@ func TestFeedReconciler_handleCreate(t *testing.T) {
 				defer tt.mockServer.Close()
 			}
 
-			err := r.handleCreate(tt.feed)
+			err := r.handleCreate(context.Background(), tt.feed)
 
 			if tt.expectedErr {
 				assert.NotNil(t, err)
@@ -385,7 +385,7 @@ func TestFeedReconciler_handleDelete(t *testing.T) {
 		{
 			name: "Successful delete",
 			setup: func(r *FeedReconciler) {
-				err := r.handleCreate(&newsaggregatorv1.Feed{
+				err := r.handleCreate(context.Background(), &newsaggregatorv1.Feed{
 					Spec: newsaggregatorv1.FeedSpec{
 						Name: "Test Feed",
 						Link: "http://example.com",
@@ -477,7 +477,7 @@ func TestFeedReconciler_handleDelete(t *testing.T) {
 		{
 			name: "Server returns error",
 			setup: func(r *FeedReconciler) {
-				err := r.handleCreate(&newsaggregatorv1.Feed{
+				err := r.handleCreate(context.Background(), &newsaggregatorv1.Feed{
 					Spec: newsaggregatorv1.FeedSpec{
 						Name: "Test Feed",
 						Link: "http://example.com",
@@ -511,7 +511,7 @@ func TestFeedReconciler_handleDelete(t *testing.T) {
 
 			tt.setup(r)
 
-			err := r.handleDelete(tt.feed)
+			err := r.handleDelete(context.Background(), tt.feed)
 
 			if tt.expectedErr {
 				assert.NotEqual(t, err.Error(), "")
@@ -604,7 +604,7 @@ func TestFeedReconciler_handleUpdate(t *testing.T) {
 				defer tt.mockServer.Close()
 			}
 
-			err := r.handleUpdate(tt.feed)
+			err := r.handleUpdate(context.Background(), tt.feed)
 
 			if tt.expectedErr {
 				assert.NotEqual(t, err.Error(), "")
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"net/http"
+	"net/url"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"strconv"
 	"strings"
 	newsaggregatorv1 "teamdev.com/go-gator/api/v1"
 )
@@ -58,6 +60,9 @@ const (
 
 	// errWrongFeedGroupName is returned when the feed group name is wrong
 	errWrongFeedGroupName = "wrong feed group name, please check the feed group name and try again"
+
+	// serverMaxLimit is the biggest amount of news, which the news aggregator server returns in one response
+	serverMaxLimit = 1000
 )
 
 // HotNewsReconciler reconciles a HotNews object
@@ -75,6 +80,9 @@ type HotNewsReconciler struct {
 	serverUrl string
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Credentials are sent to the news aggregator server, which requires reader role to retrieve news
+	Credentials ServerCredentials
 }
 
 // +kubebuilder:rbac:groups=newsaggregator.teamdev.com,resources=hotnews;feeds,verbs=get;list;watch;create;update;patch;delete
@@ -245,6 +253,9 @@ type article struct {
 // The function constructs the request URL based on the HotNews and ConfigMap data,
 // sends the request to an external server, and handles the server response.
 //
+// Only the first page of news is requested, which contains as many titles as the summary shows,
+// while the total count of matching news is returned by the server separately.
+//
 // If the response is successful, it decodes the JSON response body containing articles,
 // processes the data (e.g., titles and total count), and updates the HotNews object's status with this information.
 //
@@ -261,12 +272,18 @@ func (r *HotNewsReconciler) processHotNews(ctx context.Context, hotNews *newsagg
 	}
 	logger.Info(requestUrl)
 
-	req, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+	req, err := http.NewRequest(http.MethodGet, firstPageUrl(requestUrl, hotNews.Spec.SummaryConfig.TitlesCount), nil)
 	if err != nil {
 		logger.Error(err, errFailedToCreateRequest)
 		return err
 	}
 
+	err = r.Credentials.authorize(ctx, req)
+	if err != nil {
+		logger.Error(err, errReadingCredentials)
+		return err
+	}
+
 	customTransport := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
@@ -319,23 +336,29 @@ func (r *HotNewsReconciler) processHotNews(ctx context.Context, hotNews *newsagg
 	return nil
 }
 
+// firstPageUrl returns the request URL limited to the amount of news shown in the summary.
+// If the amount is not positive, the server returns its default amount of news.
+func firstPageUrl(requestUrl string, titlesCount int) string {
+	if titlesCount <= 0 {
+		return requestUrl
+	}
+
+	return requestUrl + "&limit=" + strconv.Itoa(min(titlesCount, serverMaxLimit))
+}
+
 // constructRequestUrl function verifies if arguments are correct and constructs a request URL
 // to our news aggregator server.
 //
 // Example:
-// http://server.com/news?keywords=bitcoin&dateFrom=2024-08-05&dateEnd=2024-08-06&sources=abc,bbc
-// http://server.com/news?keywords=bitcoin&dateFrom=2024-08-05&sources=abc,bbc
+// http://server.com/news?keywords=bitcoin&sources=abc,bbc&date-from=2024-08-05&date-end=2024-08-06
+// http://server.com/news?keywords=bitcoin&sources=abc,bbc&date-from=-24h&tz=Europe%2FKyiv
+// http://server.com/news?keywords=bitcoin&sources=abc,bbc&lang=en%2Cuk
 func (r *HotNewsReconciler) constructRequestUrl(hotNews *newsaggregatorv1.HotNews,
 	configMapList v1.ConfigMapList) (string, error) {
 	var requestUrl strings.Builder
 
 	requestUrl.WriteString(r.serverUrl)
-	var keywordsStr strings.Builder
-	for _, keyword := range hotNews.Spec.Keywords {
-		keywordsStr.WriteString(keyword)
-		keywordsStr.WriteRune(',')
-	}
-	requestUrl.WriteString("?keywords=" + keywordsStr.String()[:len(keywordsStr.String())-1])
+	requestUrl.WriteString("?keywords=" + url.QueryEscape(keywordsQuery(hotNews.Spec.Keywords)))
 
 	var feedStr strings.Builder
 	if hotNews.Spec.FeedGroups != nil {
@@ -352,16 +375,42 @@ func (r *HotNewsReconciler) constructRequestUrl(hotNews *newsaggregatorv1.HotNew
 	requestUrl.WriteString("&sources=" + feedStr.String())
 
 	if hotNews.Spec.DateStart != "" {
-		requestUrl.WriteString("&dateFrom=" + hotNews.Spec.DateStart)
+		requestUrl.WriteString("&date-from=" + url.QueryEscape(hotNews.Spec.DateStart))
 	}
 
 	if hotNews.Spec.DateEnd != "" {
-		requestUrl.WriteString("&dateEnd=" + hotNews.Spec.DateEnd)
+		requestUrl.WriteString("&date-end=" + url.QueryEscape(hotNews.Spec.DateEnd))
+	}
+
+	if hotNews.Spec.Timezone != "" {
+		requestUrl.WriteString("&tz=" + url.QueryEscape(hotNews.Spec.Timezone))
+	}
+
+	if len(hotNews.Spec.Languages) != 0 {
+		requestUrl.WriteString("&lang=" + url.QueryEscape(strings.Join(hotNews.Spec.Languages, ",")))
 	}
 
 	return requestUrl.String(), nil
 }
 
+// keywordsQuery combines keywords of HotNewsSpec into a single query of the news aggregator server.
+// Every keyword can be a query on its own, e.g. `bitcoin -scam`, so they are grouped with parentheses
+// and combined with OR:
+//
+// ["bitcoin -scam", "ethereum"] -> (bitcoin -scam),(ethereum)
+func keywordsQuery(keywords []string) string {
+	if len(keywords) == 1 {
+		return keywords[0]
+	}
+
+	groups := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		groups = append(groups, "("+keyword+")")
+	}
+
+	return strings.Join(groups, ",")
+}
+
 // setSuccessfulStatus checks if the condition should be of type "Created" or "Updated".
 // It examines the current status of the HotNews object to determine if it has been successfully created before.
 // If it has been created, it updates the condition to "Updated"; otherwise, it sets the condition to "Created".
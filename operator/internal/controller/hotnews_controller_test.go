@@ -423,7 +423,7 @@ func TestHotNewsReconciler_constructRequestUrl(t *testing.T) {
 					DateEnd:   "2024-08-06",
 				},
 			},
-			want:    serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&dateFrom=2024-08-05&dateEnd=2024-08-06",
+			want:    serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&date-from=2024-08-05&date-end=2024-08-06",
 			wantErr: false,
 		},
 		{
@@ -436,7 +436,36 @@ func TestHotNewsReconciler_constructRequestUrl(t *testing.T) {
 					DateStart: "2024-08-05",
 				},
 			},
-			want:    serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&dateFrom=2024-08-05",
+			want:    serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&date-from=2024-08-05",
+			wantErr: false,
+		},
+		{
+			name:   "Valid request with relative dates and time zone",
+			fields: fields{},
+			args: args{
+				spec: newsaggregatorv1.HotNewsSpec{
+					Keywords:  []string{"bitcoin"},
+					Feeds:     []string{"abc", "bbc"},
+					DateStart: "-24h",
+					DateEnd:   "2024-08-06T18:00:00+03:00",
+					Timezone:  "Europe/Kyiv",
+				},
+			},
+			want: serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&date-from=-24h" +
+				"&date-end=2024-08-06T18%3A00%3A00%2B03%3A00&tz=Europe%2FKyiv",
+			wantErr: false,
+		},
+		{
+			name:   "Valid request with languages",
+			fields: fields{},
+			args: args{
+				spec: newsaggregatorv1.HotNewsSpec{
+					Keywords:  []string{"bitcoin"},
+					Feeds:     []string{"abc", "bbc"},
+					Languages: []string{"en", "uk"},
+				},
+			},
+			want:    serverNewsEndpoint + "?keywords=bitcoin&sources=abc,bbc&lang=en%2Cuk",
 			wantErr: false,
 		},
 		{
@@ -1083,3 +1112,60 @@ func TestSetOwnerReferenceForFeeds(t *testing.T) {
 		})
 	}
 }
+
+func TestKeywordsQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		keywords []string
+		want     string
+	}{
+		{
+			name:     "Single query",
+			keywords: []string{"bitcoin -scam"},
+			want:     "bitcoin -scam",
+		},
+		{
+			name:     "Several queries",
+			keywords: []string{"bitcoin -scam", "ethereum"},
+			want:     "(bitcoin -scam),(ethereum)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keywordsQuery(tt.keywords))
+		})
+	}
+}
+
+func TestFirstPageUrl(t *testing.T) {
+	serverNewsEndpoint := "https://go-gator-svc.go-gator.svc.cluster.local:443/news"
+
+	tests := []struct {
+		name        string
+		titlesCount int
+		want        string
+	}{
+		{
+			name:        "Limited to titles count",
+			titlesCount: 10,
+			want:        serverNewsEndpoint + "?keywords=bitcoin&limit=10",
+		},
+		{
+			name:        "Titles count is not set",
+			titlesCount: 0,
+			want:        serverNewsEndpoint + "?keywords=bitcoin",
+		},
+		{
+			name:        "Titles count is bigger than the server limit",
+			titlesCount: 5000,
+			want:        serverNewsEndpoint + "?keywords=bitcoin&limit=1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, firstPageUrl(serverNewsEndpoint+"?keywords=bitcoin", tt.titlesCount))
+		})
+	}
+}